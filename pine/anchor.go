@@ -0,0 +1,150 @@
+package pine
+
+import (
+	"fmt"
+	"time"
+)
+
+// Anchor determines when a new anchored period begins, e.g. a new session or a new week.
+// Anchored indicators reset their state at the beginning of every period.
+type Anchor interface {
+	// ID is a unique identifier of the anchor and is used as part of a cache key
+	ID() string
+
+	// Begins returns true if a new period begins at cur.
+	// prev is the time of the previous item and is zero if cur is the first item.
+	Begins(prev, cur time.Time) bool
+}
+
+// AnchorPeriod is a calendar period anchor. The location of the item's time is used to determine the boundary.
+type AnchorPeriod int
+
+const (
+	// AnchorPeriodDay begins a new period every calendar day
+	AnchorPeriodDay AnchorPeriod = iota
+	// AnchorPeriodWeek begins a new period every ISO week, which starts on Monday
+	AnchorPeriodWeek
+	// AnchorPeriodMonth begins a new period every calendar month
+	AnchorPeriodMonth
+	// AnchorPeriodQuarter begins a new period every calendar quarter
+	AnchorPeriodQuarter
+	// AnchorPeriodYear begins a new period every calendar year
+	AnchorPeriodYear
+)
+
+// ID returns the unique identifier of the anchor
+func (a AnchorPeriod) ID() string {
+	return fmt.Sprintf("anchorperiod:%d", a)
+}
+
+// Begins returns true if prev and cur are in different periods
+func (a AnchorPeriod) Begins(prev, cur time.Time) bool {
+	if prev.IsZero() {
+		return true
+	}
+	prev = prev.In(cur.Location())
+	return a.period(prev) != a.period(cur)
+}
+
+// period returns a comparable representation of the period t belongs to
+func (a AnchorPeriod) period(t time.Time) [2]int {
+	switch a {
+	case AnchorPeriodWeek:
+		y, w := t.ISOWeek()
+		return [2]int{y, w}
+	case AnchorPeriodMonth:
+		return [2]int{t.Year(), int(t.Month())}
+	case AnchorPeriodQuarter:
+		return [2]int{t.Year(), (int(t.Month()) - 1) / 3}
+	case AnchorPeriodYear:
+		return [2]int{t.Year(), 0}
+	default:
+		return [2]int{t.Year(), t.YearDay()}
+	}
+}
+
+type sessionAnchor struct {
+	open time.Duration
+	loc  *time.Location
+}
+
+// AnchorSession generates an anchor that begins a new period every trading session.
+// A session opens at open after midnight in loc, e.g. 9h30m in America/New_York.
+func AnchorSession(open time.Duration, loc *time.Location) Anchor {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return &sessionAnchor{
+		open: open,
+		loc:  loc,
+	}
+}
+
+func (a *sessionAnchor) ID() string {
+	return fmt.Sprintf("anchorsession:%d:%s", a.open, a.loc.String())
+}
+
+func (a *sessionAnchor) Begins(prev, cur time.Time) bool {
+	if prev.IsZero() {
+		return true
+	}
+	return a.session(prev) != a.session(cur)
+}
+
+// session returns the year and the day of the year the session of t opened
+func (a *sessionAnchor) session(t time.Time) [2]int {
+	t = t.In(a.loc)
+	// shift the time so that the session open becomes midnight
+	d := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, a.loc)
+	if t.Sub(d) < a.open {
+		d = d.AddDate(0, 0, -1)
+	}
+	return [2]int{d.Year(), d.YearDay()}
+}
+
+type timeAnchor struct {
+	t time.Time
+}
+
+// AnchorTime generates an anchor that begins a single period at the first item on or after t.
+// Items before t do not belong to any period.
+func AnchorTime(t time.Time) Anchor {
+	return &timeAnchor{
+		t: t,
+	}
+}
+
+func (a *timeAnchor) ID() string {
+	return fmt.Sprintf("anchortime:%d", a.t.UnixNano())
+}
+
+func (a *timeAnchor) Begins(prev, cur time.Time) bool {
+	if cur.Before(a.t) {
+		return false
+	}
+	return prev.IsZero() || prev.Before(a.t)
+}
+
+type seriesAnchor struct {
+	cond ValueSeries
+}
+
+// AnchorSeries generates an anchor that begins a new period where cond is true (non-zero), i.e. Crossover.
+// The first item also begins a period. cond has to be generated up to the item before the anchor is used.
+func AnchorSeries(cond ValueSeries) Anchor {
+	return &seriesAnchor{
+		cond: cond,
+	}
+}
+
+func (a *seriesAnchor) ID() string {
+	return fmt.Sprintf("anchorseries:%s", a.cond.ID())
+}
+
+func (a *seriesAnchor) Begins(prev, cur time.Time) bool {
+	if prev.IsZero() {
+		return true
+	}
+	v := a.cond.Get(cur)
+	return v != nil && v.v != 0
+}
@@ -0,0 +1,56 @@
+package pine
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAnchorBegins(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	utc := func(y int, m time.Month, d, h, min int) time.Time {
+		return time.Date(y, m, d, h, min, 0, 0, time.UTC)
+	}
+
+	cond := NewValueSeries()
+	cond.Set(utc(2023, 1, 2, 0, 0), 0)
+	cond.Set(utc(2023, 1, 2, 1, 0), 1)
+
+	testTable := []struct {
+		name   string
+		anchor Anchor
+		prev   time.Time
+		cur    time.Time
+		exp    bool
+	}{
+		{"day first item", AnchorPeriodDay, time.Time{}, utc(2023, 1, 1, 10, 0), true},
+		{"day same day", AnchorPeriodDay, utc(2023, 1, 1, 10, 0), utc(2023, 1, 1, 23, 59), false},
+		{"day next day", AnchorPeriodDay, utc(2023, 1, 1, 23, 59), utc(2023, 1, 2, 0, 0), true},
+		{"week sunday to monday", AnchorPeriodWeek, utc(2023, 1, 1, 23, 0), utc(2023, 1, 2, 0, 0), true},
+		{"week monday to sunday", AnchorPeriodWeek, utc(2023, 1, 2, 0, 0), utc(2023, 1, 8, 23, 0), false},
+		{"month same month", AnchorPeriodMonth, utc(2023, 1, 1, 0, 0), utc(2023, 1, 31, 0, 0), false},
+		{"month next month", AnchorPeriodMonth, utc(2023, 1, 31, 0, 0), utc(2023, 2, 1, 0, 0), true},
+		{"quarter same quarter", AnchorPeriodQuarter, utc(2023, 1, 31, 0, 0), utc(2023, 3, 31, 0, 0), false},
+		{"quarter next quarter", AnchorPeriodQuarter, utc(2023, 3, 31, 0, 0), utc(2023, 4, 1, 0, 0), true},
+		{"year same year", AnchorPeriodYear, utc(2023, 1, 1, 0, 0), utc(2023, 12, 31, 0, 0), false},
+		{"year next year", AnchorPeriodYear, utc(2022, 12, 31, 0, 0), utc(2023, 1, 1, 0, 0), true},
+		{"session before open", AnchorSession(9*time.Hour+30*time.Minute, ny), utc(2023, 1, 3, 14, 0), utc(2023, 1, 3, 14, 29), false},
+		{"session open", AnchorSession(9*time.Hour+30*time.Minute, ny), utc(2023, 1, 3, 14, 29), utc(2023, 1, 3, 14, 30), true},
+		{"session overnight", AnchorSession(18*time.Hour, ny), utc(2023, 1, 3, 23, 30), utc(2023, 1, 4, 5, 0), false},
+		{"time before", AnchorTime(utc(2023, 1, 3, 0, 0)), utc(2023, 1, 2, 0, 0), utc(2023, 1, 2, 1, 0), false},
+		{"time crossed", AnchorTime(utc(2023, 1, 3, 0, 0)), utc(2023, 1, 2, 23, 0), utc(2023, 1, 3, 0, 0), true},
+		{"time after", AnchorTime(utc(2023, 1, 3, 0, 0)), utc(2023, 1, 3, 0, 0), utc(2023, 1, 3, 1, 0), false},
+		{"series first item", AnchorSeries(cond), time.Time{}, utc(2023, 1, 2, 0, 0), true},
+		{"series false", AnchorSeries(cond), utc(2023, 1, 1, 23, 0), utc(2023, 1, 2, 0, 0), false},
+		{"series true", AnchorSeries(cond), utc(2023, 1, 2, 0, 0), utc(2023, 1, 2, 1, 0), true},
+		{"series no value", AnchorSeries(cond), utc(2023, 1, 2, 1, 0), utc(2023, 1, 2, 2, 0), false},
+	}
+
+	for _, v := range testTable {
+		if got := v.anchor.Begins(v.prev, v.cur); got != v.exp {
+			t.Errorf("Expected %s to be %t but got %t", v.name, v.exp, got)
+		}
+	}
+}
@@ -0,0 +1,290 @@
+package pine
+
+import (
+	"math"
+	"time"
+)
+
+// TradeBar is an OHLCV aggregated from trades along with the volume of each aggressor side
+type TradeBar struct {
+	OHLCV
+	// BuyV is the volume of the trades where the buyer was the aggressor
+	BuyV float64
+	// SellV is the volume of the trades where the seller was the aggressor
+	SellV float64
+	// N is the number of trades
+	N int
+	// Notional is the sum of price * size of the trades
+	Notional float64
+}
+
+// BarBuilder aggregates trades into an OHLCVSeries.
+//
+// Only completed bars are pushed to the OHLCVSeries so that indicators never see an OHLCV that changes afterwards.
+// OHLCVSeries identifies OHLCV by the second so a bar never starts within the same second as the previous bar.
+// A trade which would start a new bar within the same second as the forming bar is merged into the forming bar instead,
+// so a bar can exceed its threshold when many trades arrive within a second.
+type BarBuilder interface {
+	// Add aggregates a trade into the forming bar.
+	// It returns false and drops the trade if the trade would start a bar within the same second as the last completed bar, i.e. after Flush
+	Add(Trade) bool
+
+	// Update aggregates the trades of the TradeSeries up to its current trade which have not been aggregated yet
+	Update(TradeSeries)
+
+	// Flush completes the forming bar, i.e. at the end of the trades
+	Flush()
+
+	// Forming returns a copy of the bar being aggregated or nil if there is none
+	Forming() *TradeBar
+
+	// Series returns the OHLCVSeries of the completed bars
+	Series() OHLCVSeries
+
+	// BuyVolume returns the ValueSeries of the buy volume of the completed bars up to the current OHLCV of Series
+	BuyVolume() ValueSeries
+
+	// SellVolume returns the ValueSeries of the sell volume of the completed bars up to the current OHLCV of Series
+	SellVolume() ValueSeries
+
+	// Trades returns the ValueSeries of the number of trades of the completed bars up to the current OHLCV of Series
+	Trades() ValueSeries
+
+	// Notional returns the ValueSeries of the notional value of the completed bars up to the current OHLCV of Series
+	Notional() ValueSeries
+}
+
+// NewTimeBarBuilder aggregates trades into bars of the interval.
+// The bar starts at the time of the trade truncated by the interval and it is completed when a trade of the next interval arrives.
+// No bar is generated for an interval without trades.
+func NewTimeBarBuilder(interval time.Duration) BarBuilder {
+	return newBarBuilder(
+		func(b *TradeBar, t Trade) bool {
+			return !t.T.Truncate(interval).Equal(b.S)
+		},
+		func(b *TradeBar) bool {
+			return false
+		},
+		func(t Trade) time.Time {
+			return t.T.Truncate(interval)
+		},
+	)
+}
+
+// NewTickBarBuilder aggregates every n trades into a bar
+func NewTickBarBuilder(n int) BarBuilder {
+	return newBarBuilder(
+		nil,
+		func(b *TradeBar) bool {
+			return b.N >= n
+		},
+		nil,
+	)
+}
+
+// NewVolumeBarBuilder aggregates trades into a bar until the volume reaches v.
+// A trade is not split so the volume of a bar can exceed v.
+func NewVolumeBarBuilder(v float64) BarBuilder {
+	return newBarBuilder(
+		nil,
+		func(b *TradeBar) bool {
+			return b.V >= v
+		},
+		nil,
+	)
+}
+
+// NewDollarBarBuilder aggregates trades into a bar until the notional value, the sum of price * size, reaches d.
+// A trade is not split so the notional value of a bar can exceed d.
+func NewDollarBarBuilder(d float64) BarBuilder {
+	return newBarBuilder(
+		nil,
+		func(b *TradeBar) bool {
+			return b.Notional >= d
+		},
+		nil,
+	)
+}
+
+// NewRangeBarBuilder aggregates trades into a bar until the range between high and low reaches r.
+// A trade which would extend the range beyond r starts a new bar so the range of a bar does not exceed r unless the trade is within the same second as the start of the bar.
+func NewRangeBarBuilder(r float64) BarBuilder {
+	return newBarBuilder(
+		func(b *TradeBar, t Trade) bool {
+			return math.Max(b.H, t.P)-math.Min(b.L, t.P) > r
+		},
+		func(b *TradeBar) bool {
+			return false
+		},
+		nil,
+	)
+}
+
+type barBuilder struct {
+	series OHLCVSeries
+
+	buyv     ValueSeries
+	sellv    ValueSeries
+	trades   ValueSeries
+	notional ValueSeries
+
+	// bar being aggregated
+	forming *TradeBar
+
+	// start time of the last completed bar
+	lastS time.Time
+
+	// last trade aggregated by Update
+	last *Trade
+
+	// starts returns true if the trade starts a new bar instead of being aggregated into b. nil never starts a new bar
+	starts func(b *TradeBar, t Trade) bool
+	// full returns true if b is completed after aggregating a trade
+	full func(b *TradeBar) bool
+	// start returns the start time of the bar started by the trade. nil uses the time of the trade
+	start func(t Trade) time.Time
+}
+
+func newBarBuilder(starts func(b *TradeBar, t Trade) bool, full func(b *TradeBar) bool, start func(t Trade) time.Time) *barBuilder {
+	series, _ := NewOHLCVSeries(nil)
+	return &barBuilder{
+		series:   series,
+		buyv:     NewValueSeries(),
+		sellv:    NewValueSeries(),
+		trades:   NewValueSeries(),
+		notional: NewValueSeries(),
+		starts:   starts,
+		full:     full,
+		start:    start,
+	}
+}
+
+func (b *barBuilder) Add(t Trade) bool {
+	if b.forming != nil && b.completes(b.forming, t) {
+		b.Flush()
+	}
+
+	if b.forming == nil {
+		s := b.startOf(t)
+		if !b.lastS.IsZero() && s.Unix() <= b.lastS.Unix() {
+			return false
+		}
+		b.forming = &TradeBar{
+			OHLCV: OHLCV{O: t.P, H: t.P, L: t.P, S: s},
+		}
+	}
+
+	f := b.forming
+	f.H = math.Max(f.H, t.P)
+	f.L = math.Min(f.L, t.P)
+	f.C = t.P
+	f.V += t.V
+	f.N++
+	f.Notional += t.P * t.V
+	switch t.Side {
+	case TradeSideBuy:
+		f.BuyV += t.V
+	case TradeSideSell:
+		f.SellV += t.V
+	}
+
+	// a full bar within a second stays open since the next trade may be merged into it
+	if b.full(f) && t.T.Unix() > f.S.Unix() {
+		b.Flush()
+	}
+	return true
+}
+
+// completes returns true if the trade completes the forming bar f and starts a new bar.
+// It is false if the new bar would start within the same second as f so that the trade is merged into f.
+func (b *barBuilder) completes(f *TradeBar, t Trade) bool {
+	if !b.full(f) && (b.starts == nil || !b.starts(f, t)) {
+		return false
+	}
+	return b.startOf(t).Unix() > f.S.Unix()
+}
+
+// startOf returns the start time of the bar started by the trade
+func (b *barBuilder) startOf(t Trade) time.Time {
+	if b.start != nil {
+		return b.start(t)
+	}
+	return t.T
+}
+
+func (b *barBuilder) Update(ts TradeSeries) {
+	stop := ts.Current()
+	if stop == nil || stop == b.last {
+		return
+	}
+
+	var t *Trade
+	if b.last == nil {
+		t = ts.GetFirst()
+	} else {
+		t = b.last.next
+	}
+
+	for {
+		if t == nil {
+			break
+		}
+		b.Add(*t)
+		b.last = t
+		if t == stop {
+			break
+		}
+		t = t.next
+	}
+}
+
+func (b *barBuilder) Flush() {
+	f := b.forming
+	if f == nil {
+		return
+	}
+	b.forming = nil
+	b.lastS = f.S
+
+	b.series.Push(f.OHLCV)
+	b.buyv.Set(f.S, f.BuyV)
+	b.sellv.Set(f.S, f.SellV)
+	b.trades.Set(f.S, float64(f.N))
+	b.notional.Set(f.S, f.Notional)
+}
+
+func (b *barBuilder) Forming() *TradeBar {
+	if b.forming == nil {
+		return nil
+	}
+	f := *b.forming
+	return &f
+}
+
+func (b *barBuilder) Series() OHLCVSeries {
+	return b.series
+}
+
+func (b *barBuilder) BuyVolume() ValueSeries {
+	return b.propagateCurrent(b.buyv)
+}
+
+func (b *barBuilder) SellVolume() ValueSeries {
+	return b.propagateCurrent(b.sellv)
+}
+
+func (b *barBuilder) Trades() ValueSeries {
+	return b.propagateCurrent(b.trades)
+}
+
+func (b *barBuilder) Notional() ValueSeries {
+	return b.propagateCurrent(b.notional)
+}
+
+// propagateCurrent sets the current value of vs to the current OHLCV of the series
+func (b *barBuilder) propagateCurrent(vs ValueSeries) ValueSeries {
+	if cur := b.series.Current(); cur != nil {
+		vs.SetCurrent(cur.S)
+	}
+	return vs
+}
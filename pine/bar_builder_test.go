@@ -0,0 +1,230 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestBarBuilder tests that each builder aggregates the trades of tradeTestData into the expected bars
+func TestBarBuilder(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	sec := func(s int) time.Time {
+		return start.Add(time.Duration(s) * time.Second)
+	}
+
+	testTable := []struct {
+		name string
+		b    BarBuilder
+		exp  []OHLCV
+	}{
+		{
+			name: "time",
+			b:    NewTimeBarBuilder(time.Minute),
+			exp: []OHLCV{
+				{O: 10, H: 11, L: 9, C: 9, V: 4, S: sec(0)},
+				{O: 12, H: 12, L: 11, C: 11, V: 4, S: sec(60)},
+				{O: 13, H: 13, L: 12, C: 12, V: 3, S: sec(120)},
+				{O: 14, H: 14, L: 14, C: 14, V: 1, S: sec(180)},
+			},
+		},
+		{
+			name: "tick",
+			b:    NewTickBarBuilder(3),
+			exp: []OHLCV{
+				{O: 10, H: 11, L: 9, C: 9, V: 4, S: sec(0)},
+				{O: 12, H: 13, L: 11, C: 13, V: 6, S: sec(70)},
+				{O: 12, H: 14, L: 12, C: 14, V: 2, S: sec(150)},
+			},
+		},
+		{
+			name: "volume",
+			b:    NewVolumeBarBuilder(4),
+			exp: []OHLCV{
+				{O: 10, H: 11, L: 9, C: 9, V: 4, S: sec(0)},
+				{O: 12, H: 12, L: 11, C: 11, V: 4, S: sec(70)},
+				{O: 13, H: 14, L: 12, C: 14, V: 4, S: sec(130)},
+			},
+		},
+		{
+			name: "dollar",
+			b:    NewDollarBarBuilder(30),
+			exp: []OHLCV{
+				{O: 10, H: 11, L: 10, C: 11, V: 3, S: sec(0)},
+				{O: 9, H: 12, L: 9, C: 12, V: 4, S: sec(40)},
+				{O: 11, H: 13, L: 11, C: 13, V: 3, S: sec(80)},
+				{O: 12, H: 14, L: 12, C: 14, V: 2, S: sec(150)},
+			},
+		},
+		{
+			name: "range",
+			b:    NewRangeBarBuilder(2),
+			exp: []OHLCV{
+				{O: 10, H: 11, L: 9, C: 9, V: 4, S: sec(0)},
+				{O: 12, H: 13, L: 11, C: 12, V: 7, S: sec(70)},
+				{O: 14, H: 14, L: 14, C: 14, V: 1, S: sec(185)},
+			},
+		},
+	}
+
+	for _, v := range testTable {
+		ts := NewTradeSeries(tradeTestData())
+		for {
+			if tr := ts.Next(); tr == nil {
+				break
+			}
+			v.b.Update(ts)
+		}
+		v.b.Flush()
+
+		s := v.b.Series()
+		if s.Len() != len(v.exp) {
+			t.Fatalf("expected %s to have %d bars but got %d", v.name, len(v.exp), s.Len())
+		}
+		for i, exp := range v.exp {
+			o, _ := s.Next()
+			if o.O != exp.O || o.H != exp.H || o.L != exp.L || o.C != exp.C || o.V != exp.V || !o.S.Equal(exp.S) {
+				t.Errorf("expected %s bar %d to be %+v but got %+v", v.name, i, exp, *o)
+			}
+		}
+	}
+}
+
+// TestBarBuilderIncremental tests that a bar is pushed only when it is completed and the forming bar is available until then
+func TestBarBuilderIncremental(t *testing.T) {
+	b := NewTickBarBuilder(3)
+	ts := NewTradeSeries(nil)
+
+	expLen := []int{0, 0, 1, 1, 1, 2, 2, 2}
+	expN := []int{1, 2, 0, 1, 2, 0, 1, 2}
+
+	for i, v := range tradeTestData() {
+		ts.Push(v)
+		ts.Next()
+		b.Update(ts)
+		// calling it again does not aggregate the same trade twice
+		b.Update(ts)
+
+		if b.Series().Len() != expLen[i] {
+			t.Errorf("expected len of %d but got %d for iteration: %d", expLen[i], b.Series().Len(), i)
+		}
+		f := b.Forming()
+		if expN[i] == 0 {
+			if f != nil {
+				t.Errorf("expected forming bar to be nil but got %+v for iteration: %d", *f, i)
+			}
+			continue
+		}
+		if f == nil || f.N != expN[i] {
+			t.Errorf("expected forming bar to have %d trades but got %+v for iteration: %d", expN[i], f, i)
+		}
+	}
+}
+
+// TestBarBuilderSameSecond tests that the trades which would start many bars within one second are merged into one bar
+// instead of bars with made up start times, and that a bar spanning seconds is completed as soon as it is full
+func TestBarBuilderSameSecond(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := NewTickBarBuilder(2)
+	for i := 0; i < 10; i++ {
+		b.Add(Trade{P: 10 + float64(i), V: 1, T: start.Add(time.Duration(i) * 50 * time.Millisecond), Side: TradeSideBuy})
+	}
+	if b.Series().Len() != 0 {
+		t.Fatalf("expected the bar within a second to stay open but got %d bars", b.Series().Len())
+	}
+
+	b.Add(Trade{P: 30, V: 2, T: start.Add(5 * time.Second), Side: TradeSideSell})
+	b.Add(Trade{P: 31, V: 2, T: start.Add(6 * time.Second), Side: TradeSideSell})
+	if b.Series().Len() != 2 {
+		t.Fatalf("expected the bar spanning seconds to be completed when it is full but got %d bars", b.Series().Len())
+	}
+
+	exp := []TradeBar{
+		{OHLCV: OHLCV{O: 10, H: 19, L: 10, C: 19, V: 10, S: start}, BuyV: 10, N: 10, Notional: 145},
+		{OHLCV: OHLCV{O: 30, H: 31, L: 30, C: 31, V: 4, S: start.Add(5 * time.Second)}, SellV: 4, N: 2, Notional: 122},
+	}
+	for i, v := range exp {
+		o, _ := b.Series().Next()
+		if o.O != v.O || o.H != v.H || o.L != v.L || o.C != v.C || o.V != v.V || !o.S.Equal(v.S) {
+			t.Errorf("expected bar %d to be %+v but got %+v", i, v.OHLCV, *o)
+		}
+		if got := *b.BuyVolume().Val(); got != v.BuyV {
+			t.Errorf("expected buy volume of %+v but got %+v for bar: %d", v.BuyV, got, i)
+		}
+		if got := *b.SellVolume().Val(); got != v.SellV {
+			t.Errorf("expected sell volume of %+v but got %+v for bar: %d", v.SellV, got, i)
+		}
+		if got := *b.Trades().Val(); got != float64(v.N) {
+			t.Errorf("expected %d trades but got %+v for bar: %d", v.N, got, i)
+		}
+		if got := *b.Notional().Val(); got != v.Notional {
+			t.Errorf("expected notional of %+v but got %+v for bar: %d", v.Notional, got, i)
+		}
+	}
+}
+
+// TestBarBuilderAfterFlush tests that a trade within the same second as the flushed bar is rejected
+func TestBarBuilderAfterFlush(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := NewTickBarBuilder(3)
+	b.Add(Trade{P: 10, V: 1, T: start})
+	b.Flush()
+
+	if b.Add(Trade{P: 11, V: 1, T: start.Add(500 * time.Millisecond)}) {
+		t.Error("expected the trade within the second of the flushed bar to be rejected")
+	}
+	if f := b.Forming(); f != nil {
+		t.Errorf("expected no forming bar but got %+v", *f)
+	}
+	if !b.Add(Trade{P: 12, V: 1, T: start.Add(time.Second)}) {
+		t.Error("expected the trade of the next second to be aggregated")
+	}
+}
+
+// TestBarBuilderSideVolume tests the buy and sell volume of time bars
+//
+// bar  | 0 | 1 | 2 | 3 |
+// buy  | 2 | 3 | 1 | 1 |
+// sell | 2 | 1 | 2 | 0 |
+func TestBarBuilderSideVolume(t *testing.T) {
+	b := NewTimeBarBuilder(time.Minute)
+	for _, v := range tradeTestData() {
+		b.Add(v)
+	}
+	b.Flush()
+
+	buyExp := []float64{2, 3, 1, 1}
+	sellExp := []float64{2, 1, 2, 0}
+
+	for i := range buyExp {
+		b.Series().Next()
+		buy := b.BuyVolume()
+		sell := b.SellVolume()
+		if *buy.Val() != buyExp[i] {
+			t.Errorf("expected buy volume of %+v but got %+v for iteration: %d", buyExp[i], *buy.Val(), i)
+		}
+		if *sell.Val() != sellExp[i] {
+			t.Errorf("expected sell volume of %+v but got %+v for iteration: %d", sellExp[i], *sell.Val(), i)
+		}
+	}
+}
+
+func ExampleNewVolumeBarBuilder() {
+	start := time.Now()
+	b := NewVolumeBarBuilder(100)
+	ts := NewTradeSeries(nil)
+	for i := 0; i < 10000; i++ {
+		ts.Push(Trade{P: 100 + float64(i%7), V: float64(i%5 + 1), T: start.Add(time.Duration(i) * time.Second), Side: TradeSideBuy})
+		ts.Next()
+		b.Update(ts)
+
+		// process the bars as they are completed
+		for {
+			if v, _ := b.Series().Next(); v == nil {
+				break
+			}
+			sma := SMA(OHLCVAttr(b.Series(), OHLCPropClose), 20)
+			log.Printf("Close: %+v, SMA: %+v", b.Series().Current().C, sma.Val())
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package pine
+
+var cache map[string]ValueSeries = make(map[string]ValueSeries)
+
+func getCache(key string) ValueSeries {
+	return cache[key]
+}
+
+func setCache(key string, v ValueSeries) {
+	setState(cache, key, v)
+}
+
+// cacheScope holds the deletions of the cached series and states created while fn of Anchored runs for a period
+type cacheScope struct {
+	deletes []func()
+}
+
+// cacheScopes are the scopes of the periods being calculated, innermost last
+var cacheScopes []*cacheScope
+
+// recordCacheKey registers the deletion of a cache entry created under the current periods.
+// An entry created by a nested Anchored belongs to the enclosing periods as well.
+func recordCacheKey(del func()) {
+	for _, c := range cacheScopes {
+		c.deletes = append(c.deletes, del)
+	}
+}
+
+// run calls fn while the cache entries created by it are recorded in c
+func (c *cacheScope) run(fn func()) {
+	cacheScopes = append(cacheScopes, c)
+	defer func() {
+		cacheScopes = cacheScopes[:len(cacheScopes)-1]
+	}()
+	fn()
+}
+
+// purge deletes the cache entries recorded in c so that they can be garbage collected
+func (c *cacheScope) purge() {
+	for _, del := range c.deletes {
+		del()
+	}
+	c.deletes = nil
+}
+
+// setState stores the state of an indicator in one of the state caches.
+// A new key is recorded in the current periods of Anchored so that it is deleted along with them.
+func setState[T any](m map[string]T, key string, v T) {
+	if _, ok := m[key]; !ok {
+		recordCacheKey(func() {
+			delete(m, key)
+		})
+	}
+	m[key] = v
+}
@@ -0,0 +1,204 @@
+package pine
+
+import (
+	"fmt"
+	"math"
+)
+
+// CandleTrend is a rule to detect the trend preceding a candlestick pattern
+type CandleTrend int
+
+const (
+	// CandleTrendSMA is an uptrend if close > sma(close, TrendFastLen) and a downtrend if close < sma(close, TrendFastLen)
+	CandleTrendSMA CandleTrend = iota
+	// CandleTrendSMACross is an uptrend if close > sma(close, TrendFastLen) > sma(close, TrendSlowLen) and a downtrend if close < sma(close, TrendFastLen) < sma(close, TrendSlowLen)
+	CandleTrendSMACross
+	// CandleTrendNone does not detect the trend. Every candle is considered both an uptrend and a downtrend
+	CandleTrendNone
+)
+
+// CandleOpts is a set of options to detect candlestick patterns.
+// Use NewCandleOpts for TradingView's default values.
+//
+// Candlestick patterns such as Doji and Hammer generate a ValueSeries of 1.0 if the pattern is detected at the OHLCV and 0.0 otherwise,
+// so that they can be used as a condition of ValueWhen and BarsSince.
+// The formulas follow TradingView's built-in candlestick patterns where c is the current candle, c[1] is the previous candle and so on.
+//
+// The common properties of a candle are
+//   - bodyHi = max(close, open)
+//   - bodyLo = min(close, open)
+//   - body = bodyHi - bodyLo
+//   - bodyAvg = ema(body, BodyAvgLen)
+//   - smallBody = body < bodyAvg
+//   - longBody = body > bodyAvg
+//   - upShadow = high - bodyHi
+//   - dnShadow = bodyLo - low
+//   - hasUpShadow = upShadow > ShadowPercent / 100 * body
+//   - hasDnShadow = dnShadow > ShadowPercent / 100 * body
+//   - dojiBody = high - low > 0 and body <= (high - low) * DojiBodyPercent / 100
+type CandleOpts struct {
+	// Trend is the rule to detect the trend
+	Trend CandleTrend
+	// TrendFastLen is the lookback periods of the fast SMA to detect the trend
+	TrendFastLen int64
+	// TrendSlowLen is the lookback periods of the slow SMA to detect the trend
+	TrendSlowLen int64
+	// BodyAvgLen is the lookback periods of EMA of the body size. A body is long if it is larger than the average and small if it is smaller
+	BodyAvgLen int64
+	// ShadowPercent is the maximum size of a shadow in percentage of the body to be considered as having no shadow
+	ShadowPercent float64
+	// ShadowEqualsPercent is the maximum difference between the upper and the lower shadows in percentage to be considered equal
+	ShadowEqualsPercent float64
+	// DojiBodyPercent is the maximum size of the body in percentage of the range to be considered a doji
+	DojiBodyPercent float64
+	// Factor is the minimum ratio of the shadow to the body for hammers and shooting stars
+	Factor float64
+}
+
+// NewCandleOpts returns CandleOpts with TradingView's default values
+func NewCandleOpts() CandleOpts {
+	return CandleOpts{
+		Trend:               CandleTrendSMA,
+		TrendFastLen:        50,
+		TrendSlowLen:        200,
+		BodyAvgLen:          14,
+		ShadowPercent:       5,
+		ShadowEqualsPercent: 100,
+		DojiBodyPercent:     5,
+		Factor:              2,
+	}
+}
+
+func (c CandleOpts) key() string {
+	return fmt.Sprintf("%d:%d:%d:%d:%g:%g:%g:%g", c.Trend, c.TrendFastLen, c.TrendSlowLen, c.BodyAvgLen, c.ShadowPercent, c.ShadowEqualsPercent, c.DojiBodyPercent, c.Factor)
+}
+
+// candle is an OHLCV with the properties used to detect candlestick patterns
+type candle struct {
+	o, h, l, c float64
+
+	bodyHi     float64
+	bodyLo     float64
+	body       float64
+	bodyMiddle float64
+	upShadow   float64
+	dnShadow   float64
+	rng        float64
+
+	smallBody    bool
+	longBody     bool
+	whiteBody    bool
+	blackBody    bool
+	hasUpShadow  bool
+	hasDnShadow  bool
+	dojiBody     bool
+	shadowEquals bool
+	upTrend      bool
+	downTrend    bool
+}
+
+// newCandle generates a candle of v. bodyAvg, fast and slow are nil if they are not available
+func newCandle(v *OHLCV, opts CandleOpts, bodyAvg, fast, slow *Value) candle {
+	c := candle{
+		o:      v.O,
+		h:      v.H,
+		l:      v.L,
+		c:      v.C,
+		bodyHi: math.Max(v.C, v.O),
+		bodyLo: math.Min(v.C, v.O),
+	}
+	c.body = c.bodyHi - c.bodyLo
+	c.bodyMiddle = c.body/2 + c.bodyLo
+	c.upShadow = v.H - c.bodyHi
+	c.dnShadow = c.bodyLo - v.L
+	c.rng = v.H - v.L
+
+	if bodyAvg != nil {
+		c.smallBody = c.body < bodyAvg.v
+		c.longBody = c.body > bodyAvg.v
+	}
+	c.whiteBody = v.O < v.C
+	c.blackBody = v.O > v.C
+	c.hasUpShadow = c.upShadow > opts.ShadowPercent/100*c.body
+	c.hasDnShadow = c.dnShadow > opts.ShadowPercent/100*c.body
+	c.dojiBody = c.rng > 0 && c.body <= c.rng*opts.DojiBodyPercent/100
+	// a shadow of 0 only equals the other shadow of 0, which avoids dividing by 0
+	c.shadowEquals = c.upShadow == c.dnShadow ||
+		(c.upShadow > 0 && c.dnShadow > 0 &&
+			math.Abs(c.upShadow-c.dnShadow)/c.dnShadow*100 < opts.ShadowEqualsPercent &&
+			math.Abs(c.dnShadow-c.upShadow)/c.upShadow*100 < opts.ShadowEqualsPercent)
+
+	switch opts.Trend {
+	case CandleTrendNone:
+		c.upTrend = true
+		c.downTrend = true
+	case CandleTrendSMA:
+		if fast != nil {
+			c.upTrend = v.C > fast.v
+			c.downTrend = v.C < fast.v
+		}
+	case CandleTrendSMACross:
+		if fast != nil && slow != nil {
+			c.upTrend = v.C > fast.v && fast.v > slow.v
+			c.downTrend = v.C < fast.v && fast.v < slow.v
+		}
+	}
+
+	return c
+}
+
+// generateCandlePattern generates a ValueSeries of 1.0 if fn returns true and 0.0 otherwise.
+// fn receives candles in the order of the current candle first followed by bars-1 previous candles.
+// 0.0 is set if there are not enough candles.
+func generateCandlePattern(o OHLCVSeries, opts CandleOpts, name string, bars int, fn func(c []candle) bool) ValueSeries {
+	key := fmt.Sprintf("candle%s:%s:%s", name, o.ID(), opts.key())
+	dest := getCache(key)
+	if dest == nil {
+		dest = NewValueSeries()
+	}
+
+	stop := o.Current()
+	if stop == nil {
+		return dest
+	}
+
+	body := DiffAbs(OHLCVAttr(o, OHLCPropClose), OHLCVAttr(o, OHLCPropOpen))
+	bodyAvg := EMA(body, opts.BodyAvgLen)
+
+	var fast, slow ValueSeries
+	if opts.Trend != CandleTrendNone {
+		fast = SMA(OHLCVAttr(o, OHLCPropClose), opts.TrendFastLen)
+	}
+	if opts.Trend == CandleTrendSMACross {
+		slow = SMA(OHLCVAttr(o, OHLCPropClose), opts.TrendSlowLen)
+	}
+
+	valueAt := func(vs ValueSeries, v *OHLCV) *Value {
+		if vs == nil {
+			return nil
+		}
+		return vs.Get(v.S)
+	}
+
+	cs := make([]candle, bars)
+	dest = generateOHLCVRecursive(o, dest, func(cur *OHLCV, _ *float64) *float64 {
+		v := cur
+		for i := 0; i < bars; i++ {
+			if v == nil {
+				return NewFloat64(0)
+			}
+			cs[i] = newCandle(v, opts, valueAt(bodyAvg, v), valueAt(fast, v), valueAt(slow, v))
+			v = v.prev
+		}
+		if fn(cs) {
+			return NewFloat64(1)
+		}
+		return NewFloat64(0)
+	})
+
+	setCache(key, dest)
+
+	dest.SetCurrent(stop.S)
+
+	return dest
+}
@@ -0,0 +1,44 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// MAType is a type of moving average
+type MAType int
+
+const (
+	// MATypeSMA is simple moving average
+	MATypeSMA MAType = iota
+	// MATypeEMA is exponential moving average
+	MATypeEMA
+	// MATypeRMA is moving average used in RSI. It is the exponentially weighted moving average with alpha = 1 / length
+	MATypeRMA
+	// MATypeWMA is weighted moving average
+	MATypeWMA
+	// MATypeHMA is hull moving average
+	MATypeHMA
+)
+
+// MA generates a ValueSeries of moving average of type t.
+// It panics if t is not a known type since it is a programming error.
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - l - int64: lookback periods [1, ∞)
+//   - t - MAType: type of moving average
+func MA(p ValueSeries, l int64, t MAType) ValueSeries {
+	switch t {
+	case MATypeSMA:
+		return SMA(p, l)
+	case MATypeEMA:
+		return EMA(p, l)
+	case MATypeRMA:
+		return RMA(p, l)
+	case MATypeWMA:
+		return WMA(p, l)
+	case MATypeHMA:
+		return HMA(p, l)
+	}
+	panic(fmt.Sprintf("pine: unknown MAType %d", t))
+}
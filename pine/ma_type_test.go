@@ -0,0 +1,57 @@
+package pine
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestMA tests that MA generates the moving average of each type
+func TestMA(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testTable := []struct {
+		mat MAType
+		fn  func(p ValueSeries, l int64) ValueSeries
+	}{
+		{mat: MATypeSMA, fn: SMA},
+		{mat: MATypeEMA, fn: EMA},
+		{mat: MATypeRMA, fn: RMA},
+		{mat: MATypeWMA, fn: WMA},
+		{mat: MATypeHMA, fn: HMA},
+	}
+
+	for range data {
+		series.Next()
+		c := OHLCVAttr(series, OHLCPropClose)
+		for _, v := range testTable {
+			ma := MA(c, 4, v.mat)
+			exp := v.fn(c, 4)
+			if (ma.Val() == nil) != (exp.Val() == nil) {
+				t.Fatalf("Expected %+v but got %+v for type: %d", exp.Val(), ma.Val(), v.mat)
+			}
+			if exp.Val() != nil && fmt.Sprintf("%.04f", *exp.Val()) != fmt.Sprintf("%.04f", *ma.Val()) {
+				t.Errorf("Expected %+v but got %+v for type: %d", *exp.Val(), *ma.Val(), v.mat)
+			}
+		}
+	}
+}
+
+// TestMAUnknownType tests that MA panics on a type that is not defined instead of falling back to another one
+func TestMAUnknownType(t *testing.T) {
+	series, err := NewOHLCVSeries(OHLCVStaticTestData())
+	if err != nil {
+		t.Fatal(err)
+	}
+	series.Next()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected to panic but did not")
+		}
+	}()
+	MA(OHLCVAttr(series, OHLCPropClose), 4, MAType(-1))
+}
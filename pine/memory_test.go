@@ -89,6 +89,9 @@ func testMemoryLeak(t *testing.T, fn testIndicator) {
 }
 
 func getMalloc() uint64 {
+	// collect garbage first so only the retained memory is compared
+	runtime.GC()
+
 	var m runtime.MemStats
 	runtime.ReadMemStats(&m)
 
@@ -0,0 +1,89 @@
+package pine
+
+import (
+	"time"
+)
+
+// monotonicDeque keeps the candidates of a rolling window extreme in a monotonic order.
+// The extreme of the window is always at the front, which makes each push O(1) amortized.
+type monotonicDeque struct {
+	items []dequeItem
+	// keep returns true if a should stay in front of a newer value b
+	keep func(a, b float64) bool
+	// number of values pushed so far
+	count int64
+	// time of the last pushed value
+	last time.Time
+}
+
+type dequeItem struct {
+	idx int64
+	v   float64
+}
+
+func newMonotonicDeque(keep func(a, b float64) bool) *monotonicDeque {
+	return &monotonicDeque{
+		items: make([]dequeItem, 0),
+		keep:  keep,
+	}
+}
+
+// push appends v at t and evicts values that are older than l items
+func (d *monotonicDeque) push(t time.Time, v float64, l int64) {
+	for len(d.items) > 0 && !d.keep(d.items[len(d.items)-1].v, v) {
+		d.items = d.items[:len(d.items)-1]
+	}
+	d.items = append(d.items, dequeItem{idx: d.count, v: v})
+	d.count++
+	d.last = t
+
+	for len(d.items) > 0 && d.items[0].idx <= d.count-1-l {
+		d.items = d.items[1:]
+	}
+}
+
+// front returns the extreme value and its offset from the last pushed value, which is 0 or negative
+func (d *monotonicDeque) front() (float64, int64) {
+	f := d.items[0]
+	return f.v, f.idx - (d.count - 1)
+}
+
+var dequeCache map[string]*monotonicDeque = make(map[string]*monotonicDeque)
+
+func getDequeCache(key string) *monotonicDeque {
+	return dequeCache[key]
+}
+
+func setDequeCache(key string, d *monotonicDeque) {
+	setState(dequeCache, key, d)
+}
+
+// generateExtreme generates the rolling extreme of l values and its bar offset from src using the deque
+func generateExtreme(stop Value, src, ext, bars ValueSeries, d *monotonicDeque, l int64) {
+	var f *Value
+	if d.count == 0 {
+		f = src.GetFirst()
+	} else if v := src.Get(d.last); v != nil {
+		f = v.next
+	} else {
+		// the source was trimmed beyond the window, start over
+		*d = *newMonotonicDeque(d.keep)
+		f = src.GetFirst()
+	}
+
+	for {
+		if f == nil {
+			break
+		}
+		d.push(f.t, f.v, l)
+		if d.count >= l {
+			v, offset := d.front()
+			ext.Set(f.t, v)
+			bars.Set(f.t, float64(offset))
+		}
+		if f.t.Equal(stop.t) {
+			break
+		}
+		f = f.next
+	}
+}
@@ -0,0 +1,49 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// AccDist generates a ValueSeries of accumulation/distribution index.
+// It is a running total that survives trimming of the series since each value only depends on the previous value.
+//
+// The formula for AccDist is
+//   - mfm = ((close - low) - (high - close)) / (high - low), which is 0 if high equals low
+//   - accdist = cum(mfm * volume)
+//
+// The arguments are:
+//   - o: OHLCVSeries - source of data
+func AccDist(o OHLCVSeries) ValueSeries {
+	key := fmt.Sprintf("accdist:%s", o.ID())
+	ad := getCache(key)
+	if ad == nil {
+		ad = NewValueSeries()
+	}
+
+	stop := o.Current()
+	if stop == nil {
+		return ad
+	}
+
+	ad = generateOHLCVRecursive(o, ad, func(cur *OHLCV, prev *float64) *float64 {
+		mfv := moneyFlowMultiplier(cur) * cur.V
+		if prev == nil {
+			return NewFloat64(mfv)
+		}
+		return NewFloat64(*prev + mfv)
+	})
+
+	setCache(key, ad)
+
+	ad.SetCurrent(stop.S)
+
+	return ad
+}
+
+// moneyFlowMultiplier returns ((close - low) - (high - close)) / (high - low), which is 0 if high equals low
+func moneyFlowMultiplier(v *OHLCV) float64 {
+	if v.H == v.L {
+		return 0
+	}
+	return ((v.C - v.L) - (v.H - v.C)) / (v.H - v.L)
+}
@@ -0,0 +1,95 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesAccDistNoData tests no data scenario
+func TestSeriesAccDistNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accdist := AccDist(series)
+	if accdist == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if accdist.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *accdist.Val())
+	}
+}
+
+// TestSeriesAccDistReference tests AccDist against TA-Lib's AD on referenceTestData
+func TestSeriesAccDistReference(t *testing.T) {
+	exp := []float64{
+		365.2896, -54.4428, -1393.5539, -1662.4225, -780.4772, -662.8301, 94.0776, -48.7942,
+		-1152.2492, -227.8347, -951.9257, -669.6312, -248.1919, -1435.2106, -1566.6150, -1802.9440,
+		-2574.0710, -2000.8025, -1612.9124, -3008.7860, -3119.6877, -3629.6642, -4490.4608, -4166.6872,
+		-3901.8240, -3048.8964, -2356.0432, -2497.3516, -2905.8316, -3276.4838, -2443.9762, -3461.1914,
+		-3762.6278, -4560.8583, -5093.7838, -4962.5993, -5541.8975, -5928.4125, -6008.2550, -7598.4216,
+		-8304.7639, -8771.3985, -9139.4797, -8869.8414, -9219.8150, -10158.9817, -11206.1107, -10542.6047,
+		-9505.8913, -9971.0024, -9478.2965, -8652.5887, -9366.8496, -9262.2871, -9253.4209, -10212.9050,
+		-9060.8465, -8407.1908, -8782.3699, -9595.9481,
+	}
+
+	assertReference(t, "accdist", 0, exp, func(o OHLCVSeries) ValueSeries {
+		return AccDist(o)
+	})
+}
+
+// TestSeriesAccDistSetMax tests that the running total is kept after the series is trimmed
+func TestSeriesAccDistSetMax(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		NewFloat64(2.9674),
+		NewFloat64(14.4380),
+		NewFloat64(26.2898),
+		NewFloat64(11.1949),
+		NewFloat64(27.1852),
+		NewFloat64(12.3297),
+		NewFloat64(4.3115),
+		NewFloat64(-5.6823),
+		NewFloat64(-10.9550),
+		NewFloat64(-24.7708),
+	}
+
+	for i, v := range tests {
+		series.Next()
+		accdist := AccDist(series)
+		accdist.SetMax(1)
+		assertSeriesVal(t, "accdist", i, v, accdist)
+	}
+}
+
+func TestMemoryLeakAccDist(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		AccDist(o)
+		return nil
+	})
+}
+
+func ExampleAccDist() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		accdist := AccDist(series)
+		log.Printf("AccDist: %+v", accdist.Val())
+	}
+}
@@ -0,0 +1,59 @@
+package pine
+
+import (
+	"fmt"
+	"math"
+)
+
+// ALMA generates a ValueSeries of Arnaud Legoux moving average.
+// It uses Gaussian distribution as weights for moving average.
+//
+// The formula for ALMA is
+//   - m = offset * (l - 1)
+//   - s = l / sigma
+//   - w(i) = exp(-(i - m)^2 / (2 * s^2))
+//   - alma = sum(p[l - 1 - i] * w(i)) / sum(w(i)) for i in [0, l)
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - l - int64: lookback periods [1, ∞)
+//   - offset - float64: controls tradeoff between smoothness (closer to 1) and responsiveness (closer to 0)
+//   - sigma - float64: changes the smoothness of ALMA. The larger sigma the smoother ALMA
+func ALMA(p ValueSeries, l int64, offset, sigma float64) ValueSeries {
+	key := fmt.Sprintf("alma:%s:%d:%v:%v", p.ID(), l, offset, sigma)
+	alma := getCache(key)
+	if alma == nil {
+		alma = NewValueSeries()
+	}
+
+	if p == nil || p.GetCurrent() == nil {
+		return alma
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+
+	m := offset * float64(l-1)
+	s := float64(l) / sigma
+	weights := make([]float64, l)
+	var norm float64
+	for i := range weights {
+		weights[i] = math.Exp(-1 * math.Pow(float64(i)-m, 2) / (2 * math.Pow(s, 2)))
+		norm = norm + weights[i]
+	}
+
+	alma = generateWindow(*stop, p, alma, int(l), func(w []float64) float64 {
+		// w is in chronological order so the oldest value has the first weight
+		var tot float64
+		for i := range w {
+			tot = tot + w[i]*weights[i]
+		}
+		return tot / norm
+	})
+
+	setCache(key, alma)
+
+	alma.SetCurrent(stop.t)
+
+	return alma
+}
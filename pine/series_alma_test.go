@@ -0,0 +1,105 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesALMAReference tests that ALMA(close, 5, 0.85, sigma) with sigma close to 0 flattens the gaussian weights into TA-Lib's SMA(close, 5)
+func TestSeriesALMAReference(t *testing.T) {
+	exp := []float64{
+		98.5600, 97.3160, 97.3500, 97.1540, 97.2900, 97.6020, 97.9620, 97.9600,
+		98.7000, 99.2860, 99.4500, 99.4160, 98.8740, 98.4180, 98.0180, 97.5740,
+		97.6680, 97.4880, 96.8220, 96.9440, 97.5520, 97.8380, 98.9600, 100.0280,
+		100.3480, 99.7020, 99.3720, 98.6180, 98.1720, 98.1260, 98.2820, 98.1420,
+		97.4120, 96.5400, 95.6600, 95.1360, 94.0100, 93.6420, 92.9900, 92.3180,
+		91.3940, 90.2720, 88.8240, 87.8420, 87.4360, 86.6260, 86.3320, 86.9060,
+		86.5860, 85.9900, 85.6260, 85.0560, 84.5760, 85.4240, 85.8420, 86.5280,
+	}
+
+	assertReference(t, "alma", 4, exp, func(o OHLCVSeries) ValueSeries {
+		return ALMA(OHLCVAttr(o, OHLCPropClose), 5, 0.85, 1e-6)
+	})
+}
+
+// TestSeriesALMADefault tests ALMA(close, 9, 0.85, 6) with the default offset and sigma against the formula of Pine Script's ta.alma on referenceTestData.
+// The weights are skewed towards the latest values, which fails if they are applied in the reverse order.
+func TestSeriesALMADefault(t *testing.T) {
+	exp := []float64{
+		97.5780, 97.4259, 97.5947, 98.2406, 99.2810, 99.6903, 99.5774, 99.1470,
+		98.4411, 98.2407, 97.9106, 97.4920, 97.5747, 97.3492, 96.8921, 96.7283,
+		97.3295, 98.4268, 99.7195, 100.5736, 100.5398, 99.4482, 98.6230, 98.1335,
+		98.1966, 98.4398, 98.2830, 98.0871, 97.1212, 96.0211, 95.1268, 94.6651,
+		94.0528, 93.4032, 92.5844, 91.8865, 91.1646, 89.8865, 88.1988, 86.9399,
+		86.9323, 86.9360, 86.6206, 86.6616, 86.2828, 86.0820, 85.5083, 84.5501,
+		84.3018, 85.3026, 86.6297, 87.4759,
+	}
+
+	assertReference(t, "alma", 8, exp, func(o OHLCVSeries) ValueSeries {
+		return ALMA(OHLCVAttr(o, OHLCPropClose), 9, 0.85, 6)
+	})
+}
+
+// TestSeriesALMAWeights tests the gaussian weights of ALMA(close, 3, 0.5, 3), where m = 1 and s = 1
+// so that the weights are exp(-0.5), 1 and exp(-0.5)
+//
+// t=time.Time         | 1    | 2    | 3     | 4       | 5       | 6       | 7       | 8       | 9       | 10    |
+// p=ValueSeries       | 16.5 | 18.7 | 18.2  | 11.9    | 19.3    | 14.2    | 14.4    | 11.0    | 14.7    | 10.3  |
+// alma(p, 3, 0.5, 3)  |      |      | 17.96 | 16.6104 | 15.6547 | 15.8741 | 15.6526 | 13.4134 | 12.9459 | 12.48 |
+func TestSeriesALMAWeights(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		nil,
+		nil,
+		NewFloat64(17.96),
+		NewFloat64(16.6104),
+		NewFloat64(15.6547),
+		NewFloat64(15.8741),
+		NewFloat64(15.6526),
+		NewFloat64(13.4134),
+		NewFloat64(12.9459),
+		NewFloat64(12.48),
+	}
+
+	for i, v := range tests {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropClose)
+		assertSeriesVal(t, "alma", i, v, ALMA(prop, 3, 0.5, 3))
+	}
+}
+
+// TestSeriesALMAFlat tests that a flat window returns the flat value
+func TestSeriesALMAFlat(t *testing.T) {
+	assertFlat(t, "alma", 4, func(p ValueSeries) ValueSeries {
+		return ALMA(p, 5, 0.85, 6)
+	})
+}
+
+func TestMemoryLeakALMA(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		ALMA(prop, 5, 0.85, 6)
+		return nil
+	})
+}
+
+func ExampleALMA() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		alma := ALMA(prop, 5, 0.85, 6)
+		log.Printf("ALMA: %+v", alma.Val())
+	}
+}
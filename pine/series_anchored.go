@@ -0,0 +1,101 @@
+package pine
+
+import (
+	"fmt"
+	"time"
+)
+
+// Anchored generates a ValueSeries of fn applied to every anchor period of p independently, i.e. an RSI that restarts every session.
+//
+// The values of each period are given to fn as a new ValueSeries so that every indicator derived from it starts over at the beginning of the period.
+// The results of all periods are merged into the returned ValueSeries.
+// The cached series and states created while fn runs for a period are deleted when the next period begins.
+// Thus an indicator which fn derives from another series than the given one is calculated again from the first value of that series.
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - a - Anchor: anchor to reset the calculation
+//   - ns - string: unique identifier of fn, which is used as part of a cache key
+//   - fn - func(ValueSeries) ValueSeries: indicator to apply on the values of a period
+func Anchored(p ValueSeries, a Anchor, ns string, fn func(ValueSeries) ValueSeries) ValueSeries {
+	key := fmt.Sprintf("anchored:%s:%s:%s", p.ID(), a.ID(), ns)
+	dest := getCache(key)
+	if dest == nil {
+		dest = NewValueSeries()
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+	if stop == nil {
+		return dest
+	}
+
+	s := anchoredCache[key]
+
+	var f *Value
+	if s == nil {
+		s = &anchoredState{}
+		f = p.GetFirst()
+	} else {
+		f = valueAfter(p, s.last)
+	}
+
+	for {
+		if f == nil || f.t.After(stop.t) {
+			break
+		}
+
+		var prevt time.Time
+		if f.prev != nil {
+			prevt = f.prev.t
+		}
+
+		if a.Begins(prevt, f.t) {
+			if s.scope != nil {
+				s.scope.purge()
+			}
+			s.seg = NewValueSeries()
+			s.scope = &cacheScope{}
+		}
+
+		// items before the first period do not belong to any period
+		if s.seg != nil {
+			s.seg.Set(f.t, f.v)
+			s.seg.SetCurrent(f.t)
+			var res ValueSeries
+			s.scope.run(func() {
+				res = fn(s.seg)
+			})
+			if v := res.Get(f.t); v != nil {
+				dest.Set(f.t, v.v)
+			}
+		}
+
+		s.last = f.t
+
+		if f.t.Equal(stop.t) {
+			break
+		}
+		f = f.next
+	}
+
+	setState(anchoredCache, key, s)
+
+	setCache(key, dest)
+
+	dest.SetCurrent(stop.t)
+
+	return dest
+}
+
+// anchoredState is the state of Anchored carried over to the next call
+type anchoredState struct {
+	// values of the current period
+	seg ValueSeries
+	// cache entries created by fn for the current period
+	scope *cacheScope
+	// time of the last processed value
+	last time.Time
+}
+
+var anchoredCache map[string]*anchoredState = make(map[string]*anchoredState)
@@ -0,0 +1,230 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// anchoredTestData generates OHLCVStaticTestData with the interval of 8 hours so that every three OHLCVs are in the same day
+func anchoredTestData() []OHLCV {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	data := OHLCVStaticTestData()
+	for i := range data {
+		data[i].S = start.Add(time.Duration(i) * 8 * time.Hour)
+	}
+	return data
+}
+
+// TestSeriesAnchoredNoData tests no data scenario
+func TestSeriesAnchoredNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prop := OHLCVAttr(series, OHLCPropClose)
+	anchored := Anchored(prop, AnchorPeriodDay, "cum", Cum)
+	if anchored == nil {
+		t.Fatal("Expected to be non nil but got nil")
+	}
+	if anchored.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *anchored.Val())
+	}
+}
+
+// TestSeriesAnchoredIteration tests that the indicators start over every day
+//
+// day                  | 1    | 1    | 1     | 2    | 2    | 2     | 3    | 3    | 3     | 4    |
+// close                | 16.5 | 18.7 | 18.2  | 11.9 | 19.3 | 14.2  | 14.4 | 11.0 | 14.7  | 10.3 |
+// volume               | 11.6 | 13.0 | 13.8  | 15.9 | 16.8 | 19.1  | 14.7 | 11.7 | 17.4  | 15.0 |
+// anchored cum(volume) | 11.6 | 24.6 | 38.4  | 15.9 | 32.7 | 51.8  | 14.7 | 26.4 | 43.8  | 15.0 |
+// anchored sma(close)  |      | 17.6 | 18.45 |      | 15.6 | 16.75 |      | 12.7 | 12.85 |      |
+func TestSeriesAnchoredIteration(t *testing.T) {
+	series, err := NewOHLCVSeries(anchoredTestData())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// array in order of cum, sma
+	tests := [][]*float64{
+		{NewFloat64(11.6), nil},
+		{NewFloat64(24.6), NewFloat64(17.6)},
+		{NewFloat64(38.4), NewFloat64(18.45)},
+		{NewFloat64(15.9), nil},
+		{NewFloat64(32.7), NewFloat64(15.6)},
+		{NewFloat64(51.8), NewFloat64(16.75)},
+		{NewFloat64(14.7), nil},
+		{NewFloat64(26.4), NewFloat64(12.7)},
+		{NewFloat64(43.8), NewFloat64(12.85)},
+		{NewFloat64(15.0), nil},
+	}
+
+	for i, v := range tests {
+		series.Next()
+		vol := OHLCVAttr(series, OHLCPropVolume)
+		c := OHLCVAttr(series, OHLCPropClose)
+		cum := Anchored(vol, AnchorPeriodDay, "cum", Cum)
+		sma := Anchored(c, AnchorPeriodDay, "sma2", func(p ValueSeries) ValueSeries {
+			return SMA(p, 2)
+		})
+		assertSeriesVal(t, "cum", i, v[0], cum)
+		assertSeriesVal(t, "sma", i, v[1], sma)
+	}
+}
+
+// TestSeriesAnchoredSeries tests that the indicator starts over where the boolean series is true
+//
+// close                 | 16.5 | 18.7 | 18.2 | 11.9 | 19.3 | 14.2 | 14.4 | 11.0 | 14.7 | 10.3 |
+// rising(close, 1)      |      | 1    | 0    | 0    | 1    | 0    | 1    | 0    | 1    | 0    |
+// anchored cum(close)   | 16.5 | 18.7 | 36.9 | 48.8 | 19.3 | 33.5 | 14.4 | 25.4 | 14.7 | 25.0 |
+// anchored at 6th value |      |      |      |      |      | 14.2 | 28.6 | 39.6 | 54.3 | 64.6 |
+func TestSeriesAnchoredSeries(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// array in order of cum, cumtime
+	tests := [][]*float64{
+		{NewFloat64(16.5), nil},
+		{NewFloat64(18.7), nil},
+		{NewFloat64(36.9), nil},
+		{NewFloat64(48.8), nil},
+		{NewFloat64(19.3), nil},
+		{NewFloat64(33.5), NewFloat64(14.2)},
+		{NewFloat64(14.4), NewFloat64(28.6)},
+		{NewFloat64(25.4), NewFloat64(39.6)},
+		{NewFloat64(14.7), NewFloat64(54.3)},
+		{NewFloat64(25.0), NewFloat64(64.6)},
+	}
+
+	for i, v := range tests {
+		series.Next()
+		c := OHLCVAttr(series, OHLCPropClose)
+		cum := Anchored(c, AnchorSeries(Rising(c, 1)), "cum", Cum)
+		cumtime := Anchored(c, AnchorTime(data[5].S), "cum", Cum)
+		assertSeriesVal(t, "cum", i, v[0], cum)
+		assertSeriesVal(t, "cumtime", i, v[1], cumtime)
+	}
+}
+
+// TestSeriesAnchoredPurge tests that the cached series of the previous periods are deleted
+func TestSeriesAnchoredPurge(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var size int
+	for i := range data {
+		series.Next()
+		c := OHLCVAttr(series, OHLCPropClose)
+		// every value begins a new period
+		Anchored(c, AnchorSeries(ReplaceAll(c, 1)), "ema", func(p ValueSeries) ValueSeries {
+			return EMA(SMA(p, 1), 1)
+		})
+		if i > 0 && len(cache) != size {
+			t.Errorf("Expected the cache size to be %d but got %d for iteration: %d", size, len(cache), i)
+		}
+		size = len(cache)
+	}
+}
+
+// TestSeriesAnchoredPurgeZigZag tests that the states created by fn are deleted along with the period
+func TestSeriesAnchoredPurgeZigZag(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var size, states int
+	for i := range data {
+		series.Next()
+		c := OHLCVAttr(series, OHLCPropClose)
+		// every value begins a new period
+		Anchored(c, AnchorSeries(ReplaceAll(c, 1)), "zigzag", func(p ValueSeries) ValueSeries {
+			v := p.Val()
+			o, err := NewOHLCVSeries([]OHLCV{{O: *v, H: *v, L: *v, C: *v, S: data[i].S}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			o.Next()
+			swing, _, _ := ZigZag(o, ZigZagPercent(30))
+			return swing
+		})
+		if i > 0 && len(cache) != size {
+			t.Errorf("Expected the cache size to be %d but got %d for iteration: %d", size, len(cache), i)
+		}
+		if i > 0 && len(zigZagCache) != states {
+			t.Errorf("Expected the zigzag states to be %d but got %d for iteration: %d", states, len(zigZagCache), i)
+		}
+		size = len(cache)
+		states = len(zigZagCache)
+	}
+}
+
+// TestSeriesAnchoredLastTrimmed tests that the calculation resumes after the last processed value is trimmed from the source
+// The first two values are processed and the source is trimmed to the last three values of the eighth call, which start the third day.
+func TestSeriesAnchoredLastTrimmed(t *testing.T) {
+	series, err := NewOHLCVSeries(anchoredTestData())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		series.Next()
+		Anchored(OHLCVAttr(series, OHLCPropVolume), AnchorPeriodDay, "cum", Cum)
+	}
+	for i := 0; i < 6; i++ {
+		series.Next()
+	}
+	vol := OHLCVAttr(series, OHLCPropVolume)
+	vol.SetMax(3)
+
+	tests := []*float64{NewFloat64(26.4), NewFloat64(43.8), NewFloat64(15.0)}
+	for i, v := range tests {
+		if i > 0 {
+			series.Next()
+			vol = OHLCVAttr(series, OHLCPropVolume)
+		}
+		assertSeriesVal(t, "cum", i, v, Anchored(vol, AnchorPeriodDay, "cum", Cum))
+	}
+}
+
+func TestMemoryLeakAnchored(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		c := OHLCVAttr(o, OHLCPropClose)
+		Anchored(c, AnchorSeries(Rising(c, 1)), "rsi", func(p ValueSeries) ValueSeries {
+			return RSI(p, 3)
+		})
+		return nil
+	})
+}
+
+func ExampleAnchored() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	ny, _ := time.LoadLocation("America/New_York")
+	session := AnchorSession(9*time.Hour+30*time.Minute, ny)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		// RSI which restarts every session and the cumulative volume of the session
+		rsi := Anchored(OHLCVAttr(series, OHLCPropClose), session, "rsi14", func(p ValueSeries) ValueSeries {
+			return RSI(p, 14)
+		})
+		vol := Anchored(OHLCVAttr(series, OHLCPropVolume), session, "cum", Cum)
+		log.Printf("RSI: %+v, session volume: %+v", rsi.Val(), vol.Val())
+	}
+}
@@ -0,0 +1,37 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// AO generates a ValueSeries of awesome oscillator.
+//
+// The formula for AO is
+//   - ao = sma(hl2, fastl) - sma(hl2, slowl)
+//
+// The arguments are:
+//   - o: OHLCVSeries - source of data
+//   - fastl: int64 - fast length. 5 is commonly used
+//   - slowl: int64 - slow length. 34 is commonly used
+func AO(o OHLCVSeries, fastl, slowl int64) ValueSeries {
+	key := fmt.Sprintf("ao:%s:%d:%d", o.ID(), fastl, slowl)
+	ao := getCache(key)
+	if ao == nil {
+		ao = NewValueSeries()
+	}
+
+	stop := o.Current()
+	if stop == nil {
+		return ao
+	}
+
+	hl2 := OHLCVAttr(o, OHLCPropHL2)
+
+	ao = Sub(SMA(hl2, fastl), SMA(hl2, slowl))
+
+	setCache(key, ao)
+
+	ao.SetCurrent(stop.S)
+
+	return ao
+}
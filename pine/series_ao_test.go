@@ -0,0 +1,62 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesAONoData tests no data scenario
+func TestSeriesAONoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ao := AO(series, 2, 4)
+	if ao == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if ao.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *ao.Val())
+	}
+}
+
+// TestSeriesAOReference tests AO(5, 34) against sma(hl2, 5) - sma(hl2, 34) with TA-Lib's MEDPRICE and SMA on referenceTestData
+func TestSeriesAOReference(t *testing.T) {
+	exp := []float64{
+		-0.1538, 0.1385, 0.2105, -0.3068, -1.1136, -2.0777, -2.5126, -3.2630,
+		-3.5562, -4.0605, -4.5946, -5.3824, -6.0129, -6.8962, -7.7364, -8.0391,
+		-8.4373, -8.8080, -8.4361, -8.1341, -8.1228, -8.2071, -8.0255, -8.1440,
+		-7.4457, -6.5784, -5.2893,
+	}
+
+	assertReference(t, "ao", 33, exp, func(o OHLCVSeries) ValueSeries {
+		return AO(o, 5, 34)
+	})
+}
+
+func TestMemoryLeakAO(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		AO(o, 2, 4)
+		return nil
+	})
+}
+
+func ExampleAO() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		ao := AO(series, 2, 4)
+		log.Printf("AO: %+v", ao.Val())
+	}
+}
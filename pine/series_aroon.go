@@ -0,0 +1,60 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// Aroon generates ValueSeries of Aroon up, Aroon down and Aroon oscillator in that order.
+// Aroon up and down measure how many bars have passed since the highest high and the lowest low in the range of [0, 100].
+//
+// The formula for Aroon is
+//   - up = 100 * (highestbars(high, l + 1) + l) / l
+//   - down = 100 * (lowestbars(low, l + 1) + l) / l
+//   - oscillator = up - down
+//
+// If multiple values are the highest or the lowest, the most recent one is used.
+//
+// The arguments are:
+//   - o: OHLCVSeries - source of data
+//   - l: int64 - lookback periods [1, ∞)
+func Aroon(o OHLCVSeries, l int64) (up, down, osc ValueSeries) {
+	upkey := fmt.Sprintf("aroonup:%s:%d", o.ID(), l)
+	up = getCache(upkey)
+	if up == nil {
+		up = NewValueSeries()
+	}
+
+	downkey := fmt.Sprintf("aroondown:%s:%d", o.ID(), l)
+	down = getCache(downkey)
+	if down == nil {
+		down = NewValueSeries()
+	}
+
+	osckey := fmt.Sprintf("aroonosc:%s:%d", o.ID(), l)
+	osc = getCache(osckey)
+	if osc == nil {
+		osc = NewValueSeries()
+	}
+
+	stop := o.Current()
+	if stop == nil {
+		return up, down, osc
+	}
+
+	hb := HighestBars(OHLCVAttr(o, OHLCPropHigh), l+1)
+	lb := LowestBars(OHLCVAttr(o, OHLCPropLow), l+1)
+
+	up = MulConst(AddConst(hb, float64(l)), 100/float64(l))
+	down = MulConst(AddConst(lb, float64(l)), 100/float64(l))
+	osc = Sub(up, down)
+
+	up.SetCurrent(stop.S)
+	down.SetCurrent(stop.S)
+	osc.SetCurrent(stop.S)
+
+	setCache(upkey, up)
+	setCache(downkey, down)
+	setCache(osckey, osc)
+
+	return up, down, osc
+}
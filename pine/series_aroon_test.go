@@ -0,0 +1,87 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesAroonNoData tests no data scenario
+func TestSeriesAroonNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	up, down, osc := Aroon(series, 3)
+	if up == nil || down == nil || osc == nil {
+		t.Fatal("Expected to be non nil but got nil")
+	}
+	if up.Val() != nil || down.Val() != nil || osc.Val() != nil {
+		t.Error("Expected to be nil but got non nil")
+	}
+}
+
+// TestSeriesAroonReference tests Aroon(14) against TA-Lib's AROON and AROONOSC on referenceTestData
+func TestSeriesAroonReference(t *testing.T) {
+	assertReference(t, "up", 14, []float64{
+		0.0000, 78.5714, 71.4286, 64.2857, 57.1429, 50.0000, 42.8571, 35.7143,
+		28.5714, 21.4286, 14.2857, 7.1429, 0.0000, 92.8571, 85.7143, 78.5714,
+		71.4286, 64.2857, 57.1429, 50.0000, 42.8571, 35.7143, 28.5714, 21.4286,
+		14.2857, 7.1429, 0.0000, 0.0000, 0.0000, 21.4286, 14.2857, 7.1429,
+		0.0000, 0.0000, 0.0000, 0.0000, 0.0000, 0.0000, 7.1429, 0.0000,
+		0.0000, 0.0000, 0.0000, 7.1429, 0.0000, 0.0000,
+	}, func(o OHLCVSeries) ValueSeries {
+		up, _, _ := Aroon(o, 14)
+		return up
+	})
+
+	assertReference(t, "down", 14, []float64{
+		21.4286, 14.2857, 7.1429, 0.0000, 0.0000, 92.8571, 85.7143, 100.0000,
+		92.8571, 100.0000, 92.8571, 85.7143, 78.5714, 71.4286, 64.2857, 57.1429,
+		50.0000, 42.8571, 35.7143, 28.5714, 21.4286, 14.2857, 100.0000, 92.8571,
+		85.7143, 78.5714, 100.0000, 100.0000, 100.0000, 92.8571, 100.0000, 100.0000,
+		100.0000, 100.0000, 92.8571, 85.7143, 78.5714, 71.4286, 64.2857, 57.1429,
+		100.0000, 100.0000, 92.8571, 85.7143, 78.5714, 71.4286,
+	}, func(o OHLCVSeries) ValueSeries {
+		_, down, _ := Aroon(o, 14)
+		return down
+	})
+
+	assertReference(t, "osc", 14, []float64{
+		-21.4286, 64.2857, 64.2857, 64.2857, 57.1429, -42.8571, -42.8571, -64.2857,
+		-64.2857, -78.5714, -78.5714, -78.5714, -78.5714, 21.4286, 21.4286, 21.4286,
+		21.4286, 21.4286, 21.4286, 21.4286, 21.4286, 21.4286, -71.4286, -71.4286,
+		-71.4286, -71.4286, -100.0000, -100.0000, -100.0000, -71.4286, -85.7143, -92.8571,
+		-100.0000, -100.0000, -92.8571, -85.7143, -78.5714, -71.4286, -57.1429, -57.1429,
+		-100.0000, -100.0000, -92.8571, -78.5714, -78.5714, -71.4286,
+	}, func(o OHLCVSeries) ValueSeries {
+		_, _, osc := Aroon(o, 14)
+		return osc
+	})
+}
+
+func TestMemoryLeakAroon(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		Aroon(o, 3)
+		return nil
+	})
+}
+
+func ExampleAroon() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		up, down, osc := Aroon(series, 3)
+		log.Printf("up: %+v, down: %+v, osc: %+v", up.Val(), down.Val(), osc.Val())
+	}
+}
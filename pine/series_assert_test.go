@@ -0,0 +1,158 @@
+package pine
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+// assertSeriesVal checks that the current value of vs matches exp up to 4 decimal places.
+// exp of nil expects the current value to be nil.
+func assertSeriesVal(t *testing.T, name string, i int, exp *float64, vs ValueSeries) {
+	t.Helper()
+	got := vs.Val()
+	if (got == nil) != (exp == nil) {
+		if got != nil {
+			t.Errorf("Expected %s to be nil but got %+v for iteration: %d", name, *got, i)
+		} else {
+			t.Errorf("Expected %s to be %+v but got nil for iteration: %d", name, *exp, i)
+		}
+		return
+	}
+	if exp != nil && fmt.Sprintf("%.04f", *exp) != fmt.Sprintf("%.04f", *got) {
+		t.Errorf("Expected %s to be %+v but got %+v for iteration: %d", name, *exp, *got, i)
+	}
+}
+
+// assertFlat runs fn over OHLCVStaticTestData with every close set to the same value.
+// The values before the iteration first are expected to be nil and the rest to be the flat value.
+func assertFlat(t *testing.T, name string, first int, fn func(p ValueSeries) ValueSeries) {
+	t.Helper()
+	assertFlatTo(t, name, first, 12.5, fn)
+}
+
+// assertFlatTo is like assertFlat but expects the values from the iteration first to be exp, i.e. 0 for oscillators.
+func assertFlatTo(t *testing.T, name string, first int, exp float64, fn func(p ValueSeries) ValueSeries) {
+	t.Helper()
+	data := OHLCVStaticTestData()
+	for i := range data {
+		data[i].C = 12.5
+	}
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range data {
+		series.Next()
+		var v *float64
+		if i >= first {
+			v = NewFloat64(exp)
+		}
+		assertSeriesVal(t, name, i, v, fn(OHLCVAttr(series, OHLCPropClose)))
+	}
+}
+
+// assertFlatOHLCV runs fn over OHLCVStaticTestData with every price of every OHLCV set to the same value.
+// The values before the iteration first are expected to be nil and the rest to be exp. exp of nil expects no value at all.
+func assertFlatOHLCV(t *testing.T, name string, first int, exp *float64, fn func(o OHLCVSeries) ValueSeries) {
+	t.Helper()
+	data := OHLCVStaticTestData()
+	for i := range data {
+		data[i].O, data[i].H, data[i].L, data[i].C = 12.5, 12.5, 12.5, 12.5
+	}
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range data {
+		series.Next()
+		var v *float64
+		if i >= first {
+			v = exp
+		}
+		assertSeriesVal(t, name, i, v, fn(series))
+	}
+}
+
+// assertSourceTrimmed runs fn over referenceTestData twice, first as is and then trimming the source to max values
+// before every call, and expects the same values from both runs.
+func assertSourceTrimmed(t *testing.T, name string, max int64, fn func(p ValueSeries) ValueSeries) {
+	t.Helper()
+	data := referenceTestData()
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := make([]*float64, len(data))
+	for i := range data {
+		series.Next()
+		if v := fn(OHLCVAttr(series, OHLCPropClose)).Val(); v != nil {
+			exp[i] = NewFloat64(*v)
+		}
+	}
+
+	trimmed, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range data {
+		trimmed.Next()
+		prop := OHLCVAttr(trimmed, OHLCPropClose)
+		prop.SetMax(max)
+		assertSeriesVal(t, name, i, exp[i], fn(prop))
+	}
+}
+
+// sineTestData returns n OHLCVs whose prices are a sine around 100 with the amplitude of 1 and the period.
+func sineTestData(n int, period float64) []OHLCV {
+	start := time.Now()
+	data := make([]OHLCV, n)
+	for i := range data {
+		v := 100 + math.Sin(2*math.Pi*float64(i)/period)
+		data[i] = OHLCV{O: v, H: v, L: v, C: v, V: 1, S: start.Add(time.Duration(i) * 5 * time.Minute)}
+	}
+	return data
+}
+
+// sineAmplitude runs fn over 600 values of sineTestData and returns the amplitude of the output over the last 200 values.
+func sineAmplitude(t *testing.T, period float64, fn func(p ValueSeries) ValueSeries) float64 {
+	t.Helper()
+	data := sineTestData(600, period)
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hi, lo := math.Inf(-1), math.Inf(1)
+	for i := range data {
+		series.Next()
+		v := fn(OHLCVAttr(series, OHLCPropClose)).Val()
+		if i < len(data)-200 || v == nil {
+			continue
+		}
+		hi, lo = math.Max(hi, *v), math.Min(lo, *v)
+	}
+	return (hi - lo) / 2
+}
+
+// assertTrigger runs fn over referenceTestData and expects the trigger to be the previous value of the line.
+func assertTrigger(t *testing.T, name string, fn func(o OHLCVSeries) (line, trigger ValueSeries)) {
+	t.Helper()
+	data := referenceTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var prev *float64
+	for i := range data {
+		series.Next()
+		line, trigger := fn(series)
+		assertSeriesVal(t, name, i, prev, trigger)
+		prev = line.Val()
+	}
+}
@@ -15,5 +15,10 @@ package pine
 //   - ATR: ValueSeries - ATR
 //   - err: error
 func ATR(tr ValueSeries, l int64) ValueSeries {
-	return RMA(tr, l)
+	return ATRWithMA(tr, l, MATypeRMA)
+}
+
+// ATRWithMA generates a ValueSeries of average true range smoothed by the moving average of type mat
+func ATRWithMA(tr ValueSeries, l int64, mat MAType) ValueSeries {
+	return MA(tr, l, mat)
 }
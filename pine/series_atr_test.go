@@ -162,6 +162,34 @@ func TestSeriesATRNotEnoughData(t *testing.T) {
 	}
 }
 
+// TestSeriesATRWithMA tests ATR smoothed by SMA against values of the reference formula
+func TestSeriesATRWithMA(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		nil,
+		nil,
+		NewFloat64(7.9667),
+		NewFloat64(7.7333),
+		NewFloat64(8.2333),
+		NewFloat64(7.5000),
+		NewFloat64(7.0667),
+		NewFloat64(7.5000),
+		NewFloat64(8.0667),
+		NewFloat64(8.4000),
+	}
+
+	for i, v := range tests {
+		series.Next()
+		atr := ATRWithMA(OHLCVAttr(series, OHLCPropTRHL), 3, MATypeSMA)
+		assertSeriesVal(t, "atr", i, v, atr)
+	}
+}
+
 func TestMemoryLeakATR(t *testing.T) {
 	testMemoryLeak(t, func(o OHLCVSeries) error {
 		c := OHLCVAttr(o, OHLCPropClose)
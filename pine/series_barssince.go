@@ -0,0 +1,70 @@
+package pine
+
+import (
+	"fmt"
+	"time"
+)
+
+// BarsSince generates a ValueSeries of the number of values since the condition was last true.
+// The value is 0 when the condition is true and there are no values until the condition is true for the first time.
+//
+// Parameters
+//   - bs - ValueSeries: condition where non-zero values are true, i.e. 1.0 from Crossover
+func BarsSince(bs ValueSeries) ValueSeries {
+	key := fmt.Sprintf("barssince:%s", bs.ID())
+	since := getCache(key)
+	if since == nil {
+		since = NewValueSeries()
+	}
+
+	// current available value
+	stop := bs.GetCurrent()
+	if stop == nil {
+		return since
+	}
+
+	s := barsSinceCache[key]
+
+	var f *Value
+	if s == nil {
+		s = &barsSinceState{count: -1}
+		f = bs.GetFirst()
+	} else {
+		f = valueAfter(bs, s.last)
+	}
+	for {
+		if f == nil {
+			break
+		}
+		if f.v != 0 {
+			s.count = 0
+		} else if s.count >= 0 {
+			s.count++
+		}
+		if s.count >= 0 {
+			since.Set(f.t, s.count)
+		}
+		s.last = f.t
+		if f.t.Equal(stop.t) {
+			break
+		}
+		f = f.next
+	}
+
+	setCache(key, since)
+	setState(barsSinceCache, key, s)
+
+	since.SetCurrent(stop.t)
+
+	return since
+}
+
+// barsSinceState is the state of BarsSince carried over to the next call
+type barsSinceState struct {
+	// number of values since the condition was true, which is -1 until the condition is true for the first time
+	count float64
+	// time of the last processed value
+	last time.Time
+}
+
+var barsSinceCache map[string]*barsSinceState = make(map[string]*barsSinceState)
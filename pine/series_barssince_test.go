@@ -0,0 +1,118 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesBarsSinceNoData tests no data scenario
+func TestSeriesBarsSinceNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := OHLCVAttr(series, OHLCPropClose)
+	o := OHLCVAttr(series, OHLCPropOpen)
+	bs := BarsSince(Crossover(c, o))
+	if bs == nil {
+		t.Fatal("Expected to be non nil but got nil")
+	}
+	if bs.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *bs.Val())
+	}
+}
+
+// TestSeriesBarsSinceIteration tests the number of bars since close crossed over open
+//
+// crossover(close, open) | 0   | 0   | 0   | 0   | 1 | 0 | 0 | 1 | 0 | 0 |
+// barssince              | nil | nil | nil | nil | 0 | 1 | 2 | 0 | 1 | 2 |
+func TestSeriesBarsSinceIteration(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		nil,
+		nil,
+		nil,
+		nil,
+		NewFloat64(0),
+		NewFloat64(1),
+		NewFloat64(2),
+		NewFloat64(0),
+		NewFloat64(1),
+		NewFloat64(2),
+	}
+
+	for i, v := range tests {
+		series.Next()
+		c := OHLCVAttr(series, OHLCPropClose)
+		o := OHLCVAttr(series, OHLCPropOpen)
+		bs := BarsSince(Crossover(c, o))
+		assertSeriesVal(t, "barssince", i, v, bs)
+	}
+}
+
+// TestSeriesBarsSinceSetMax tests that the last true value is carried over after the series are trimmed
+func TestSeriesBarsSinceSetMax(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		nil,
+		nil,
+		nil,
+		nil,
+		NewFloat64(0),
+		NewFloat64(1),
+		NewFloat64(2),
+		NewFloat64(0),
+		NewFloat64(1),
+		NewFloat64(2),
+	}
+
+	for i, v := range tests {
+		series.Next()
+		c := OHLCVAttr(series, OHLCPropClose)
+		o := OHLCVAttr(series, OHLCPropOpen)
+		bs := BarsSince(Crossover(c, o))
+		bs.SetMax(1)
+		assertSeriesVal(t, "barssince", i, v, bs)
+	}
+}
+
+func TestMemoryLeakBarsSince(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		c := OHLCVAttr(o, OHLCPropClose)
+		op := OHLCVAttr(o, OHLCPropOpen)
+		BarsSince(Crossover(c, op))
+		return nil
+	})
+}
+
+func ExampleBarsSince() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		c := OHLCVAttr(series, OHLCPropClose)
+		o := OHLCVAttr(series, OHLCPropOpen)
+		bs := BarsSince(Crossover(c, o))
+		log.Printf("BarsSince: %+v", bs.Val())
+	}
+}
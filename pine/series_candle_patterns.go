@@ -0,0 +1,205 @@
+package pine
+
+// Doji detects a candle whose body is very small and the upper and lower shadows are about the same size.
+// Dragonfly and gravestone dojis are excluded.
+func Doji(o OHLCVSeries, opts CandleOpts) ValueSeries {
+	return generateCandlePattern(o, opts, "doji", 1, func(c []candle) bool {
+		return c[0].dojiBody && c[0].shadowEquals && !isDragonflyDoji(c[0]) && !isGravestoneDoji(c[0])
+	})
+}
+
+// DragonflyDoji detects a doji whose upper shadow is no larger than the body
+func DragonflyDoji(o OHLCVSeries, opts CandleOpts) ValueSeries {
+	return generateCandlePattern(o, opts, "dragonflydoji", 1, func(c []candle) bool {
+		return isDragonflyDoji(c[0])
+	})
+}
+
+// GravestoneDoji detects a doji whose lower shadow is no larger than the body
+func GravestoneDoji(o OHLCVSeries, opts CandleOpts) ValueSeries {
+	return generateCandlePattern(o, opts, "gravestonedoji", 1, func(c []candle) bool {
+		return isGravestoneDoji(c[0])
+	})
+}
+
+// Hammer detects a small body at the top of the range with a long lower shadow in a downtrend
+//
+// The formula for Hammer is
+//   - smallBody and body > 0 and bodyLo > hl2 and dnShadow >= Factor * body and not hasUpShadow and downTrend
+func Hammer(o OHLCVSeries, opts CandleOpts) ValueSeries {
+	return generateCandlePattern(o, opts, "hammer", 1, func(c []candle) bool {
+		return isHammerShape(c[0], opts) && c[0].downTrend
+	})
+}
+
+// HangingMan detects the hammer shape in an uptrend
+func HangingMan(o OHLCVSeries, opts CandleOpts) ValueSeries {
+	return generateCandlePattern(o, opts, "hangingman", 1, func(c []candle) bool {
+		return isHammerShape(c[0], opts) && c[0].upTrend
+	})
+}
+
+// InvertedHammer detects a small body at the bottom of the range with a long upper shadow in a downtrend
+//
+// The formula for InvertedHammer is
+//   - smallBody and body > 0 and bodyHi < hl2 and upShadow >= Factor * body and not hasDnShadow and downTrend
+func InvertedHammer(o OHLCVSeries, opts CandleOpts) ValueSeries {
+	return generateCandlePattern(o, opts, "invertedhammer", 1, func(c []candle) bool {
+		return isInvertedHammerShape(c[0], opts) && c[0].downTrend
+	})
+}
+
+// ShootingStar detects the inverted hammer shape in an uptrend
+func ShootingStar(o OHLCVSeries, opts CandleOpts) ValueSeries {
+	return generateCandlePattern(o, opts, "shootingstar", 1, func(c []candle) bool {
+		return isInvertedHammerShape(c[0], opts) && c[0].upTrend
+	})
+}
+
+// EngulfingBullish detects a long white body engulfing the previous small black body in a downtrend
+//
+// The formula for EngulfingBullish is
+//   - downTrend and whiteBody and longBody and blackBody[1] and smallBody[1] and close >= open[1] and open <= close[1] and (close > open[1] or open < close[1])
+func EngulfingBullish(o OHLCVSeries, opts CandleOpts) ValueSeries {
+	return generateCandlePattern(o, opts, "engulfingbullish", 2, func(c []candle) bool {
+		return c[0].downTrend && c[0].whiteBody && c[0].longBody && c[1].blackBody && c[1].smallBody &&
+			c[0].c >= c[1].o && c[0].o <= c[1].c && (c[0].c > c[1].o || c[0].o < c[1].c)
+	})
+}
+
+// EngulfingBearish detects a long black body engulfing the previous small white body in an uptrend
+//
+// The formula for EngulfingBearish is
+//   - upTrend and blackBody and longBody and whiteBody[1] and smallBody[1] and close <= open[1] and open >= close[1] and (close < open[1] or open > close[1])
+func EngulfingBearish(o OHLCVSeries, opts CandleOpts) ValueSeries {
+	return generateCandlePattern(o, opts, "engulfingbearish", 2, func(c []candle) bool {
+		return c[0].upTrend && c[0].blackBody && c[0].longBody && c[1].whiteBody && c[1].smallBody &&
+			c[0].c <= c[1].o && c[0].o >= c[1].c && (c[0].c < c[1].o || c[0].o > c[1].c)
+	})
+}
+
+// HaramiBullish detects a small white candle within the body of the previous long black body in a downtrend
+//
+// The formula for HaramiBullish is
+//   - longBody[1] and blackBody[1] and downTrend[1] and whiteBody and smallBody and high <= bodyHi[1] and low >= bodyLo[1]
+func HaramiBullish(o OHLCVSeries, opts CandleOpts) ValueSeries {
+	return generateCandlePattern(o, opts, "haramibullish", 2, func(c []candle) bool {
+		return c[1].longBody && c[1].blackBody && c[1].downTrend && c[0].whiteBody && c[0].smallBody &&
+			c[0].h <= c[1].bodyHi && c[0].l >= c[1].bodyLo
+	})
+}
+
+// HaramiBearish detects a small black candle within the body of the previous long white body in an uptrend
+//
+// The formula for HaramiBearish is
+//   - longBody[1] and whiteBody[1] and upTrend[1] and blackBody and smallBody and high <= bodyHi[1] and low >= bodyLo[1]
+func HaramiBearish(o OHLCVSeries, opts CandleOpts) ValueSeries {
+	return generateCandlePattern(o, opts, "haramibearish", 2, func(c []candle) bool {
+		return c[1].longBody && c[1].whiteBody && c[1].upTrend && c[0].blackBody && c[0].smallBody &&
+			c[0].h <= c[1].bodyHi && c[0].l >= c[1].bodyLo
+	})
+}
+
+// MorningStar detects a long black body, a small body gapping down and a long white body closing into the first body in a downtrend
+//
+// The formula for MorningStar is
+//   - longBody[2] and smallBody[1] and longBody and downTrend and blackBody[2] and bodyHi[1] < bodyLo[2] and whiteBody and bodyHi >= bodyMiddle[2] and bodyHi < bodyHi[2] and bodyHi[1] < bodyLo
+func MorningStar(o OHLCVSeries, opts CandleOpts) ValueSeries {
+	return generateCandlePattern(o, opts, "morningstar", 3, func(c []candle) bool {
+		return c[2].longBody && c[1].smallBody && c[0].longBody && c[0].downTrend && c[2].blackBody &&
+			c[1].bodyHi < c[2].bodyLo && c[0].whiteBody && c[0].bodyHi >= c[2].bodyMiddle &&
+			c[0].bodyHi < c[2].bodyHi && c[1].bodyHi < c[0].bodyLo
+	})
+}
+
+// EveningStar detects a long white body, a small body gapping up and a long black body closing into the first body in an uptrend
+//
+// The formula for EveningStar is
+//   - longBody[2] and smallBody[1] and longBody and upTrend and whiteBody[2] and bodyLo[1] > bodyHi[2] and blackBody and bodyLo <= bodyMiddle[2] and bodyLo > bodyLo[2] and bodyLo[1] > bodyHi
+func EveningStar(o OHLCVSeries, opts CandleOpts) ValueSeries {
+	return generateCandlePattern(o, opts, "eveningstar", 3, func(c []candle) bool {
+		return c[2].longBody && c[1].smallBody && c[0].longBody && c[0].upTrend && c[2].whiteBody &&
+			c[1].bodyLo > c[2].bodyHi && c[0].blackBody && c[0].bodyLo <= c[2].bodyMiddle &&
+			c[0].bodyLo > c[2].bodyLo && c[1].bodyLo > c[0].bodyHi
+	})
+}
+
+// ThreeWhiteSoldiers detects three long white bodies each opening within the previous body and closing higher without upper shadows.
+// Upper shadows smaller than ShadowPercent of the range are ignored.
+func ThreeWhiteSoldiers(o OHLCVSeries, opts CandleOpts) ValueSeries {
+	return generateCandlePattern(o, opts, "threewhitesoldiers", 3, func(c []candle) bool {
+		for _, v := range c {
+			if !v.longBody || !v.whiteBody || v.rng*opts.ShadowPercent/100 <= v.upShadow {
+				return false
+			}
+		}
+		return c[0].c > c[1].c && c[1].c > c[2].c && c[0].o < c[1].c && c[0].o > c[1].o &&
+			c[1].o < c[2].c && c[1].o > c[2].o
+	})
+}
+
+// ThreeBlackCrows detects three long black bodies each opening within the previous body and closing lower without lower shadows.
+// Lower shadows smaller than ShadowPercent of the range are ignored.
+func ThreeBlackCrows(o OHLCVSeries, opts CandleOpts) ValueSeries {
+	return generateCandlePattern(o, opts, "threeblackcrows", 3, func(c []candle) bool {
+		for _, v := range c {
+			if !v.longBody || !v.blackBody || v.rng*opts.ShadowPercent/100 <= v.dnShadow {
+				return false
+			}
+		}
+		return c[0].c < c[1].c && c[1].c < c[2].c && c[0].o > c[1].c && c[0].o < c[1].o &&
+			c[1].o > c[2].c && c[1].o < c[2].o
+	})
+}
+
+// Piercing detects a white candle opening below the previous low and closing above the middle of the previous long black body in a downtrend
+//
+// The formula for Piercing is
+//   - downTrend[1] and blackBody[1] and longBody[1] and whiteBody and open <= low[1] and close > bodyMiddle[1] and close < open[1]
+func Piercing(o OHLCVSeries, opts CandleOpts) ValueSeries {
+	return generateCandlePattern(o, opts, "piercing", 2, func(c []candle) bool {
+		return c[1].downTrend && c[1].blackBody && c[1].longBody && c[0].whiteBody &&
+			c[0].o <= c[1].l && c[0].c > c[1].bodyMiddle && c[0].c < c[1].o
+	})
+}
+
+// DarkCloudCover detects a black candle opening above the previous high and closing below the middle of the previous long white body in an uptrend
+//
+// The formula for DarkCloudCover is
+//   - upTrend[1] and whiteBody[1] and longBody[1] and blackBody and open >= high[1] and close < bodyMiddle[1] and close > open[1]
+func DarkCloudCover(o OHLCVSeries, opts CandleOpts) ValueSeries {
+	return generateCandlePattern(o, opts, "darkcloudcover", 2, func(c []candle) bool {
+		return c[1].upTrend && c[1].whiteBody && c[1].longBody && c[0].blackBody &&
+			c[0].o >= c[1].h && c[0].c < c[1].bodyMiddle && c[0].c > c[1].o
+	})
+}
+
+// MarubozuWhite detects a long white body with shadows no larger than ShadowPercent of the body
+func MarubozuWhite(o OHLCVSeries, opts CandleOpts) ValueSeries {
+	return generateCandlePattern(o, opts, "marubozuwhite", 1, func(c []candle) bool {
+		return c[0].whiteBody && c[0].longBody && !c[0].hasUpShadow && !c[0].hasDnShadow
+	})
+}
+
+// MarubozuBlack detects a long black body with shadows no larger than ShadowPercent of the body
+func MarubozuBlack(o OHLCVSeries, opts CandleOpts) ValueSeries {
+	return generateCandlePattern(o, opts, "marubozublack", 1, func(c []candle) bool {
+		return c[0].blackBody && c[0].longBody && !c[0].hasUpShadow && !c[0].hasDnShadow
+	})
+}
+
+func isDragonflyDoji(c candle) bool {
+	return c.dojiBody && c.upShadow <= c.body
+}
+
+func isGravestoneDoji(c candle) bool {
+	return c.dojiBody && c.dnShadow <= c.body
+}
+
+func isHammerShape(c candle, opts CandleOpts) bool {
+	return c.smallBody && c.body > 0 && c.bodyLo > (c.h+c.l)/2 && c.dnShadow >= opts.Factor*c.body && !c.hasUpShadow
+}
+
+func isInvertedHammerShape(c candle, opts CandleOpts) bool {
+	return c.smallBody && c.body > 0 && c.bodyHi < (c.h+c.l)/2 && c.upShadow >= opts.Factor*c.body && !c.hasDnShadow
+}
@@ -0,0 +1,309 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// candleTestData generates OHLCV of three white candles with the body of 2 followed by bars in the order of open, high, low, close
+func candleTestData(bars [][4]float64) []OHLCV {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	prefix := [][4]float64{
+		{10, 12.5, 9.5, 12},
+		{10, 12.5, 9.5, 12},
+		{10, 12.5, 9.5, 12},
+	}
+
+	data := make([]OHLCV, 0)
+	for i, v := range append(prefix, bars...) {
+		data = append(data, OHLCV{
+			O: v[0],
+			H: v[1],
+			L: v[2],
+			C: v[3],
+			V: 100,
+			S: start.Add(time.Duration(i) * 5 * time.Minute),
+		})
+	}
+	return data
+}
+
+// TestSeriesCandlePatternsNoData tests no data scenario
+func TestSeriesCandlePatternsNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hammer := Hammer(series, NewCandleOpts())
+	if hammer == nil {
+		t.Fatal("Expected to be non nil but got nil")
+	}
+	if hammer.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *hammer.Val())
+	}
+}
+
+// TestSeriesCandlePatterns tests that each pattern is detected only at the last candle without the trend context
+//
+// The average body is 2 before the pattern and the EMA of the body has the lookback of 3
+func TestSeriesCandlePatterns(t *testing.T) {
+	opts := NewCandleOpts()
+	opts.Trend = CandleTrendNone
+	opts.BodyAvgLen = 3
+
+	testTable := []struct {
+		name string
+		fn   func(o OHLCVSeries, opts CandleOpts) ValueSeries
+		bars [][4]float64
+	}{
+		{
+			name: "doji",
+			fn:   Doji,
+			bars: [][4]float64{{11, 12, 10, 11.02}},
+		},
+		{
+			name: "dragonfly doji",
+			fn:   DragonflyDoji,
+			bars: [][4]float64{{12, 12, 10, 12}},
+		},
+		{
+			name: "gravestone doji",
+			fn:   GravestoneDoji,
+			bars: [][4]float64{{10, 12, 10, 10}},
+		},
+		{
+			name: "hammer",
+			fn:   Hammer,
+			bars: [][4]float64{{11.6, 12, 9, 12}},
+		},
+		{
+			name: "hanging man",
+			fn:   HangingMan,
+			bars: [][4]float64{{11.6, 12, 9, 12}},
+		},
+		{
+			name: "inverted hammer",
+			fn:   InvertedHammer,
+			bars: [][4]float64{{10.4, 13, 10, 10}},
+		},
+		{
+			name: "shooting star",
+			fn:   ShootingStar,
+			bars: [][4]float64{{10.4, 13, 10, 10}},
+		},
+		{
+			name: "engulfing bullish",
+			fn:   EngulfingBullish,
+			bars: [][4]float64{{12, 12.2, 11.3, 11.5}, {11.4, 13.1, 11.3, 13}},
+		},
+		{
+			name: "engulfing bearish",
+			fn:   EngulfingBearish,
+			bars: [][4]float64{{11.5, 12.2, 11.3, 12}, {12.1, 12.2, 10.4, 10.5}},
+		},
+		{
+			name: "harami bullish",
+			fn:   HaramiBullish,
+			bars: [][4]float64{{13, 13.1, 9.9, 10}, {11, 12, 10.5, 11.5}},
+		},
+		{
+			name: "harami bearish",
+			fn:   HaramiBearish,
+			bars: [][4]float64{{10, 13.1, 9.9, 13}, {11.5, 12, 10.5, 11}},
+		},
+		{
+			name: "morning star",
+			fn:   MorningStar,
+			bars: [][4]float64{{14, 14.1, 10.9, 11}, {10.2, 10.5, 10, 10.4}, {11, 13.1, 10.9, 13}},
+		},
+		{
+			name: "evening star",
+			fn:   EveningStar,
+			bars: [][4]float64{{11, 14.1, 10.9, 14}, {14.8, 15, 14.5, 14.6}, {14, 14.1, 11.9, 12}},
+		},
+		{
+			name: "three white soldiers",
+			fn:   ThreeWhiteSoldiers,
+			bars: [][4]float64{{10, 13, 9.9, 13}, {12, 15, 11.9, 15}, {14, 17.2, 13.9, 17.2}},
+		},
+		{
+			name: "three black crows",
+			fn:   ThreeBlackCrows,
+			bars: [][4]float64{{20, 20.1, 17, 17}, {18, 18.1, 15, 15}, {16, 16.1, 12.8, 12.8}},
+		},
+		{
+			name: "piercing",
+			fn:   Piercing,
+			bars: [][4]float64{{14, 14.1, 10.9, 11}, {10.5, 13.1, 10.4, 13}},
+		},
+		{
+			name: "dark cloud cover",
+			fn:   DarkCloudCover,
+			bars: [][4]float64{{11, 14.1, 10.9, 14}, {14.5, 14.6, 11.9, 12}},
+		},
+		{
+			name: "marubozu white",
+			fn:   MarubozuWhite,
+			bars: [][4]float64{{10, 13, 10, 13}},
+		},
+		{
+			name: "marubozu black",
+			fn:   MarubozuBlack,
+			bars: [][4]float64{{13, 13, 10, 10}},
+		},
+	}
+
+	for _, v := range testTable {
+		data := candleTestData(v.bars)
+		series, err := NewOHLCVSeries(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i := range data {
+			series.Next()
+			p := v.fn(series, opts)
+			exp := 0.0
+			if i == len(data)-1 {
+				exp = 1.0
+			}
+			if p.Val() == nil {
+				t.Fatalf("Expected %s to be %+v but got nil for iteration: %d", v.name, exp, i)
+			}
+			if *p.Val() != exp {
+				t.Errorf("Expected %s to be %+v but got %+v for iteration: %d", v.name, exp, *p.Val(), i)
+			}
+		}
+	}
+}
+
+// TestSeriesCandlePatternsFlatCandle tests that a candle without a body, shadows or range is not detected as a doji
+func TestSeriesCandlePatternsFlatCandle(t *testing.T) {
+	opts := NewCandleOpts()
+	opts.Trend = CandleTrendNone
+	opts.BodyAvgLen = 3
+
+	testTable := []struct {
+		name string
+		fn   func(o OHLCVSeries, opts CandleOpts) ValueSeries
+	}{
+		{name: "doji", fn: Doji},
+		{name: "dragonfly doji", fn: DragonflyDoji},
+		{name: "gravestone doji", fn: GravestoneDoji},
+	}
+
+	for _, v := range testTable {
+		series, err := NewOHLCVSeries(candleTestData([][4]float64{{11, 11, 11, 11}}))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var p ValueSeries
+		for {
+			if c, _ := series.Next(); c == nil {
+				break
+			}
+			p = v.fn(series, opts)
+		}
+		if p.Val() == nil || *p.Val() != 0 {
+			t.Errorf("Expected %s to be 0 but got %+v", v.name, p.Val())
+		}
+	}
+}
+
+// TestSeriesCandlePatternsTrend tests that the trend context distinguishes a hammer from a hanging man
+//
+// close            | 16 | 15 | 14 | 12     |
+// sma(close, 3)    |    |    | 15 | 13.667 |
+// hammer           | 0  | 0  | 0  | 1      |
+// hanging man      | 0  | 0  | 0  | 0      |
+func TestSeriesCandlePatternsTrend(t *testing.T) {
+	opts := NewCandleOpts()
+	opts.TrendFastLen = 3
+	opts.BodyAvgLen = 3
+
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	data := []OHLCV{
+		{O: 18, H: 18.5, L: 15.5, C: 16, S: start},
+		{O: 17, H: 17.5, L: 14.5, C: 15, S: start.Add(5 * time.Minute)},
+		{O: 16, H: 16.5, L: 13.5, C: 14, S: start.Add(10 * time.Minute)},
+		{O: 11.6, H: 12, L: 9, C: 12, S: start.Add(15 * time.Minute)},
+	}
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hammerExp := []float64{0, 0, 0, 1}
+	hangingExp := []float64{0, 0, 0, 0}
+
+	for i := range data {
+		series.Next()
+		hammer := Hammer(series, opts)
+		hanging := HangingMan(series, opts)
+		if *hammer.Val() != hammerExp[i] {
+			t.Errorf("Expected hammer to be %+v but got %+v for iteration: %d", hammerExp[i], *hammer.Val(), i)
+		}
+		if *hanging.Val() != hangingExp[i] {
+			t.Errorf("Expected hanging man to be %+v but got %+v for iteration: %d", hangingExp[i], *hanging.Val(), i)
+		}
+	}
+}
+
+// TestSeriesCandlePatternsValueWhen tests that a pattern can be used as a condition of ValueWhen
+func TestSeriesCandlePatternsValueWhen(t *testing.T) {
+	opts := NewCandleOpts()
+	opts.Trend = CandleTrendNone
+	opts.BodyAvgLen = 3
+
+	data := candleTestData([][4]float64{{11.6, 12, 9, 12}, {10, 12.5, 9.5, 12.3}})
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		nil,
+		nil,
+		nil,
+		NewFloat64(9),
+		NewFloat64(9),
+	}
+
+	for i, v := range tests {
+		series.Next()
+		low := OHLCVAttr(series, OHLCPropLow)
+		vw := ValueWhen(Hammer(series, opts), low, 0)
+		assertSeriesVal(t, "valuewhen", i, v, vw)
+	}
+}
+
+func TestMemoryLeakCandlePatterns(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		MorningStar(o, NewCandleOpts())
+		return nil
+	})
+}
+
+func ExampleHammer() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	opts := NewCandleOpts()
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		hammer := Hammer(series, opts)
+		low := OHLCVAttr(series, OHLCPropLow)
+		lastHammerLow := ValueWhen(hammer, low, 0)
+		log.Printf("Hammer: %+v, last hammer low: %+v", hammer.Val(), lastHammerLow.Val())
+	}
+}
@@ -4,7 +4,10 @@ import (
 	"fmt"
 )
 
-// CCI generates a ValueSeries of exponential moving average.
+// CCI generates a ValueSeries of commodity channel index.
+//
+// The formula for CCI is
+//   - cci = (tp - sma(tp, l)) / (0.015 * dev(tp, l))
 func CCI(tp ValueSeries, l int64) ValueSeries {
 	key := fmt.Sprintf("cci:%s:%d", tp.ID(), l)
 	cci := getCache(key)
@@ -18,25 +21,9 @@ func CCI(tp ValueSeries, l int64) ValueSeries {
 	}
 
 	ma := SMA(tp, l)
-
-	// need moving average to perform this
-	if ma.GetCurrent() == nil {
-		return cci
-	}
-	mav := ma.GetCurrent().v
-	mdv := SubConstNoCache(tp, mav)
-
-	// get absolute value
-	mdvabs := OperateNoCache(mdv, mdv, "cci:absval", func(a, b float64) float64 {
-		if a < 0 {
-			return -1 * a
-		}
-		return a
-	})
-	mdvabssum := SumNoCache(mdvabs, int(l))
-	md := DivConstNoCache(mdvabssum, float64(l))
-	denom := MulConstNoCache(md, 0.015)
-	cci = DivNoCache(mdv, denom)
+	md := Dev(tp, int(l))
+	denom := MulConst(md, 0.015)
+	cci = Div(Sub(tp, ma), denom)
 
 	setCache(key, cci)
 
@@ -90,6 +90,51 @@ func TestSeriesCCIIteration(t *testing.T) {
 	}
 }
 
+// TestSeriesCCIReference tests CCI(14) of hlc3 against TA-Lib's CCI on referenceTestData.
+// Every value is checked at its own bar so that the deviations of each window are from the mean of that window
+func TestSeriesCCIReference(t *testing.T) {
+	exp := []float64{
+		52.6778, 26.7895, 20.0396, -9.2304, 1.9807, -111.1111, -61.9721, 39.0973,
+		-95.1152, -124.3276, -79.3355, 65.5365, 94.1630, 154.2380, 139.8835, 51.0421,
+		-73.8767, -15.4479, -7.8303, 28.1553, 26.7364, -47.8373, -45.0260, -184.8186,
+		-158.4847, -144.6312, -93.2737, -143.2933, -123.3892, -135.0291, -130.4768, -129.3307,
+		-154.1651, -162.7415, -142.3087, -79.0758, -101.4216, -112.7344, -53.8708, -88.6532,
+		-63.3433, -107.8705, -122.8414, -65.2683, 77.1452, 119.3224, 108.0019,
+	}
+
+	assertReference(t, "cci", 13, exp, func(o OHLCVSeries) ValueSeries {
+		return CCI(OHLCVAttr(o, OHLCPropHLC3), 14)
+	})
+}
+
+// TestSeriesCCIHistory tests that the past values are kept as they were at their bars
+// instead of being recalculated with the mean of the current window
+func TestSeriesCCIHistory(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := make(map[int]float64)
+	var cci ValueSeries
+	for i := range data {
+		series.Next()
+		tp := OHLCVAttr(series, OHLCPropHLC3)
+		cci = CCI(tp, 4)
+		if v := cci.Val(); v != nil {
+			exp[i] = *v
+		}
+	}
+
+	for i, v := range exp {
+		got := cci.Get(data[i].S)
+		if got == nil || fmt.Sprintf("%.04f", got.v) != fmt.Sprintf("%.04f", v) {
+			t.Errorf("Expected cci to be %+v but got %+v at %d", v, got, i)
+		}
+	}
+}
+
 func TestMemoryLeakCCI(t *testing.T) {
 	testMemoryLeak(t, func(o OHLCVSeries) error {
 		c := OHLCVAttr(o, OHLCPropClose)
@@ -0,0 +1,45 @@
+package pine
+
+import (
+	"fmt"
+	"math"
+)
+
+// Choppiness generates a ValueSeries of Choppiness Index, which determines whether the market is trending (close to 0) or choppy (close to 100).
+//
+// The formula for Choppiness is
+//   - chop = 100 * log10(sum(atr(1), l) / (highest(high, l) - lowest(low, l))) / log10(l)
+//
+// where atr(1) is the true range and it is high - low for the first OHLCV.
+// There is no value where highest(high, l) equals lowest(low, l), i.e. a flat window.
+//
+// The arguments are:
+//   - o: OHLCVSeries - source of data
+//   - l: int64 - lookback periods [2, ∞)
+func Choppiness(o OHLCVSeries, l int64) ValueSeries {
+	key := fmt.Sprintf("choppiness:%s:%d", o.ID(), l)
+	chop := getCache(key)
+	if chop == nil {
+		chop = NewValueSeries()
+	}
+
+	stop := o.Current()
+	if stop == nil {
+		return chop
+	}
+
+	str := Sum(OHLCVAttr(o, OHLCPropTRHL), int(l))
+	hh := Highest(OHLCVAttr(o, OHLCPropHigh), l)
+	ll := Lowest(OHLCVAttr(o, OHLCPropLow), l)
+
+	logl := math.Log10(float64(l))
+	chop = operationConst(divNonZero(str, Sub(hh, ll)), fmt.Sprintf("choppiness:%d", l), func(r float64) float64 {
+		return 100 * math.Log10(r) / logl
+	}, true)
+
+	setCache(key, chop)
+
+	chop.SetCurrent(stop.S)
+
+	return chop
+}
@@ -0,0 +1,49 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesChoppinessReference tests Choppiness(14) against the formula composed of TA-Lib's SUM, MAX and MIN on referenceTestData
+func TestSeriesChoppinessReference(t *testing.T) {
+	assertReference(t, "choppiness", 13, []float64{
+		56.2847, 60.9538, 60.0836, 62.3408, 62.6820, 69.4217, 70.7429, 70.9929,
+		70.1636, 70.3408, 70.5655, 71.0042, 70.0391, 71.9896, 70.4612, 70.9920,
+		71.6647, 69.7889, 67.6896, 67.1182, 66.0063, 65.5173, 64.5883, 58.6050,
+		58.5174, 57.0551, 57.1379, 55.9842, 58.1421, 53.7136, 51.3164, 49.7035,
+		46.8263, 43.9853, 44.7365, 44.6103, 48.9352, 48.0820, 50.4329, 52.4875,
+		59.5176, 57.6162, 55.0188, 59.8948, 62.1041, 67.0145, 66.7800,
+	}, func(o OHLCVSeries) ValueSeries {
+		return Choppiness(o, 14)
+	})
+}
+
+// TestSeriesChoppinessFlat tests that a window without a range has no value instead of dividing by 0
+func TestSeriesChoppinessFlat(t *testing.T) {
+	assertFlatOHLCV(t, "choppiness", 0, nil, func(o OHLCVSeries) ValueSeries {
+		return Choppiness(o, 3)
+	})
+}
+
+func TestMemoryLeakChoppiness(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		Choppiness(o, 3)
+		return nil
+	})
+}
+
+func ExampleChoppiness() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		choppiness := Choppiness(series, 3)
+		log.Printf("Choppiness: %+v", choppiness.Val())
+	}
+}
@@ -0,0 +1,50 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// CMF generates a ValueSeries of Chaikin money flow.
+//
+// The formula for CMF is
+//   - mfm = ((close - low) - (high - close)) / (high - low), which is 0 if high equals low
+//   - cmf = sum(mfm * volume, l) / sum(volume, l)
+//
+// The arguments are:
+//   - o: OHLCVSeries - source of data
+//   - l: int - lookback periods [1, ∞)
+func CMF(o OHLCVSeries, l int) ValueSeries {
+	key := fmt.Sprintf("cmf:%s:%d", o.ID(), l)
+	cmf := getCache(key)
+	if cmf == nil {
+		cmf = NewValueSeries()
+	}
+
+	// money flow volume of each OHLCV
+	mfvkey := fmt.Sprintf("cmfmfv:%s", o.ID())
+	mfv := getCache(mfvkey)
+	if mfv == nil {
+		mfv = NewValueSeries()
+	}
+
+	stop := o.Current()
+	if stop == nil {
+		return cmf
+	}
+
+	mfv = generateOHLCVRecursive(o, mfv, func(cur *OHLCV, _ *float64) *float64 {
+		return NewFloat64(moneyFlowMultiplier(cur) * cur.V)
+	})
+	mfv.SetCurrent(stop.S)
+	setCache(mfvkey, mfv)
+
+	vol := OHLCVAttr(o, OHLCPropVolume)
+
+	cmf = Div(Sum(mfv, l), Sum(vol, l))
+
+	setCache(key, cmf)
+
+	cmf.SetCurrent(stop.S)
+
+	return cmf
+}
@@ -0,0 +1,64 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesCMFNoData tests no data scenario
+func TestSeriesCMFNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmf := CMF(series, 3)
+	if cmf == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if cmf.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *cmf.Val())
+	}
+}
+
+// TestSeriesCMFReference tests CMF(20) against sum(ad increments, 20) / sum(volume, 20) of TA-Lib's AD and SUM on referenceTestData
+func TestSeriesCMFReference(t *testing.T) {
+	exp := []float64{
+		-0.1046, -0.1216, -0.1253, -0.1112, -0.0884, -0.1086, -0.0798, -0.0813,
+		-0.0800, -0.0576, -0.1021, -0.0503, -0.0958, -0.1253, -0.1115, -0.1256,
+		-0.1143, -0.1061, -0.1404, -0.1578, -0.1657, -0.1890, -0.1871, -0.1665,
+		-0.1698, -0.1944, -0.2670, -0.3294, -0.2993, -0.2432, -0.2474, -0.2590,
+		-0.1882, -0.1966, -0.1646, -0.1452, -0.1814, -0.1215, -0.0856, -0.0992,
+		-0.0725,
+	}
+
+	assertReference(t, "cmf", 19, exp, func(o OHLCVSeries) ValueSeries {
+		return CMF(o, 20)
+	})
+}
+
+func TestMemoryLeakCMF(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		CMF(o, 3)
+		return nil
+	})
+}
+
+func ExampleCMF() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		cmf := CMF(series, 3)
+		log.Printf("CMF: %+v", cmf.Val())
+	}
+}
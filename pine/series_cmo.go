@@ -0,0 +1,57 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// CMO generates a ValueSeries of Chande momentum oscillator.
+//
+// The formula for CMO is
+//   - mom = change(p, 1)
+//   - u = sum(mom if mom >= 0 else 0, l)
+//   - d = sum(-mom if mom < 0 else 0, l)
+//   - cmo = 100 * (u - d) / (u + d)
+//
+// There is no value where u + d is 0, i.e. a flat window.
+//
+// arguments are
+//   - p: ValueSeries - source data
+//   - l: int - lookback periods [1, ∞)
+func CMO(p ValueSeries, l int) ValueSeries {
+	key := fmt.Sprintf("cmo:%s:%d", p.ID(), l)
+	cmo := getCache(key)
+	if cmo == nil {
+		cmo = NewValueSeries()
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+	if stop == nil {
+		return cmo
+	}
+
+	mom := Change(p, 1)
+	up := operationConst(mom, "cmo:up", func(a float64) float64 {
+		if a >= 0 {
+			return a
+		}
+		return 0
+	}, true)
+	down := operationConst(mom, "cmo:down", func(a float64) float64 {
+		if a >= 0 {
+			return 0
+		}
+		return -a
+	}, true)
+
+	sm1 := Sum(up, l)
+	sm2 := Sum(down, l)
+
+	cmo = MulConst(divNonZero(Sub(sm1, sm2), Add(sm1, sm2)), 100)
+
+	setCache(key, cmo)
+
+	cmo.SetCurrent(stop.t)
+
+	return cmo
+}
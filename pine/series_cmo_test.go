@@ -0,0 +1,83 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesCMONoData tests no data scenario
+func TestSeriesCMONoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prop := OHLCVAttr(series, OHLCPropClose)
+	cmo := CMO(prop, 3)
+	if cmo == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if cmo.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *cmo.Val())
+	}
+}
+
+// TestSeriesCMOReference tests CMO(9) against sums of the gains and losses with TA-Lib's SUM on referenceTestData.
+// TA-Lib's CMO is not used since it smooths the gains and losses with Wilder's method while Pine Script sums them
+func TestSeriesCMOReference(t *testing.T) {
+	exp := []float64{
+		-28.2307, -5.8668, 7.1990, 44.5836, 16.5545, 13.4390, -10.2757, -5.5409,
+		26.2979, -9.2593, -17.9844, -5.8457, -37.1713, -26.3947, -9.5551, 7.3454,
+		22.9209, 18.9189, 33.2271, 17.6636, -17.6066, 18.8302, 15.3740, 9.8155,
+		-6.6318, -25.9080, -30.7628, -45.0432, -34.9434, -18.2938, -50.3614, -57.3057,
+		-72.5000, -76.6000, -72.1760, -92.8899, -92.3551, -97.2689, -77.0028, -41.2030,
+		-45.4672, -47.9139, -19.1203, -32.6209, -19.3159, -14.5445, -14.5445, -2.9693,
+		-0.6554, 12.1564, 11.3911,
+	}
+
+	assertReference(t, "cmo", 9, exp, func(o OHLCVSeries) ValueSeries {
+		return CMO(OHLCVAttr(o, OHLCPropClose), 9)
+	})
+}
+
+// TestSeriesCMOFlat tests that a flat window has no value instead of dividing zero by zero
+func TestSeriesCMOFlat(t *testing.T) {
+	assertFlatOHLCV(t, "cmo", 0, nil, func(o OHLCVSeries) ValueSeries {
+		return CMO(OHLCVAttr(o, OHLCPropClose), 3)
+	})
+}
+
+// TestSeriesCMOSourceTrimmed tests that trimming the source to 3 values does not change the output
+func TestSeriesCMOSourceTrimmed(t *testing.T) {
+	assertSourceTrimmed(t, "cmo", 3, func(p ValueSeries) ValueSeries {
+		return CMO(p, 9)
+	})
+}
+
+func TestMemoryLeakCMO(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		CMO(prop, 3)
+		return nil
+	})
+}
+
+func ExampleCMO() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		cmo := CMO(prop, 3)
+		log.Printf("CMO: %+v", cmo.Val())
+	}
+}
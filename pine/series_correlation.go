@@ -0,0 +1,39 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// Correlation generates a ValueSeries of the Pearson correlation coefficient of a and b, which is in the range of [-1, 1].
+// It is calculated from the running sums of Covariance. There is no value if either window is flat since the correlation is undefined.
+//
+// The formula for correlation is
+//   - corr = cov(a, b, l) / (stdev(a, l) * stdev(b, l))
+//
+// Parameters
+//   - a - ValueSeries: source data
+//   - b - ValueSeries: source data
+//   - l - int: lookback periods [1, ∞)
+func Correlation(a, b ValueSeries, l int) ValueSeries {
+	key := fmt.Sprintf("correlation:%s:%s:%d", a.ID(), b.ID(), l)
+	corr := getCache(key)
+	if corr == nil {
+		corr = NewValueSeries()
+	}
+
+	// current available value
+	stop := a.GetCurrent()
+	if stop == nil {
+		return corr
+	}
+
+	cv := getCovariance(a, b, l)
+
+	corr = divNonZero(cv.cov, Mul(cv.stdeva, cv.stdevb))
+
+	setCache(key, corr)
+
+	corr.SetCurrent(stop.t)
+
+	return corr
+}
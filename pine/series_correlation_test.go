@@ -0,0 +1,120 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesCorrelationNoData tests no data scenario
+func TestSeriesCorrelationNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prop := OHLCVAttr(series, OHLCPropClose)
+	correlation := Correlation(prop, OHLCVAttr(series, OHLCPropVolume), 4)
+	if correlation == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if correlation.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *correlation.Val())
+	}
+}
+
+// TestSeriesCorrelationReference tests Correlation(close, open, 10) against TA-Lib's CORREL on referenceTestData
+func TestSeriesCorrelationReference(t *testing.T) {
+	exp := []float64{
+		0.5914, 0.5838, 0.4606, 0.5111, 0.4926, 0.3389, 0.2359, 0.3589,
+		0.2217, 0.1550, 0.1682, 0.1313, 0.0970, 0.2968, 0.0860, -0.0304,
+		0.1604, 0.4248, 0.5995, 0.5521, 0.5292, 0.5383, 0.5960, 0.5041,
+		0.3989, 0.4843, 0.4687, 0.6389, 0.6883, 0.7336, 0.8033, 0.8759,
+		0.8879, 0.8851, 0.8684, 0.8809, 0.9145, 0.9762, 0.9153, 0.8689,
+		0.8405, 0.8418, 0.7291, 0.6059, 0.4096, 0.0974, 0.3298, 0.3596,
+		0.4195, 0.4413, 0.5250,
+	}
+
+	assertReference(t, "correlation", 9, exp, func(o OHLCVSeries) ValueSeries {
+		return Correlation(OHLCVAttr(o, OHLCPropClose), OHLCVAttr(o, OHLCPropOpen), 10)
+	})
+}
+
+// TestSeriesCorrelationHighLevel tests Correlation(1e6 + close / 1000, 1e6 + open / 1000, 10) against TA-Lib's CORREL(close, open, 10) on referenceTestData,
+// where the means are far larger than the spreads of the windows
+func TestSeriesCorrelationHighLevel(t *testing.T) {
+	exp := []float64{
+		0.5914, 0.5838, 0.4606, 0.5111, 0.4926, 0.3389, 0.2359, 0.3589,
+		0.2217, 0.1550, 0.1682, 0.1313, 0.0970, 0.2968, 0.0860, -0.0304,
+		0.1604, 0.4248, 0.5995, 0.5521, 0.5292, 0.5383, 0.5960, 0.5041,
+		0.3989, 0.4843, 0.4687, 0.6389, 0.6883, 0.7336, 0.8033, 0.8759,
+		0.8879, 0.8851, 0.8684, 0.8809, 0.9145, 0.9762, 0.9153, 0.8689,
+		0.8405, 0.8418, 0.7291, 0.6059, 0.4096, 0.0974, 0.3298, 0.3596,
+		0.4195, 0.4413, 0.5250,
+	}
+
+	assertReference(t, "correlation", 9, exp, func(o OHLCVSeries) ValueSeries {
+		a := AddConst(DivConst(OHLCVAttr(o, OHLCPropClose), 1000), 1e6)
+		b := AddConst(DivConst(OHLCVAttr(o, OHLCPropOpen), 1000), 1e6)
+		return Correlation(a, b, 10)
+	})
+}
+
+// TestSeriesCorrelationFlat tests that there is no value when one of the windows is flat instead of dividing by zero
+//
+// t=time.Time         | 1    | 2    | 3      | 4       | 5    | 6    |
+// a=ValueSeries       | 10.1 | 13.7 | 12.5   | 12.5    | 12.5 | 12.5 |
+// b=ValueSeries       | 1    | 2    | 3      | 5       | 4    | 6    |
+// correlation(a, b, 3)|      |      | 0.6547 | -0.7559 |      |      |
+func TestSeriesCorrelationFlat(t *testing.T) {
+	start := time.Now()
+	data := OHLCVTestData(start, 6, 5*60*1000)
+	closes := []float64{10.1, 13.7, 12.5, 12.5, 12.5, 12.5}
+	opens := []float64{1, 2, 3, 5, 4, 6}
+	for i := range data {
+		data[i].C = closes[i]
+		data[i].O = opens[i]
+	}
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{nil, nil, NewFloat64(0.6547), NewFloat64(-0.7559), nil, nil}
+
+	for i, v := range tests {
+		series.Next()
+		a := OHLCVAttr(series, OHLCPropClose)
+		b := OHLCVAttr(series, OHLCPropOpen)
+		corr := Correlation(a, b, 3)
+		assertSeriesVal(t, "correlation", i, v, corr)
+	}
+}
+
+func TestMemoryLeakCorrelation(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		Correlation(prop, OHLCVAttr(o, OHLCPropVolume), 4)
+		return nil
+	})
+}
+
+func ExampleCorrelation() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		correlation := Correlation(prop, OHLCVAttr(series, OHLCPropVolume), 4)
+		log.Printf("Correlation: %+v", correlation.Val())
+	}
+}
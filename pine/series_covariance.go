@@ -0,0 +1,217 @@
+package pine
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Covariance generates a ValueSeries of the rolling covariance of a and b.
+// It uses the population (biased) estimator and is calculated from running sums of the values shifted by the mean of the window,
+// which keeps the precision for values far from 0.
+//
+// The formula for covariance is
+//   - cov = sum((a - sma(a, l)) * (b - sma(b, l)), l) / l
+//
+// Parameters
+//   - a - ValueSeries: source data
+//   - b - ValueSeries: source data
+//   - l - int: lookback periods [1, ∞)
+func Covariance(a, b ValueSeries, l int) ValueSeries {
+	return getCovariance(a, b, l).cov
+}
+
+// covarianceSeries are the series generated from the window of a and b
+type covarianceSeries struct {
+	// population covariance of a and b
+	cov ValueSeries
+	// mean of a
+	meana ValueSeries
+	// population standard deviation of a and b, which is exactly 0 for a flat window
+	stdeva, stdevb ValueSeries
+}
+
+func getCovariance(a, b ValueSeries, l int) covarianceSeries {
+	cv := covarianceSeries{}
+	series := []*ValueSeries{&cv.cov, &cv.meana, &cv.stdeva, &cv.stdevb}
+	keys := make([]string, 0)
+	for i, name := range []string{"", "meana", "stdeva", "stdevb"} {
+		key := fmt.Sprintf("covariance%s:%s:%s:%d", name, a.ID(), b.ID(), l)
+		vs := getCache(key)
+		if vs == nil {
+			vs = NewValueSeries()
+		}
+		*series[i] = vs
+		keys = append(keys, key)
+	}
+
+	// current available value
+	stop := a.GetCurrent()
+	if stop == nil {
+		return cv
+	}
+
+	statekey := fmt.Sprintf("covariance:%s:%s:%d", a.ID(), b.ID(), l)
+	s := covarianceCache[statekey]
+
+	var f *Value
+	if s == nil {
+		s = &covarianceState{}
+		f = a.GetFirst()
+	} else {
+		f = valueAfter(a, s.last)
+	}
+
+	n := float64(l)
+	for {
+		if f == nil {
+			break
+		}
+
+		if v := b.Get(f.t); v != nil {
+			s.push(f.v, v.v, l)
+		}
+		s.last = f.t
+
+		if len(s.a) == l {
+			mda, mdb := s.sa/n, s.sb/n
+			var vara, varb, cov float64
+			if s.flata < l {
+				vara = math.Max(s.saa/n-mda*mda, 0)
+			}
+			if s.flatb < l {
+				varb = math.Max(s.sbb/n-mdb*mdb, 0)
+			}
+			if vara > 0 && varb > 0 {
+				cov = s.sab/n - mda*mdb
+			}
+			cv.cov.Set(f.t, cov)
+			cv.meana.Set(f.t, s.ka+mda)
+			cv.stdeva.Set(f.t, math.Sqrt(vara))
+			cv.stdevb.Set(f.t, math.Sqrt(varb))
+		}
+
+		if f.t.Equal(stop.t) {
+			break
+		}
+		f = f.next
+	}
+
+	setState(covarianceCache, statekey, s)
+
+	for i, vs := range series {
+		(*vs).SetCurrent(stop.t)
+		setCache(keys[i], *vs)
+	}
+
+	return cv
+}
+
+// covarianceState is the window of a and b carried over to the next call
+type covarianceState struct {
+	// last l values of a and b in arrival order
+	a, b []float64
+	// running sums of a, b, a * b, a^2 and b^2 of the window where a is shifted by ka and b by kb
+	sa, sb, sab, saa, sbb float64
+	// shifts of the values in the running sums, which keep them small to avoid losing precision
+	ka, kb float64
+	// number of the latest values of a and b which are equal to the latest one
+	flata, flatb int
+	// number of slides since the running sums were calculated from the window
+	slides int
+	// time of the last processed value
+	last time.Time
+}
+
+var covarianceCache map[string]*covarianceState = make(map[string]*covarianceState)
+
+// push appends the values of a and b to the window of l values and updates the running sums.
+// The running sums are calculated from the window again every l slides so that rounding errors do not accumulate.
+func (s *covarianceState) push(a, b float64, l int) {
+	s.flata = flatCount(s.a, s.flata, a)
+	s.flatb = flatCount(s.b, s.flatb, b)
+
+	if len(s.a) == 0 {
+		s.ka, s.kb = a, b
+	}
+	if len(s.a) < l {
+		s.a = append(s.a, a)
+		s.b = append(s.b, b)
+		s.add(a, b, 1)
+		return
+	}
+
+	olda, oldb := s.a[0], s.b[0]
+	s.a = append(s.a[1:], a)
+	s.b = append(s.b[1:], b)
+	s.slides++
+	if s.slides >= l {
+		s.resum()
+		return
+	}
+	s.add(olda, oldb, -1)
+	s.add(a, b, 1)
+}
+
+// add adds the shifted values multiplied by sign to the running sums
+func (s *covarianceState) add(a, b, sign float64) {
+	da, db := a-s.ka, b-s.kb
+	s.sa += sign * da
+	s.sb += sign * db
+	s.sab += sign * da * db
+	s.saa += sign * da * da
+	s.sbb += sign * db * db
+}
+
+// resum shifts the values by the mean of the window and calculates the running sums from the window
+func (s *covarianceState) resum() {
+	s.ka, s.kb = 0, 0
+	for i := range s.a {
+		s.ka += s.a[i]
+		s.kb += s.b[i]
+	}
+	s.ka /= float64(len(s.a))
+	s.kb /= float64(len(s.b))
+
+	s.sa, s.sb, s.sab, s.saa, s.sbb, s.slides = 0, 0, 0, 0, 0, 0
+	for i := range s.a {
+		s.add(s.a[i], s.b[i], 1)
+	}
+}
+
+// flatCount returns the number of the latest values equal to v after v is appended to w whose latest cnt values are equal
+func flatCount(w []float64, cnt int, v float64) int {
+	if len(w) > 0 && w[len(w)-1] == v {
+		return cnt + 1
+	}
+	return 1
+}
+
+// divNonZero generates a ValueSeries of a / b, which has no value where b is 0 as na in Pine Script
+func divNonZero(a, b ValueSeries) ValueSeries {
+	key := fmt.Sprintf("divnonzero:%s:%s", a.ID(), b.ID())
+	dest := getCache(key)
+	if dest == nil {
+		dest = NewValueSeries()
+	}
+
+	// current available value
+	stop := a.GetCurrent()
+	if stop == nil {
+		return dest
+	}
+
+	dest = generateFilter(*stop, a, dest, 0, func(v *Value, _ []*float64) *float64 {
+		d := b.Get(v.t)
+		if d == nil || d.v == 0 {
+			return nil
+		}
+		return NewFloat64(v.v / d.v)
+	})
+
+	setCache(key, dest)
+
+	dest.SetCurrent(stop.t)
+
+	return dest
+}
@@ -0,0 +1,87 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesCovarianceNoData tests no data scenario
+func TestSeriesCovarianceNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prop := OHLCVAttr(series, OHLCPropClose)
+	covariance := Covariance(prop, OHLCVAttr(series, OHLCPropVolume), 4)
+	if covariance == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if covariance.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *covariance.Val())
+	}
+}
+
+// TestSeriesCovarianceReference tests Covariance(close, open, 10) against sma(close * open, 10) - sma(close, 10) * sma(open, 10) with TA-Lib's SMA on referenceTestData
+func TestSeriesCovarianceReference(t *testing.T) {
+	exp := []float64{
+		2.3768, 1.4931, 0.8193, 1.0186, 1.1606, 0.5891, 0.3661, 0.5240,
+		0.4030, 0.2457, 0.2828, 0.2300, 0.1855, 0.5340, 0.1577, -0.0555,
+		0.3536, 1.2176, 2.0782, 2.1367, 2.0659, 2.0889, 2.1343, 1.4006,
+		0.6221, 0.7426, 0.9034, 1.5417, 1.8462, 2.2680, 2.8862, 4.2132,
+		4.6353, 4.6832, 4.0621, 3.6376, 4.4518, 6.6268, 8.5638, 7.7932,
+		6.3915, 6.5383, 4.4438, 2.9222, 1.3264, 0.2100, 0.7679, 0.9085,
+		1.2104, 1.2549, 1.7841,
+	}
+
+	assertReference(t, "covariance", 9, exp, func(o OHLCVSeries) ValueSeries {
+		return Covariance(OHLCVAttr(o, OHLCPropClose), OHLCVAttr(o, OHLCPropOpen), 10)
+	})
+}
+
+// TestSeriesCovarianceHighLevel tests Covariance(p, p, 10) of p = 1e6 + close / 1000 against TA-Lib's VAR(close, 10) * 1e-6 on referenceTestData,
+// where the mean is far larger than the spread of the window
+func TestSeriesCovarianceHighLevel(t *testing.T) {
+	exp := []float64{
+		4.6878, 2.0534, 2.1159, 3.2850, 2.5251, 2.1362, 1.8224, 1.9437,
+		1.8932, 1.4820, 1.8522, 1.8674, 2.2186, 1.5516, 1.3808, 1.6543,
+		2.3201, 3.5791, 4.2627, 4.0105, 4.0930, 4.0840, 3.4559, 2.3988,
+		2.1211, 2.4834, 2.3375, 2.9548, 2.8896, 3.2318, 3.7289, 4.8019,
+		5.5007, 5.7385, 4.8337, 4.9741, 5.6777, 9.2498, 10.0170, 8.2938,
+		6.9907, 6.7302, 4.8497, 4.3478, 2.7331, 2.0809, 3.2041, 3.1757,
+		4.0823, 3.6116, 3.8268,
+	}
+
+	assertReference(t, "covariance", 9, exp, func(o OHLCVSeries) ValueSeries {
+		p := AddConst(DivConst(OHLCVAttr(o, OHLCPropClose), 1000), 1e6)
+		return MulConst(Covariance(p, p, 10), 1e6)
+	})
+}
+
+func TestMemoryLeakCovariance(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		Covariance(prop, OHLCVAttr(o, OHLCPropVolume), 4)
+		return nil
+	})
+}
+
+func ExampleCovariance() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		covariance := Covariance(prop, OHLCVAttr(series, OHLCPropVolume), 4)
+		log.Printf("Covariance: %+v", covariance.Val())
+	}
+}
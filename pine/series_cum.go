@@ -0,0 +1,48 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// Cum generates a ValueSeries of the cumulative sum of all source values.
+//
+// Parameters
+//   - p - ValueSeries: source data
+func Cum(p ValueSeries) ValueSeries {
+	key := fmt.Sprintf("cum:%s", p.ID())
+	cum := getCache(key)
+	if cum == nil {
+		cum = NewValueSeries()
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+	if stop == nil {
+		return cum
+	}
+
+	// the last sum carries over even after older values are trimmed
+	var tot float64
+	if last := cum.GetLast(); last != nil {
+		tot = last.v
+	}
+
+	f := operationGetStart(p, cum)
+	for {
+		if f == nil {
+			break
+		}
+		tot += f.v
+		cum.Set(f.t, tot)
+		if f.t.Equal(stop.t) {
+			break
+		}
+		f = f.next
+	}
+
+	setCache(key, cum)
+
+	cum.SetCurrent(stop.t)
+
+	return cum
+}
@@ -0,0 +1,110 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesCumNoData tests no data scenario
+func TestSeriesCumNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prop := OHLCVAttr(series, OHLCPropClose)
+	cum := Cum(prop)
+	if cum == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if cum.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *cum.Val())
+	}
+}
+
+// TestSeriesCumIteration tests the output against values of the reference formula
+func TestSeriesCumIteration(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		NewFloat64(16.5),
+		NewFloat64(35.2),
+		NewFloat64(53.4),
+		NewFloat64(65.3),
+		NewFloat64(84.6),
+		NewFloat64(98.8),
+		NewFloat64(113.2),
+		NewFloat64(124.2),
+		NewFloat64(138.9),
+		NewFloat64(149.2),
+	}
+
+	for i, v := range tests {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropClose)
+		cum := Cum(prop)
+		assertSeriesVal(t, "cum", i, v, cum)
+	}
+}
+
+// TestSeriesCumSetMax tests that the sum is carried over after the series is trimmed
+func TestSeriesCumSetMax(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		NewFloat64(16.5),
+		NewFloat64(35.2),
+		NewFloat64(53.4),
+		NewFloat64(65.3),
+		NewFloat64(84.6),
+		NewFloat64(98.8),
+		NewFloat64(113.2),
+		NewFloat64(124.2),
+		NewFloat64(138.9),
+		NewFloat64(149.2),
+	}
+
+	for i, v := range tests {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropClose)
+		cum := Cum(prop)
+		cum.SetMax(1)
+		assertSeriesVal(t, "cum", i, v, cum)
+	}
+}
+
+func TestMemoryLeakCum(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		Cum(prop)
+		return nil
+	})
+}
+
+func ExampleCum() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		cum := Cum(prop)
+		log.Printf("Cum: %+v", cum.Val())
+	}
+}
@@ -0,0 +1,21 @@
+package pine
+
+// VolumeDelta generates a ValueSeries of the buy volume minus the sell volume of each bar built by b.
+// The volume of the trades whose aggressor side is unknown is not included.
+//
+// The arguments are:
+//   - b: BarBuilder - source of data
+func VolumeDelta(b BarBuilder) ValueSeries {
+	return Sub(b.BuyVolume(), b.SellVolume())
+}
+
+// CVD generates a ValueSeries of cumulative volume delta, which is the running total of VolumeDelta since the first bar built by b.
+//
+// The formula for CVD is
+//   - cvd = cum(buyvolume - sellvolume)
+//
+// The arguments are:
+//   - b: BarBuilder - source of data
+func CVD(b BarBuilder) ValueSeries {
+	return Cum(VolumeDelta(b))
+}
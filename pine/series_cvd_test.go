@@ -0,0 +1,84 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesCVDNoData tests no data scenario
+func TestSeriesCVDNoData(t *testing.T) {
+	b := NewTimeBarBuilder(time.Minute)
+
+	cvd := CVD(b)
+	if cvd == nil {
+		t.Fatal("Expected to be non nil but got nil")
+	}
+	if cvd.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *cvd.Val())
+	}
+}
+
+// TestSeriesCVDIteration tests the output of one minute bars of tradeTestData
+//
+// bar   | 0 | 1 | 2  | 3 |
+// delta | 0 | 2 | -1 | 1 |
+// cvd   | 0 | 2 | 1  | 2 |
+func TestSeriesCVDIteration(t *testing.T) {
+	b := NewTimeBarBuilder(time.Minute)
+	ts := NewTradeSeries(tradeTestData())
+
+	deltaExp := []float64{0, 2, -1, 1}
+	cvdExp := []float64{0, 2, 1, 2}
+
+	i := 0
+	for {
+		if v := ts.Next(); v == nil {
+			b.Flush()
+		} else {
+			b.Update(ts)
+		}
+
+		for {
+			if v, _ := b.Series().Next(); v == nil {
+				break
+			}
+			delta := VolumeDelta(b)
+			cvd := CVD(b)
+			assertSeriesVal(t, "delta", i, NewFloat64(deltaExp[i]), delta)
+			assertSeriesVal(t, "cvd", i, NewFloat64(cvdExp[i]), cvd)
+			i++
+		}
+
+		if ts.Current() == ts.GetLast() && b.Forming() == nil {
+			break
+		}
+	}
+
+	if i != len(cvdExp) {
+		t.Errorf("expected %d bars but got %d", len(cvdExp), i)
+	}
+}
+
+func ExampleCVD() {
+	start := time.Now()
+	b := NewTickBarBuilder(50)
+	ts := NewTradeSeries(nil)
+	for i := 0; i < 10000; i++ {
+		side := TradeSideBuy
+		if i%3 == 0 {
+			side = TradeSideSell
+		}
+		ts.Push(Trade{P: 100 + float64(i%7), V: 1, T: start.Add(time.Duration(i) * time.Second), Side: side})
+		ts.Next()
+		b.Update(ts)
+
+		for {
+			if v, _ := b.Series().Next(); v == nil {
+				break
+			}
+			cvd := CVD(b)
+			log.Printf("CVD: %+v", cvd.Val())
+		}
+	}
+}
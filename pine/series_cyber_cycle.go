@@ -0,0 +1,62 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// CyberCycle generates ValueSeries of Ehlers' cyber cycle and its trigger in that order.
+// The trigger is the cyber cycle of the previous value.
+//
+// The formula for CyberCycle is
+//   - smooth = (p + 2 * p[1] + 2 * p[2] + p[3]) / 6
+//   - cycle = (1 - alpha / 2)^2 * (smooth - 2 * smooth[1] + smooth[2]) + 2 * (1 - alpha) * cycle[1] - (1 - alpha)^2 * cycle[2]
+//
+// Until seven values are available, cycle is (p - 2 * p[1] + p[2]) / 4.
+//
+// Parameters
+//   - p - ValueSeries: source data, i.e. hl2
+//   - alpha - float64: smoothing factor, i.e. 0.07
+func CyberCycle(p ValueSeries, alpha float64) (cycle, trigger ValueSeries) {
+	key := fmt.Sprintf("cybercycle:%s:%v", p.ID(), alpha)
+	cycle = getCache(key)
+	if cycle == nil {
+		cycle = NewValueSeries()
+	}
+
+	triggerkey := fmt.Sprintf("cybercycletrigger:%s:%v", p.ID(), alpha)
+	trigger = getCache(triggerkey)
+	if trigger == nil {
+		trigger = NewValueSeries()
+	}
+
+	if p == nil || p.GetCurrent() == nil {
+		return cycle, trigger
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+
+	cycle = generateFilter(*stop, p, cycle, 2, func(v *Value, prev []*float64) *float64 {
+		if w := windowValues(v, 7); w != nil && prev[0] != nil && prev[1] != nil {
+			s0 := (w[6] + 2*w[5] + 2*w[4] + w[3]) / 6
+			s1 := (w[5] + 2*w[4] + 2*w[3] + w[2]) / 6
+			s2 := (w[4] + 2*w[3] + 2*w[2] + w[1]) / 6
+			return NewFloat64((1-alpha/2)*(1-alpha/2)*(s0-2*s1+s2) + 2*(1-alpha)**prev[0] - (1-alpha)*(1-alpha)**prev[1])
+		}
+		if w := windowValues(v, 3); w != nil {
+			return NewFloat64((w[2] - 2*w[1] + w[0]) / 4)
+		}
+		return nil
+	})
+	cycle.SetCurrent(stop.t)
+
+	trigger = generateWindow(*stop, cycle, trigger, 2, func(w []float64) float64 {
+		return w[0]
+	})
+	trigger.SetCurrent(stop.t)
+
+	setCache(key, cycle)
+	setCache(triggerkey, trigger)
+
+	return cycle, trigger
+}
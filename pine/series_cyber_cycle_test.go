@@ -0,0 +1,96 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesCyberCycleNoData tests no data scenario
+func TestSeriesCyberCycleNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cycle, trigger := CyberCycle(OHLCVAttr(series, OHLCPropHL2), 0.07)
+	if cycle == nil || trigger == nil {
+		t.Fatal("Expected to be non nil but got nil")
+	}
+	if cycle.Val() != nil || trigger.Val() != nil {
+		t.Error("Expected to be nil but got non nil")
+	}
+}
+
+// TestSeriesCyberCycleReference tests CyberCycle(hl2, 0.07) against Ehlers' cyber cycle in Cybernetic Analysis for Stocks and Futures on referenceTestData
+func TestSeriesCyberCycleReference(t *testing.T) {
+	exp := []float64{
+		0.3013, -0.6450, 0.6963, 0.4750, 1.3972, 2.9881, 4.0297, 4.3940,
+		4.9164, 5.8898, 6.9759, 7.6382, 7.5683, 6.9990, 6.2418, 5.7348,
+		5.2441, 4.8756, 5.0249, 5.3110, 5.1785, 4.5800, 4.5427, 5.2969,
+		6.2087, 6.7668, 6.6024, 5.6077, 4.2263, 3.3631, 3.4405, 3.9376,
+		3.9432, 3.4475, 2.4253, 1.3245, 0.4924, 0.2763, 0.3254, 0.0878,
+		-0.4177, -1.0291, -1.3859, -1.8150, -2.4186, -3.1710, -3.2924, -2.6153,
+		-1.9975, -1.5946, -1.3082, -0.7320, -0.4691, -0.7050, -0.7132, 0.1020,
+		1.8394, 3.4821,
+	}
+
+	assertReference(t, "cybercycle", 2, exp, func(o OHLCVSeries) ValueSeries {
+		cycle, _ := CyberCycle(OHLCVAttr(o, OHLCPropHL2), 0.07)
+		return cycle
+	})
+}
+
+// TestSeriesCyberCycleFlat tests that a flat source has no cycle
+func TestSeriesCyberCycleFlat(t *testing.T) {
+	assertFlatTo(t, "cycle", 2, 0, func(p ValueSeries) ValueSeries {
+		cycle, _ := CyberCycle(p, 0.07)
+		return cycle
+	})
+}
+
+// TestSeriesCyberCycleResponse tests that the trend is removed and the cycle is kept
+func TestSeriesCyberCycleResponse(t *testing.T) {
+	fn := func(p ValueSeries) ValueSeries {
+		cycle, _ := CyberCycle(p, 0.07)
+		return cycle
+	}
+
+	if a := sineAmplitude(t, 20, fn); a < 0.85 {
+		t.Errorf("Expected a cycle to pass but got the amplitude of %.4f", a)
+	}
+	if a := sineAmplitude(t, 200, fn); a > 0.2 {
+		t.Errorf("Expected a trend to be removed but got the amplitude of %.4f", a)
+	}
+}
+
+func TestSeriesCyberCycleTrigger(t *testing.T) {
+	assertTrigger(t, "trigger", func(o OHLCVSeries) (ValueSeries, ValueSeries) {
+		return CyberCycle(OHLCVAttr(o, OHLCPropHL2), 0.07)
+	})
+}
+
+func TestMemoryLeakCyberCycle(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		CyberCycle(OHLCVAttr(o, OHLCPropHL2), 0.07)
+		return nil
+	})
+}
+
+func ExampleCyberCycle() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		cycle, trigger := CyberCycle(OHLCVAttr(series, OHLCPropHL2), 0.07)
+		log.Printf("cycle: %+v, trigger: %+v", cycle.Val(), trigger.Val())
+	}
+}
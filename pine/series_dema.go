@@ -0,0 +1,39 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// DEMA generates a ValueSeries of double exponential moving average.
+//
+// The formula for DEMA is
+//   - e1 = ema(p, l)
+//   - dema = 2 * e1 - ema(e1, l)
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - l - int64: lookback periods [1, ∞)
+func DEMA(p ValueSeries, l int64) ValueSeries {
+	key := fmt.Sprintf("dema:%s:%d", p.ID(), l)
+	dema := getCache(key)
+	if dema == nil {
+		dema = NewValueSeries()
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+	if stop == nil {
+		return dema
+	}
+
+	e1 := EMA(p, l)
+	e2 := EMA(e1, l)
+
+	dema = Sub(MulConst(e1, 2), e2)
+
+	setCache(key, dema)
+
+	dema.SetCurrent(stop.t)
+
+	return dema
+}
@@ -0,0 +1,61 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesDEMAReference tests DEMA(close, 4) against TA-Lib's DEMA
+func TestSeriesDEMAReference(t *testing.T) {
+	exp := []float64{
+		98.5382, 97.9703, 96.6379, 97.3441, 98.1257, 99.1598, 100.6521, 99.7314,
+		99.2093, 98.7479, 97.5821, 98.3765, 97.3830, 96.7497, 98.0081, 96.7783,
+		95.9790, 96.7842, 98.5470, 99.8365, 101.2293, 101.3868, 100.1320, 97.7824,
+		98.2081, 97.9297, 98.3959, 98.6540, 97.6951, 97.8130, 95.4870, 94.5685,
+		94.2173, 94.1841, 92.8515, 92.3219, 91.3534, 90.9129, 90.0323, 87.6861,
+		85.6773, 85.5722, 87.4252, 86.3106, 85.4789, 86.9108, 85.4454, 85.6898,
+		84.5394, 83.0278, 84.4391, 87.2274, 88.0203, 87.8866,
+	}
+
+	assertReference(t, "dema", 6, exp, func(o OHLCVSeries) ValueSeries {
+		return DEMA(OHLCVAttr(o, OHLCPropClose), 4)
+	})
+}
+
+// TestSeriesDEMAFlat tests that a flat window returns the flat value
+func TestSeriesDEMAFlat(t *testing.T) {
+	assertFlat(t, "dema", 6, func(p ValueSeries) ValueSeries {
+		return DEMA(p, 4)
+	})
+}
+
+// TestSeriesDEMASourceTrimmed tests that trimming the source to 5 values does not change the output
+func TestSeriesDEMASourceTrimmed(t *testing.T) {
+	assertSourceTrimmed(t, "dema", 5, func(p ValueSeries) ValueSeries {
+		return DEMA(p, 4)
+	})
+}
+
+func TestMemoryLeakDEMA(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		DEMA(prop, 4)
+		return nil
+	})
+}
+
+func ExampleDEMA() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		dema := DEMA(prop, 4)
+		log.Printf("DEMA: %+v", dema.Val())
+	}
+}
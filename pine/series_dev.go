@@ -0,0 +1,49 @@
+package pine
+
+import (
+	"fmt"
+	"math"
+)
+
+// Dev generates a ValueSeries of the mean absolute deviation of the last l values from their mean
+//
+// The formula for Dev is
+//   - mean = sma(p, l)
+//   - dev = sum(abs(p[i] - mean), l) / l
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - l - int: lookback periods [1, ∞)
+func Dev(p ValueSeries, l int) ValueSeries {
+	key := fmt.Sprintf("dev:%s:%d", p.ID(), l)
+	dev := getCache(key)
+	if dev == nil {
+		dev = NewValueSeries()
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+	if stop == nil {
+		return dev
+	}
+
+	dev = generateWindow(*stop, p, dev, l, func(w []float64) float64 {
+		var mean float64
+		for _, v := range w {
+			mean += v
+		}
+		mean = mean / float64(l)
+
+		var tot float64
+		for _, v := range w {
+			tot += math.Abs(v - mean)
+		}
+		return tot / float64(l)
+	})
+
+	setCache(key, dev)
+
+	dev.SetCurrent(stop.t)
+
+	return dev
+}
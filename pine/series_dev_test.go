@@ -0,0 +1,80 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesDevNoData tests no data scenario
+func TestSeriesDevNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prop := OHLCVAttr(series, OHLCPropClose)
+	dev := Dev(prop, 4)
+	if dev == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if dev.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *dev.Val())
+	}
+}
+
+// TestSeriesDevIteration tests the output against values of the Pine Script reference formula
+func TestSeriesDevIteration(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		nil,
+		nil,
+		nil,
+		NewFloat64(2.2125),
+		NewFloat64(2.5625),
+		NewFloat64(2.8500),
+		NewFloat64(2.1750),
+		NewFloat64(2.2875),
+		NewFloat64(1.2875),
+		NewFloat64(1.9500),
+	}
+
+	for i, v := range tests {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropClose)
+		dev := Dev(prop, 4)
+		assertSeriesVal(t, "dev", i, v, dev)
+	}
+}
+
+func TestMemoryLeakDev(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		Dev(prop, 4)
+		return nil
+	})
+}
+
+func ExampleDev() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		dev := Dev(prop, 4)
+		log.Printf("Dev: %+v", dev.Val())
+	}
+}
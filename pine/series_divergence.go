@@ -0,0 +1,301 @@
+package pine
+
+import (
+	"fmt"
+	"time"
+)
+
+// DivergenceKind is a kind of divergence between the price and an oscillator
+type DivergenceKind int
+
+const (
+	// DivergenceRegularBullish is a lower low of the price with a higher low of the oscillator, which suggests a reversal to the upside
+	DivergenceRegularBullish DivergenceKind = iota
+	// DivergenceHiddenBullish is a higher low of the price with a lower low of the oscillator, which suggests a continuation of the uptrend
+	DivergenceHiddenBullish
+	// DivergenceRegularBearish is a higher high of the price with a lower high of the oscillator, which suggests a reversal to the downside
+	DivergenceRegularBearish
+	// DivergenceHiddenBearish is a lower high of the price with a higher high of the oscillator, which suggests a continuation of the downtrend
+	DivergenceHiddenBearish
+)
+
+// Divergences are ValueSeries of each kind of divergence.
+// Each ValueSeries is 1.0 where the divergence is confirmed, otherwise 0.0 so that it can be used as a condition, i.e. ValueWhen.
+type Divergences struct {
+	RegularBullish ValueSeries
+	HiddenBullish  ValueSeries
+	RegularBearish ValueSeries
+	HiddenBearish  ValueSeries
+}
+
+// DivergencePoint is a pair of the pivots of the oscillator and the price which anchors a divergence
+type DivergencePoint struct {
+	// T is the time of the pivot of the oscillator
+	T time.Time
+	// PriceT is the time of the pivot of the price
+	PriceT time.Time
+	// Price is the price at the pivot of the price
+	Price float64
+	// Osc is the oscillator at the pivot of the oscillator
+	Osc float64
+}
+
+// Divergence generates ValueSeries of regular and hidden bullish and bearish divergences between the price and the oscillator.
+//
+// Pivot lows and pivot highs are detected on both the price and the oscillator.
+// A pivot of the oscillator is paired with the nearest pivot of the price of the same kind within tol values once both are confirmed.
+// Pivots which are not paired are ignored.
+// Every time a pair is made, it is compared with the previous pair of the same kind
+//   - regular bullish: price makes a lower low while the oscillator makes a higher low
+//   - hidden bullish: price makes a higher low while the oscillator makes a lower low
+//   - regular bearish: price makes a higher high while the oscillator makes a lower high
+//   - hidden bearish: price makes a lower high while the oscillator makes a higher high
+//
+// As with PivotLow and PivotHigh, the divergence is set at the confirming time, which is right values after the later pivot of the pair.
+// Use DivergenceAnchors for the two pairs of the divergence.
+//
+// The arguments are:
+//   - price: ValueSeries - price, i.e. close
+//   - osc: ValueSeries - oscillator, i.e. RSI, the histogram of MACD or MFI
+//   - left: int - number of values on the left of the pivot [1, ∞)
+//   - right: int - number of values on the right of the pivot [0, ∞)
+//   - tol: int - number of values the pivots of the price and the oscillator may be apart [0, ∞)
+func Divergence(price, osc ValueSeries, left, right, tol int) Divergences {
+	dv, _ := getDivergence(price, osc, left, right, tol)
+	return Divergences{
+		RegularBullish: dv.kinds[DivergenceRegularBullish],
+		HiddenBullish:  dv.kinds[DivergenceHiddenBullish],
+		RegularBearish: dv.kinds[DivergenceRegularBearish],
+		HiddenBearish:  dv.kinds[DivergenceHiddenBearish],
+	}
+}
+
+// DivergenceAnchors returns the previous pair and the latest pair of pivots of the divergence of the kind confirmed at the current time of osc.
+// nil is returned if the divergence was not confirmed at the current time.
+// It panics if kind is not a known kind since it is a programming error.
+func DivergenceAnchors(price, osc ValueSeries, left, right, tol int, kind DivergenceKind) (first, second *DivergencePoint) {
+	if kind < DivergenceRegularBullish || kind > DivergenceHiddenBearish {
+		panic(fmt.Sprintf("pine: unknown DivergenceKind %d", kind))
+	}
+
+	dv, s := getDivergence(price, osc, left, right, tol)
+
+	cur := dv.kinds[kind].GetCurrent()
+	if cur == nil || cur.v == 0 || !cur.t.Equal(s.last) {
+		return nil, nil
+	}
+
+	pv := s.lows
+	if kind == DivergenceRegularBearish || kind == DivergenceHiddenBearish {
+		pv = s.highs
+	}
+	if pv.first == nil || pv.second == nil {
+		return nil, nil
+	}
+	first, second = new(DivergencePoint), new(DivergencePoint)
+	*first, *second = *pv.first, *pv.second
+	return first, second
+}
+
+// divergenceSeries are the series generated by Divergence
+type divergenceSeries struct {
+	// series of each DivergenceKind
+	kinds []ValueSeries
+}
+
+// divergencePivot is a confirmed pivot of the price or the oscillator
+type divergencePivot struct {
+	t time.Time
+	// index of the pivot in the processed values
+	i int
+	v float64
+}
+
+// divergencePivots are the pivots of one kind, either lows or highs
+type divergencePivots struct {
+	// confirmed pivots of the oscillator and the price which are not paired yet
+	osc, price []divergencePivot
+	// latest pair
+	last *DivergencePoint
+	// pairs compared at the last processed value, nil if none were compared
+	first, second *DivergencePoint
+}
+
+// add appends the pivots confirmed at the value with index i and pairs them.
+// first and second are set to the latest pair made at i and its previous pair.
+func (d *divergencePivots) add(osc, price *divergencePivot, i, right, tol int) {
+	if osc != nil {
+		d.osc = append(d.osc, *osc)
+	}
+	if price != nil {
+		d.price = append(d.price, *price)
+	}
+
+	d.first, d.second = nil, nil
+	for oi := 0; oi < len(d.osc); {
+		o := d.osc[oi]
+		best := -1
+		for pi, p := range d.price {
+			dist := abs(p.i - o.i)
+			if dist <= tol && (best < 0 || dist < abs(d.price[best].i-o.i)) {
+				best = pi
+			}
+		}
+		if best < 0 {
+			oi++
+			continue
+		}
+
+		p := d.price[best]
+		pair := &DivergencePoint{T: o.t, PriceT: p.t, Price: p.v, Osc: o.v}
+		if d.last != nil {
+			d.first, d.second = d.last, pair
+		}
+		d.last = pair
+
+		// the pairs are made in time order, so the pivots up to the pair are no longer paired
+		d.osc, d.price = keepAfter(d.osc, o.i), keepAfter(d.price, p.i)
+		oi = 0
+	}
+
+	// pivots confirmed later are after i - right, which the pivots before i - right - tol cannot be paired with
+	d.osc, d.price = keepAfter(d.osc, i-right-tol-1), keepAfter(d.price, i-right-tol-1)
+}
+
+// keepAfter returns the pivots whose index is after i
+func keepAfter(pts []divergencePivot, i int) []divergencePivot {
+	n := 0
+	for _, pt := range pts {
+		if pt.i > i {
+			pts[n] = pt
+			n++
+		}
+	}
+	return pts[:n]
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// divergenceState is the state of Divergence carried over to the next call
+type divergenceState struct {
+	lows  divergencePivots
+	highs divergencePivots
+	// number of processed values
+	n int
+	// time of the last processed value
+	last time.Time
+}
+
+var divergenceCache map[string]*divergenceState = make(map[string]*divergenceState)
+
+func getDivergence(price, osc ValueSeries, left, right, tol int) (divergenceSeries, *divergenceState) {
+	dv := divergenceSeries{kinds: make([]ValueSeries, 4)}
+	names := []string{"regularbullish", "hiddenbullish", "regularbearish", "hiddenbearish"}
+	keys := make([]string, 0)
+	for i, name := range names {
+		key := fmt.Sprintf("divergence%s:%s:%s:%d:%d:%d", name, price.ID(), osc.ID(), left, right, tol)
+		vs := getCache(key)
+		if vs == nil {
+			vs = NewValueSeries()
+		}
+		dv.kinds[i] = vs
+		keys = append(keys, key)
+	}
+
+	statekey := fmt.Sprintf("divergence:%s:%s:%d:%d:%d", price.ID(), osc.ID(), left, right, tol)
+	s := divergenceCache[statekey]
+
+	// current available value
+	stop := osc.GetCurrent()
+	if stop == nil {
+		if s == nil {
+			s = &divergenceState{}
+		}
+		return dv, s
+	}
+
+	_, osclow := getPivot(osc, left, right, false)
+	_, oschigh := getPivot(osc, left, right, true)
+	_, pricelow := getPivot(price, left, right, false)
+	_, pricehigh := getPivot(price, left, right, true)
+
+	// pivot returns the pivot of p confirmed at t, which is right values before the value with index i
+	pivot := func(p ValueSeries, ps *pivotState, t time.Time, i int) (*divergencePivot, bool) {
+		pt, ok := ps.confirmed(t)
+		if !ok || pt.IsZero() {
+			return nil, ok
+		}
+		v := p.Get(pt)
+		if v == nil {
+			return nil, true
+		}
+		return &divergencePivot{t: pt, i: i - right, v: v.v}, true
+	}
+
+	bool2float := func(b bool) float64 {
+		if b {
+			return 1
+		}
+		return 0
+	}
+
+	var f *Value
+	if s == nil {
+		s = &divergenceState{}
+		f = osc.GetFirst()
+	} else {
+		f = valueAfter(osc, s.last)
+	}
+	for {
+		if f == nil {
+			break
+		}
+
+		i := s.n
+		ol, okol := pivot(osc, osclow, f.t, i)
+		oh, okoh := pivot(osc, oschigh, f.t, i)
+		pl, okpl := pivot(price, pricelow, f.t, i)
+		ph, okph := pivot(price, pricehigh, f.t, i)
+		if okol && okoh && okpl && okph {
+			var regbull, hidbull, regbear, hidbear bool
+
+			s.lows.add(ol, pl, i, right, tol)
+			if p1, p2 := s.lows.first, s.lows.second; p2 != nil {
+				regbull = p2.Price < p1.Price && p2.Osc > p1.Osc
+				hidbull = p2.Price > p1.Price && p2.Osc < p1.Osc
+			}
+
+			s.highs.add(oh, ph, i, right, tol)
+			if p1, p2 := s.highs.first, s.highs.second; p2 != nil {
+				regbear = p2.Price > p1.Price && p2.Osc < p1.Osc
+				hidbear = p2.Price < p1.Price && p2.Osc > p1.Osc
+			}
+
+			dv.kinds[DivergenceRegularBullish].Set(f.t, bool2float(regbull))
+			dv.kinds[DivergenceHiddenBullish].Set(f.t, bool2float(hidbull))
+			dv.kinds[DivergenceRegularBearish].Set(f.t, bool2float(regbear))
+			dv.kinds[DivergenceHiddenBearish].Set(f.t, bool2float(hidbear))
+		}
+		s.n++
+		s.last = f.t
+
+		if f.t.Equal(stop.t) {
+			break
+		}
+		f = f.next
+	}
+
+	setState(divergenceCache, statekey, s)
+
+	for i, vs := range dv.kinds {
+		vs.SetCurrent(stop.t)
+		setCache(keys[i], vs)
+	}
+
+	return dv, s
+}
@@ -0,0 +1,274 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// divergenceTestData returns data with the oscillator in O and the price in C
+//
+// t=time.Time   | 0  | 1 | 2  | 3 | 4  | 5 | 6  | 7   | 8  | 9    | 10 |
+// osc=O         | 5  | 3 | 6  | 4 | 7  | 2 | 6  | 6.5 | 5  | 9    | 6  |
+// price=C       | 10 | 8 | 11 | 7 | 12 | 9 | 13 | 15  | 14 | 14.5 | 12 |
+func divergenceTestData() []OHLCV {
+	osc := []float64{5, 3, 6, 4, 7, 2, 6, 6.5, 5, 9, 6}
+	price := []float64{10, 8, 11, 7, 12, 9, 13, 15, 14, 14.5, 12}
+	data := OHLCVTestData(time.Now(), int64(len(osc)), 5*60*1000)
+	for i := range data {
+		data[i].O = osc[i]
+		data[i].C = price[i]
+	}
+	return data
+}
+
+// TestSeriesDivergenceNoData tests no data scenario
+func TestSeriesDivergenceNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prop := OHLCVAttr(series, OHLCPropClose)
+	osc := OHLCVAttr(series, OHLCPropOpen)
+	dv := Divergence(prop, osc, 1, 1, 1)
+	for _, vs := range []ValueSeries{dv.RegularBullish, dv.HiddenBullish, dv.RegularBearish, dv.HiddenBearish} {
+		if vs == nil {
+			t.Fatal("Expected to be non nil but got nil")
+		}
+		if vs.Val() != nil {
+			t.Errorf("Expected to be nil but got %+v", *vs.Val())
+		}
+	}
+	if first, second := DivergenceAnchors(prop, osc, 1, 1, 1, DivergenceRegularBullish); first != nil || second != nil {
+		t.Errorf("Expected to be nil but got %+v, %+v", first, second)
+	}
+}
+
+// TestSeriesDivergenceIteration tests that each kind of divergence is set at the confirming time of the pivot
+//
+// pivot low of osc and price at 1, 3, 5 and 8 confirmed at 2, 4, 6 and 9
+// pivot high of osc and price at 2, 4, 7 and 9 confirmed at 3, 5, 8 and 10
+//   - 4: regular bullish - price 8 -> 7 is a lower low, osc 3 -> 4 is a higher low
+//   - 6: hidden bullish - price 7 -> 9 is a higher low, osc 4 -> 2 is a lower low
+//   - 8: regular bearish - price 12 -> 15 is a higher high, osc 7 -> 6.5 is a lower high
+//   - 10: hidden bearish - price 15 -> 14.5 is a lower high, osc 6.5 -> 9 is a higher high
+func TestSeriesDivergenceIteration(t *testing.T) {
+	data := divergenceTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// array in order of regular bullish, hidden bullish, regular bearish, hidden bearish
+	tests := [][]*float64{
+		{nil, nil, nil, nil},
+		{nil, nil, nil, nil},
+		{NewFloat64(0), NewFloat64(0), NewFloat64(0), NewFloat64(0)},
+		{NewFloat64(0), NewFloat64(0), NewFloat64(0), NewFloat64(0)},
+		{NewFloat64(1), NewFloat64(0), NewFloat64(0), NewFloat64(0)},
+		{NewFloat64(0), NewFloat64(0), NewFloat64(0), NewFloat64(0)},
+		{NewFloat64(0), NewFloat64(1), NewFloat64(0), NewFloat64(0)},
+		{NewFloat64(0), NewFloat64(0), NewFloat64(0), NewFloat64(0)},
+		{NewFloat64(0), NewFloat64(0), NewFloat64(1), NewFloat64(0)},
+		{NewFloat64(0), NewFloat64(0), NewFloat64(0), NewFloat64(0)},
+		{NewFloat64(0), NewFloat64(0), NewFloat64(0), NewFloat64(1)},
+	}
+
+	for i, v := range tests {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropClose)
+		osc := OHLCVAttr(series, OHLCPropOpen)
+		dv := Divergence(prop, osc, 1, 1, 1)
+		assertSeriesVal(t, "regularbullish", i, v[0], dv.RegularBullish)
+		assertSeriesVal(t, "hiddenbullish", i, v[1], dv.HiddenBullish)
+		assertSeriesVal(t, "regularbearish", i, v[2], dv.RegularBearish)
+		assertSeriesVal(t, "hiddenbearish", i, v[3], dv.HiddenBearish)
+	}
+}
+
+// TestSeriesDivergenceAnchors tests the two pivots of each divergence
+func TestSeriesDivergenceAnchors(t *testing.T) {
+	data := divergenceTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testTable := map[int]struct {
+		kind   DivergenceKind
+		first  int
+		second int
+	}{
+		4:  {DivergenceRegularBullish, 1, 3},
+		6:  {DivergenceHiddenBullish, 3, 5},
+		8:  {DivergenceRegularBearish, 4, 7},
+		10: {DivergenceHiddenBearish, 7, 9},
+	}
+
+	for i := range data {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropClose)
+		osc := OHLCVAttr(series, OHLCPropOpen)
+
+		exp, ok := testTable[i]
+		for _, kind := range []DivergenceKind{DivergenceRegularBullish, DivergenceHiddenBullish, DivergenceRegularBearish, DivergenceHiddenBearish} {
+			first, second := DivergenceAnchors(prop, osc, 1, 1, 1, kind)
+			if !ok || kind != exp.kind {
+				if first != nil || second != nil {
+					t.Errorf("Expected no anchors for kind %d but got %+v, %+v for iteration: %d", kind, first, second, i)
+				}
+				continue
+			}
+			if first == nil || second == nil {
+				t.Fatalf("Expected anchors for kind %d but got nil for iteration: %d", kind, i)
+			}
+			for _, a := range []struct {
+				idx int
+				got *DivergencePoint
+			}{{exp.first, first}, {exp.second, second}} {
+				d := data[a.idx]
+				if !a.got.T.Equal(d.S) || !a.got.PriceT.Equal(d.S) || a.got.Price != d.C || a.got.Osc != d.O {
+					t.Errorf("Expected anchor to be %+v, %+v, %+v but got %+v for iteration: %d", d.S, d.C, d.O, *a.got, i)
+				}
+			}
+		}
+	}
+}
+
+// TestSeriesDivergenceTolerance tests that the pivots of the price are paired with the pivots of the oscillator within the tolerance
+//
+// t=time.Time   | 0  | 1 | 2 | 3  | 4  | 5 | 6  |
+// osc=O         | 5  | 3 | 6 | 4  | 7  | 8 | 9  |
+// price=C       | 10 | 9 | 8 | 12 | 11 | 7 | 13 |
+//
+// pivot low of osc at 1 and 3 confirmed at 2 and 4, pivot low of price at 2 and 5 confirmed at 3 and 6.
+// With the tolerance of 2, the pairs (1, 2) and (3, 5) make a regular bullish divergence at 6 as price 8 -> 7 is a lower low and osc 3 -> 4 is a higher low.
+// With the tolerance of 1, the pivot of osc at 3 is not paired, and the price at the pivots of osc alone 9 -> 12 would be no divergence.
+func TestSeriesDivergenceTolerance(t *testing.T) {
+	osc := []float64{5, 3, 6, 4, 7, 8, 9}
+	price := []float64{10, 9, 8, 12, 11, 7, 13}
+	data := OHLCVTestData(time.Now(), int64(len(osc)), 5*60*1000)
+	for i := range data {
+		data[i].O = osc[i]
+		data[i].C = price[i]
+	}
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range data {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropClose)
+		o := OHLCVAttr(series, OHLCPropOpen)
+		if i < 2 {
+			continue
+		}
+
+		var exp float64
+		if i == 6 {
+			exp = 1
+		}
+		assertSeriesVal(t, "regularbullish", i, NewFloat64(exp), Divergence(prop, o, 1, 1, 2).RegularBullish)
+		assertSeriesVal(t, "regularbullish", i, NewFloat64(0), Divergence(prop, o, 1, 1, 1).RegularBullish)
+	}
+
+	first, second := DivergenceAnchors(OHLCVAttr(series, OHLCPropClose), OHLCVAttr(series, OHLCPropOpen), 1, 1, 2, DivergenceRegularBullish)
+	if first == nil || second == nil {
+		t.Fatal("Expected anchors but got nil")
+	}
+	if !first.T.Equal(data[1].S) || !first.PriceT.Equal(data[2].S) || first.Osc != 3 || first.Price != 8 {
+		t.Errorf("Expected the first anchor to be at %+v and %+v but got %+v", data[1].S, data[2].S, *first)
+	}
+	if !second.T.Equal(data[3].S) || !second.PriceT.Equal(data[5].S) || second.Osc != 4 || second.Price != 7 {
+		t.Errorf("Expected the second anchor to be at %+v and %+v but got %+v", data[3].S, data[5].S, *second)
+	}
+}
+
+// TestSeriesDivergenceUnknownKind tests that DivergenceAnchors panics on a kind that is not defined
+func TestSeriesDivergenceUnknownKind(t *testing.T) {
+	series, err := NewOHLCVSeries(divergenceTestData())
+	if err != nil {
+		t.Fatal(err)
+	}
+	series.Next()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected to panic but did not")
+		}
+	}()
+	DivergenceAnchors(OHLCVAttr(series, OHLCPropClose), OHLCVAttr(series, OHLCPropOpen), 1, 1, 1, DivergenceKind(4))
+}
+
+// TestSeriesDivergenceLastTrimmed tests that the divergences resume after the last processed value is trimmed from the oscillator
+func TestSeriesDivergenceLastTrimmed(t *testing.T) {
+	data := divergenceTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 6; i++ {
+		series.Next()
+		Divergence(OHLCVAttr(series, OHLCPropClose), OHLCVAttr(series, OHLCPropOpen), 1, 1, 1)
+	}
+	for i := 0; i < 3; i++ {
+		series.Next()
+	}
+	prop := OHLCVAttr(series, OHLCPropClose)
+	osc := OHLCVAttr(series, OHLCPropOpen)
+	osc.SetMax(3)
+
+	// the pivot high at 4 is kept in the state after it is trimmed, which the pivot high at 7 confirmed at 8 is compared with
+	dv := Divergence(prop, osc, 1, 1, 1)
+	assertSeriesVal(t, "regularbearish", 8, NewFloat64(1), dv.RegularBearish)
+
+	for i := 0; i < 2; i++ {
+		series.Next()
+	}
+	prop = OHLCVAttr(series, OHLCPropClose)
+	osc = OHLCVAttr(series, OHLCPropOpen)
+	dv = Divergence(prop, osc, 1, 1, 1)
+	assertSeriesVal(t, "hiddenbearish", 10, NewFloat64(1), dv.HiddenBearish)
+
+	first, second := DivergenceAnchors(prop, osc, 1, 1, 1, DivergenceHiddenBearish)
+	if first == nil || second == nil {
+		t.Fatal("Expected anchors but got nil")
+	}
+	if !first.T.Equal(data[7].S) || !second.T.Equal(data[9].S) {
+		t.Errorf("Expected anchors at %+v and %+v but got %+v and %+v", data[7].S, data[9].S, first.T, second.T)
+	}
+}
+
+func TestMemoryLeakDivergence(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		Divergence(prop, RSI(prop, 14), 5, 5, 2)
+		return nil
+	})
+}
+
+func ExampleDivergence() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		rsi := RSI(prop, 14)
+		dv := Divergence(prop, rsi, 5, 5, 2)
+		if v := dv.RegularBullish.Val(); v != nil && *v == 1 {
+			first, second := DivergenceAnchors(prop, rsi, 5, 5, 2, DivergenceRegularBullish)
+			log.Printf("Regular bullish divergence from %+v to %+v", first, second)
+		}
+	}
+}
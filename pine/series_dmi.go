@@ -6,19 +6,24 @@ import (
 
 // DMI generates a ValueSeries of directional movement index.
 func DMI(ohlcv OHLCVSeries, len, smoo int) (adx, plus, minus ValueSeries) {
-	adxkey := fmt.Sprintf("adx:%s:%d:%d", ohlcv.ID(), len, smoo)
+	return DMIWithMA(ohlcv, len, smoo, MATypeRMA)
+}
+
+// DMIWithMA generates a ValueSeries of directional movement index smoothed by the moving average of type mat.
+func DMIWithMA(ohlcv OHLCVSeries, len, smoo int, mat MAType) (adx, plus, minus ValueSeries) {
+	adxkey := fmt.Sprintf("adx:%s:%d:%d:%d", ohlcv.ID(), len, smoo, mat)
 	adx = getCache(adxkey)
 	if adx == nil {
 		adx = NewValueSeries()
 	}
 
-	pluskey := fmt.Sprintf("plus:%s:%d:%d", ohlcv.ID(), len, smoo)
+	pluskey := fmt.Sprintf("plus:%s:%d:%d:%d", ohlcv.ID(), len, smoo, mat)
 	plus = getCache(pluskey)
 	if plus == nil {
 		plus = NewValueSeries()
 	}
 
-	minuskey := fmt.Sprintf("minus:%s:%d:%d", ohlcv.ID(), len, smoo)
+	minuskey := fmt.Sprintf("minus:%s:%d:%d:%d", ohlcv.ID(), len, smoo, mat)
 	minus = getCache(minuskey)
 	if minus == nil {
 		minus = NewValueSeries()
@@ -49,9 +54,9 @@ func DMI(ohlcv OHLCVSeries, len, smoo int) (adx, plus, minus ValueSeries) {
 		}
 		return 0
 	})
-	trurange := RMA(tr, int64(len))
-	plusdmrma := RMA(plusdm, int64(len))
-	minusdmrma := RMA(minusdm, int64(len))
+	trurange := MA(tr, int64(len), mat)
+	plusdmrma := MA(plusdm, int64(len), mat)
+	minusdmrma := MA(minusdm, int64(len), mat)
 	plus = MulConst(Div(plusdmrma, trurange), 100)
 	minus = MulConst(Div(minusdmrma, trurange), 100)
 
@@ -63,7 +68,7 @@ func DMI(ohlcv OHLCVSeries, len, smoo int) (adx, plus, minus ValueSeries) {
 		return a
 	})
 
-	adxrma := RMA(Div(DiffAbs(plus, minus), denom), 3)
+	adxrma := MA(Div(DiffAbs(plus, minus), denom), 3, mat)
 	adx = MulConst(adxrma, 100)
 
 	setCache(adxkey, adx)
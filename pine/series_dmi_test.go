@@ -107,6 +107,37 @@ func TestSeriesDMIIteration(t *testing.T) {
 	}
 }
 
+// TestSeriesDMIWithMA tests DMI with SMA against values of the reference formula
+func TestSeriesDMIWithMA(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// array in order of adx, plus, minus
+	tests := [][]*float64{
+		{nil, nil, nil},
+		{nil, nil, nil},
+		{nil, nil, nil},
+		{nil, nil, nil},
+		{nil, NewFloat64(2.5397), NewFloat64(7.9365)},
+		{nil, NewFloat64(3.5484), NewFloat64(8.0645)},
+		{NewFloat64(30.1347), NewFloat64(3.7801), NewFloat64(3.7801)},
+		{NewFloat64(41.2963), NewFloat64(0.9740), NewFloat64(12.0130)},
+		{NewFloat64(55.9524), NewFloat64(0.9836), NewFloat64(10.4918)},
+		{NewFloat64(89.2857), NewFloat64(0.0000), NewFloat64(17.6101)},
+	}
+
+	for i, v := range tests {
+		series.Next()
+		adx, plus, minus := DMIWithMA(series, 4, 2, MATypeSMA)
+		assertSeriesVal(t, "adx", i, v[0], adx)
+		assertSeriesVal(t, "plus", i, v[1], plus)
+		assertSeriesVal(t, "minus", i, v[2], minus)
+	}
+}
+
 func TestMemoryLeakDMI(t *testing.T) {
 	testMemoryLeak(t, func(o OHLCVSeries) error {
 		DMI(o, 4, 3)
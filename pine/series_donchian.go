@@ -0,0 +1,54 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// Donchian generates ValueSeries of Donchian channel's upper, lower and basis in that order.
+//
+// The formula for Donchian channel is
+//   - upper = highest(high, l)
+//   - lower = lowest(low, l)
+//   - basis = (upper + lower) / 2
+//
+// The arguments are:
+//   - o: OHLCVSeries - source of data
+//   - l: int64 - lookback periods [1, ∞)
+func Donchian(o OHLCVSeries, l int64) (upper, lower, basis ValueSeries) {
+	upperkey := fmt.Sprintf("donchianupper:%s:%d", o.ID(), l)
+	upper = getCache(upperkey)
+	if upper == nil {
+		upper = NewValueSeries()
+	}
+
+	lowerkey := fmt.Sprintf("donchianlower:%s:%d", o.ID(), l)
+	lower = getCache(lowerkey)
+	if lower == nil {
+		lower = NewValueSeries()
+	}
+
+	basiskey := fmt.Sprintf("donchianbasis:%s:%d", o.ID(), l)
+	basis = getCache(basiskey)
+	if basis == nil {
+		basis = NewValueSeries()
+	}
+
+	stop := o.Current()
+	if stop == nil {
+		return upper, lower, basis
+	}
+
+	upper = Highest(OHLCVAttr(o, OHLCPropHigh), l)
+	lower = Lowest(OHLCVAttr(o, OHLCPropLow), l)
+	basis = DivConst(Add(upper, lower), 2)
+
+	upper.SetCurrent(stop.S)
+	lower.SetCurrent(stop.S)
+	basis.SetCurrent(stop.S)
+
+	setCache(upperkey, upper)
+	setCache(lowerkey, lower)
+	setCache(basiskey, basis)
+
+	return upper, lower, basis
+}
@@ -0,0 +1,87 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesDonchianNoData tests no data scenario
+func TestSeriesDonchianNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	upper, lower, basis := Donchian(series, 3)
+	if upper == nil || lower == nil || basis == nil {
+		t.Fatal("Expected to be non nil but got nil")
+	}
+	if upper.Val() != nil || lower.Val() != nil || basis.Val() != nil {
+		t.Error("Expected to be nil but got non nil")
+	}
+}
+
+// TestSeriesDonchianReference tests Donchian(20) against TA-Lib's MAX, MIN and MIDPRICE on referenceTestData
+func TestSeriesDonchianReference(t *testing.T) {
+	assertReference(t, "upper", 19, []float64{
+		104.9600, 103.1700, 103.1700, 103.1700, 103.1700, 103.1700, 103.1700, 103.1700,
+		103.1700, 103.1700, 103.1700, 103.1700, 103.1700, 102.5600, 102.5600, 102.5600,
+		102.5600, 102.5600, 102.5600, 102.5600, 102.5600, 102.5600, 102.5600, 102.5600,
+		102.5600, 102.5600, 102.5600, 102.1800, 101.5100, 100.8800, 100.8800, 100.8800,
+		100.8800, 100.6500, 99.8900, 99.8400, 98.1100, 97.5100, 96.8900, 96.8900,
+		94.8400,
+	}, func(o OHLCVSeries) ValueSeries {
+		upper, _, _ := Donchian(o, 20)
+		return upper
+	})
+
+	assertReference(t, "lower", 19, []float64{
+		93.5600, 93.5600, 93.5600, 93.5600, 93.7800, 94.5000, 94.5000, 94.5000,
+		94.5000, 94.5000, 94.5000, 94.5000, 94.5000, 94.5000, 94.5000, 94.5000,
+		94.5000, 92.4100, 92.4100, 92.4100, 92.4100, 91.8600, 91.5500, 89.2200,
+		89.2200, 88.1500, 86.2100, 84.4400, 83.4700, 83.4700, 83.4700, 83.4700,
+		83.4700, 83.4700, 83.4700, 82.6100, 81.4200, 81.4200, 81.4200, 81.4200,
+		81.4200,
+	}, func(o OHLCVSeries) ValueSeries {
+		_, lower, _ := Donchian(o, 20)
+		return lower
+	})
+
+	assertReference(t, "basis", 19, []float64{
+		99.2600, 98.3650, 98.3650, 98.3650, 98.4750, 98.8350, 98.8350, 98.8350,
+		98.8350, 98.8350, 98.8350, 98.8350, 98.8350, 98.5300, 98.5300, 98.5300,
+		98.5300, 97.4850, 97.4850, 97.4850, 97.4850, 97.2100, 97.0550, 95.8900,
+		95.8900, 95.3550, 94.3850, 93.3100, 92.4900, 92.1750, 92.1750, 92.1750,
+		92.1750, 92.0600, 91.6800, 91.2250, 89.7650, 89.4650, 89.1550, 89.1550,
+		88.1300,
+	}, func(o OHLCVSeries) ValueSeries {
+		_, _, basis := Donchian(o, 20)
+		return basis
+	})
+}
+
+func TestMemoryLeakDonchian(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		Donchian(o, 3)
+		return nil
+	})
+}
+
+func ExampleDonchian() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		upper, lower, basis := Donchian(series, 3)
+		log.Printf("upper: %+v, lower: %+v, basis: %+v", upper.Val(), lower.Val(), basis.Val())
+	}
+}
@@ -0,0 +1,40 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// Falling generates a ValueSeries of 1.0 if the source value is less than all of the previous l values, otherwise 0.0.
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - l - int: number of previous values to compare [1, ∞)
+func Falling(p ValueSeries, l int) ValueSeries {
+	key := fmt.Sprintf("falling:%s:%d", p.ID(), l)
+	fs := getCache(key)
+	if fs == nil {
+		fs = NewValueSeries()
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+	if stop == nil {
+		return fs
+	}
+
+	fs = generateWindow(*stop, p, fs, l+1, func(w []float64) float64 {
+		c := w[l]
+		for _, v := range w[:l] {
+			if v <= c {
+				return 0
+			}
+		}
+		return 1
+	})
+
+	setCache(key, fs)
+
+	fs.SetCurrent(stop.t)
+
+	return fs
+}
@@ -0,0 +1,80 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesFallingNoData tests no data scenario
+func TestSeriesFallingNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prop := OHLCVAttr(series, OHLCPropClose)
+	falling := Falling(prop, 2)
+	if falling == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if falling.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *falling.Val())
+	}
+}
+
+// TestSeriesFallingIteration tests the output against values of the reference formula
+func TestSeriesFallingIteration(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		nil,
+		nil,
+		NewFloat64(0),
+		NewFloat64(1),
+		NewFloat64(0),
+		NewFloat64(0),
+		NewFloat64(0),
+		NewFloat64(1),
+		NewFloat64(0),
+		NewFloat64(1),
+	}
+
+	for i, v := range tests {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropClose)
+		falling := Falling(prop, 2)
+		assertSeriesVal(t, "falling", i, v, falling)
+	}
+}
+
+func TestMemoryLeakFalling(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		Falling(prop, 2)
+		return nil
+	})
+}
+
+func ExampleFalling() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		falling := Falling(prop, 2)
+		log.Printf("Falling: %+v", falling.Val())
+	}
+}
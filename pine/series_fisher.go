@@ -0,0 +1,92 @@
+package pine
+
+import (
+	"fmt"
+	"math"
+)
+
+// Fisher generates ValueSeries of Ehlers' Fisher transform and its trigger in that order.
+// The trigger is the Fisher transform of the previous value.
+//
+// The formula for Fisher is
+//   - value = 0.66 * ((p - lowest(p, l)) / (highest(p, l) - lowest(p, l)) - 0.5) + 0.67 * value[1]
+//   - value is set to 0.999 above 0.99 and to -0.999 below -0.99 as in Ehlers' original code
+//   - fisher = 0.5 * ln((1 + value) / (1 - value)) + 0.5 * fisher[1]
+//
+// value[1] and fisher[1] are 0 if they are not available.
+//
+// Parameters
+//   - p - ValueSeries: source data, i.e. hl2
+//   - l - int64: lookback periods [1, ∞)
+func Fisher(p ValueSeries, l int64) (fisher, trigger ValueSeries) {
+	key := fmt.Sprintf("fisher:%s:%d", p.ID(), l)
+	fisher = getCache(key)
+	if fisher == nil {
+		fisher = NewValueSeries()
+	}
+
+	triggerkey := fmt.Sprintf("fishertrigger:%s:%d", p.ID(), l)
+	trigger = getCache(triggerkey)
+	if trigger == nil {
+		trigger = NewValueSeries()
+	}
+
+	valuekey := fmt.Sprintf("fishervalue:%s:%d", p.ID(), l)
+	value := getCache(valuekey)
+	if value == nil {
+		value = NewValueSeries()
+	}
+
+	if p == nil || p.GetCurrent() == nil {
+		return fisher, trigger
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+
+	hi := Highest(p, l)
+	lo := Lowest(p, l)
+
+	value = generateFilter(*stop, p, value, 1, func(v *Value, prev []*float64) *float64 {
+		h, lw := hi.Get(v.t), lo.Get(v.t)
+		if h == nil || lw == nil {
+			return nil
+		}
+		var norm float64
+		if h.v != lw.v {
+			norm = (v.v-lw.v)/(h.v-lw.v) - 0.5
+		}
+		var pv float64
+		if prev[0] != nil {
+			pv = *prev[0]
+		}
+		val := 0.66*norm + 0.67*pv
+		if val > 0.99 {
+			val = 0.999
+		} else if val < -0.99 {
+			val = -0.999
+		}
+		return NewFloat64(val)
+	})
+	value.SetCurrent(stop.t)
+
+	fisher = generateFilter(*stop, value, fisher, 1, func(v *Value, prev []*float64) *float64 {
+		var pf float64
+		if prev[0] != nil {
+			pf = *prev[0]
+		}
+		return NewFloat64(0.5*math.Log((1+v.v)/(1-v.v)) + 0.5*pf)
+	})
+	fisher.SetCurrent(stop.t)
+
+	trigger = generateWindow(*stop, fisher, trigger, 2, func(w []float64) float64 {
+		return w[0]
+	})
+	trigger.SetCurrent(stop.t)
+
+	setCache(key, fisher)
+	setCache(triggerkey, trigger)
+	setCache(valuekey, value)
+
+	return fisher, trigger
+}
@@ -0,0 +1,135 @@
+package pine
+
+import (
+	"log"
+	"math"
+	"testing"
+	"time"
+)
+
+// TestSeriesFisherNoData tests no data scenario
+func TestSeriesFisherNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fisher, trigger := Fisher(OHLCVAttr(series, OHLCPropHL2), 3)
+	if fisher == nil || trigger == nil {
+		t.Fatal("Expected to be non nil but got nil")
+	}
+	if fisher.Val() != nil || trigger.Val() != nil {
+		t.Error("Expected to be nil but got non nil")
+	}
+}
+
+// TestSeriesFisherReference tests Fisher(hl2, 9) against Ehlers' Fisher transform as in TradingView's built-in script on referenceTestData
+func TestSeriesFisherReference(t *testing.T) {
+	exp := []float64{
+		-0.1944, -0.3020, 0.0361, 0.5005, 0.9921, 1.3446, 1.1973, 0.9075,
+		0.5903, 0.1842, -0.3364, -0.5599, -0.4701, -0.5710, -0.8145, -1.0662,
+		-0.5902, 0.0054, 0.5866, 1.1263, 1.2238, 0.7265, 0.2659, -0.0155,
+		-0.0742, -0.0339, -0.2098, -0.4700, -0.8741, -1.3204, -1.7712, -1.7673,
+		-1.9932, -2.3239, -2.7010, -3.0974, -3.5008, -3.9060, -4.3111, -4.7153,
+		-3.4891, -2.7797, -2.6457, -2.1413, -1.9457, -1.6560, -1.7518, -2.0253,
+		-1.9890, -1.1697, -0.3678, 0.3303,
+	}
+
+	assertReference(t, "fisher", 8, exp, func(o OHLCVSeries) ValueSeries {
+		fisher, _ := Fisher(OHLCVAttr(o, OHLCPropHL2), 9)
+		return fisher
+	})
+}
+
+// TestSeriesFisherClamp tests that value is set to 0.999 once it exceeds 0.99 as in Ehlers' original code.
+// value of a rising source is 0.33 + 0.67 * value[1], which exceeds 0.99 at 13.
+func TestSeriesFisherClamp(t *testing.T) {
+	data := OHLCVTestData(time.Now(), 20, 5*60*1000)
+	for i := range data {
+		data[i].C = float64(i + 1)
+	}
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := []float64{
+		0.3428, 0.7914, 1.2615, 1.7252, 2.1754, 2.6126, 3.0391, 3.4578,
+		3.8708, 4.2798, 4.6860, 6.1432, 6.8718, 7.2361, 7.4183, 7.5093,
+		7.5549, 7.5776,
+	}
+	for i := range data {
+		series.Next()
+		fisher, _ := Fisher(OHLCVAttr(series, OHLCPropClose), 3)
+		var v *float64
+		if i >= 2 {
+			v = NewFloat64(exp[i-2])
+		}
+		assertSeriesVal(t, "fisher", i, v, fisher)
+	}
+}
+
+// TestSeriesFisherFlat tests that a flat source sits at the middle of its range
+func TestSeriesFisherFlat(t *testing.T) {
+	assertFlatTo(t, "fisher", 2, 0, func(p ValueSeries) ValueSeries {
+		fisher, _ := Fisher(p, 3)
+		return fisher
+	})
+}
+
+// TestSeriesFisherBounded tests that a source at the top of its range keeps the Fisher transform finite and positive.
+// value converges to 0.66 * 0.5 / (1 - 0.67) = 1 and is clamped to 0.999, so fisher converges to 2 * atanh(0.999).
+func TestSeriesFisherBounded(t *testing.T) {
+	data := sineTestData(100, 1000)
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	limit := 2 * math.Atanh(0.999)
+	for i := range data {
+		series.Next()
+		fisher, _ := Fisher(OHLCVAttr(series, OHLCPropClose), 10)
+		if i < 9 {
+			continue
+		}
+		v := fisher.Val()
+		if v == nil {
+			t.Fatalf("Expected fisher to be non nil for iteration: %d", i)
+		}
+		if *v <= 0 || *v > limit+1e-9 {
+			t.Errorf("Expected fisher to be within (0, %.4f] but got %+v for iteration: %d", limit, *v, i)
+		}
+	}
+}
+
+func TestSeriesFisherTrigger(t *testing.T) {
+	assertTrigger(t, "trigger", func(o OHLCVSeries) (ValueSeries, ValueSeries) {
+		return Fisher(OHLCVAttr(o, OHLCPropHL2), 10)
+	})
+}
+
+func TestMemoryLeakFisher(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		Fisher(OHLCVAttr(o, OHLCPropHL2), 3)
+		return nil
+	})
+}
+
+func ExampleFisher() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		fisher, trigger := Fisher(OHLCVAttr(series, OHLCPropHL2), 3)
+		log.Printf("fisher: %+v, trigger: %+v", fisher.Val(), trigger.Val())
+	}
+}
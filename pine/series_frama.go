@@ -0,0 +1,73 @@
+package pine
+
+import (
+	"fmt"
+	"math"
+)
+
+// FRAMA generates a ValueSeries of Ehlers' fractal adaptive moving average.
+// The smoothing factor is derived from the fractal dimension of the lookback window.
+//
+// The formula for FRAMA is
+//   - n1 = (highest - lowest) of the most recent l/2 values / (l/2)
+//   - n2 = (highest - lowest) of the previous l/2 values / (l/2)
+//   - n3 = (highest - lowest) of the l values / l
+//   - d = (log(n1 + n2) - log(n3)) / log(2)
+//   - alpha = exp(-4.6 * (d - 1)) clamped to [0.01, 1]
+//   - frama = alpha * p + (1 - alpha) * frama[1]
+//
+// The first FRAMA value is seeded with the source value.
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - l - int64: lookback periods [2, ∞). Odd numbers are rounded down to an even number
+func FRAMA(p ValueSeries, l int64) ValueSeries {
+	key := fmt.Sprintf("frama:%s:%d", p.ID(), l)
+	frama := getCache(key)
+	if frama == nil {
+		frama = NewValueSeries()
+	}
+
+	if p == nil || p.GetCurrent() == nil {
+		return frama
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+
+	half := int(l / 2)
+
+	frama = generateRecursive(*stop, p, frama, half*2, func(w []float64, prev *float64) float64 {
+		cur := w[len(w)-1]
+		if prev == nil {
+			return cur
+		}
+		n1 := windowRange(w[half:]) / float64(half)
+		n2 := windowRange(w[:half]) / float64(half)
+		n3 := windowRange(w) / float64(half*2)
+
+		alpha := 1.0
+		if n3 > 0 && n1+n2 > 0 {
+			d := (math.Log(n1+n2) - math.Log(n3)) / math.Log(2)
+			alpha = math.Min(math.Max(math.Exp(-4.6*(d-1)), 0.01), 1)
+		}
+		return alpha*cur + (1-alpha)*(*prev)
+	})
+
+	setCache(key, frama)
+
+	frama.SetCurrent(stop.t)
+
+	return frama
+}
+
+// windowRange returns the difference between the highest and the lowest value of w
+func windowRange(w []float64) float64 {
+	h := math.Inf(-1)
+	lo := math.Inf(1)
+	for _, v := range w {
+		h = math.Max(h, v)
+		lo = math.Min(lo, v)
+	}
+	return h - lo
+}
@@ -0,0 +1,94 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesFRAMAIteration tests the output against values worked out by hand from the formula of FRAMA
+func TestSeriesFRAMAIteration(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		nil,
+		nil,
+		nil,
+		NewFloat64(11.9000),
+		NewFloat64(16.6950),
+		NewFloat64(16.5532),
+		NewFloat64(14.7493),
+		NewFloat64(11.5480),
+		NewFloat64(13.7706),
+		NewFloat64(13.6929),
+	}
+
+	for i, v := range tests {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropClose)
+		frama := FRAMA(prop, 4)
+		assertSeriesVal(t, "frama", i, v, frama)
+	}
+}
+
+// TestSeriesFRAMATrend tests that the fractal dimension of a straight line is 1 so that FRAMA follows the source without lag
+func TestSeriesFRAMATrend(t *testing.T) {
+	data := OHLCVStaticTestData()
+	for i := range data {
+		data[i].C = 10 + float64(i)
+	}
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range data {
+		series.Next()
+		var exp *float64
+		if i >= 3 {
+			exp = NewFloat64(data[i].C)
+		}
+		assertSeriesVal(t, "frama", i, exp, FRAMA(OHLCVAttr(series, OHLCPropClose), 4))
+	}
+}
+
+// TestSeriesFRAMAFlat tests that a flat window returns the flat value
+func TestSeriesFRAMAFlat(t *testing.T) {
+	assertFlat(t, "frama", 3, func(p ValueSeries) ValueSeries {
+		return FRAMA(p, 4)
+	})
+}
+
+// TestSeriesFRAMASourceTrimmed tests that trimming the source to 6 values does not change the output
+func TestSeriesFRAMASourceTrimmed(t *testing.T) {
+	assertSourceTrimmed(t, "frama", 6, func(p ValueSeries) ValueSeries {
+		return FRAMA(p, 4)
+	})
+}
+
+func TestMemoryLeakFRAMA(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		FRAMA(prop, 4)
+		return nil
+	})
+}
+
+func ExampleFRAMA() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		frama := FRAMA(prop, 4)
+		log.Printf("FRAMA: %+v", frama.Val())
+	}
+}
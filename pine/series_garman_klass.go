@@ -0,0 +1,44 @@
+package pine
+
+import (
+	"fmt"
+	"math"
+)
+
+// GarmanKlass generates a ValueSeries of Garman-Klass volatility estimator, which uses the open, high, low and close of each OHLCV.
+// It assumes no drift and no opening jumps.
+//
+// The formula for GarmanKlass is
+//   - variance = sma(0.5 * ln(high / low)^2 - (2 * ln(2) - 1) * ln(close / open)^2, l)
+//   - garmanklass = sqrt(variance * ppy)
+//
+// The arguments are:
+//   - o: OHLCVSeries - source of data
+//   - l: int64 - lookback periods [1, ∞)
+//   - ppy: float64 - OHLCVs per year to annualize the volatility. See PeriodsPerYear. Use 1 for the volatility per OHLCV
+func GarmanKlass(o OHLCVSeries, l int64, ppy float64) ValueSeries {
+	key := fmt.Sprintf("garmanklass:%s:%d:%+v", o.ID(), l, ppy)
+	gk := getCache(key)
+	if gk == nil {
+		gk = NewValueSeries()
+	}
+
+	stop := o.Current()
+	if stop == nil {
+		return gk
+	}
+
+	term := ohlcvTerm(o, "garmanklassterm", func(cur *OHLCV) *float64 {
+		hl := math.Log(cur.H / cur.L)
+		co := math.Log(cur.C / cur.O)
+		return NewFloat64(0.5*hl*hl - (2*math.Ln2-1)*co*co)
+	})
+
+	gk = annualizedVolatility(SMA(term, l), ppy)
+
+	setCache(key, gk)
+
+	gk.SetCurrent(stop.S)
+
+	return gk
+}
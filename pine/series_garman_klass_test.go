@@ -0,0 +1,50 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesGarmanKlassReference tests GarmanKlass(10, 252) against the formula composed of TA-Lib's SMA on referenceTestData
+func TestSeriesGarmanKlassReference(t *testing.T) {
+	assertReference(t, "garmanklass", 9, []float64{
+		0.3599, 0.3375, 0.3357, 0.3631, 0.3481, 0.3407, 0.3599, 0.3769,
+		0.3858, 0.3934, 0.4057, 0.4143, 0.4250, 0.4052, 0.4107, 0.4114,
+		0.3967, 0.3756, 0.3595, 0.3605, 0.3743, 0.3591, 0.3230, 0.3309,
+		0.3086, 0.3168, 0.3366, 0.3719, 0.4020, 0.3939, 0.3698, 0.3799,
+		0.3941, 0.4284, 0.4255, 0.4432, 0.4509, 0.4329, 0.4307, 0.4415,
+		0.4508, 0.4485, 0.4807, 0.4540, 0.4714, 0.4526, 0.4593, 0.4492,
+		0.4596, 0.4574, 0.4667,
+	}, func(o OHLCVSeries) ValueSeries {
+		return GarmanKlass(o, 10, 252)
+	})
+}
+
+// TestSeriesGarmanKlassFlat tests that OHLCVs without a range have no volatility
+func TestSeriesGarmanKlassFlat(t *testing.T) {
+	assertFlatOHLCV(t, "garmanklass", 2, NewFloat64(0), func(o OHLCVSeries) ValueSeries {
+		return GarmanKlass(o, 3, 252)
+	})
+}
+
+func TestMemoryLeakGarmanKlass(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		GarmanKlass(o, 3, 252)
+		return nil
+	})
+}
+
+func ExampleGarmanKlass() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		gk := GarmanKlass(series, 3, 252)
+		log.Printf("GarmanKlass: %+v", gk.Val())
+	}
+}
@@ -0,0 +1,66 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// Highest generates a ValueSeries of the highest value for a given number of bars back.
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - l - int64: lookback periods [1, ∞)
+func Highest(p ValueSeries, l int64) ValueSeries {
+	h, _ := getHighest(p, l)
+	return h
+}
+
+// HighestBars generates a ValueSeries of the offset to the highest value for a given number of bars back.
+// The offset is 0 or negative, e.g. -2 means the highest value was 2 bars ago.
+// If multiple values are the highest, the most recent one is used.
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - l - int64: lookback periods [1, ∞)
+func HighestBars(p ValueSeries, l int64) ValueSeries {
+	_, bars := getHighest(p, l)
+	return bars
+}
+
+func getHighest(p ValueSeries, l int64) (ValueSeries, ValueSeries) {
+	key := fmt.Sprintf("highest:%s:%d", p.ID(), l)
+	highest := getCache(key)
+	if highest == nil {
+		highest = NewValueSeries()
+	}
+
+	barskey := fmt.Sprintf("highestbars:%s:%d", p.ID(), l)
+	bars := getCache(barskey)
+	if bars == nil {
+		bars = NewValueSeries()
+	}
+
+	if p == nil || p.GetCurrent() == nil {
+		return highest, bars
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+
+	d := getDequeCache(key)
+	if d == nil {
+		d = newMonotonicDeque(func(a, b float64) bool {
+			return a > b
+		})
+	}
+
+	generateExtreme(*stop, p, highest, bars, d, l)
+
+	setDequeCache(key, d)
+	setCache(key, highest)
+	setCache(barskey, bars)
+
+	highest.SetCurrent(stop.t)
+	bars.SetCurrent(stop.t)
+
+	return highest, bars
+}
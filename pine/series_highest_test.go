@@ -0,0 +1,130 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesHighestNoData tests no data scenario
+func TestSeriesHighestNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prop := OHLCVAttr(series, OHLCPropClose)
+	highest := Highest(prop, 3)
+	if highest == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if highest.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *highest.Val())
+	}
+}
+
+// TestSeriesHighestIteration tests the output against values of the Pine Script reference formula
+func TestSeriesHighestIteration(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// array in order of Highest, HighestBars
+	tests := [][]*float64{
+		{nil, nil},
+		{nil, nil},
+		{NewFloat64(18.7000), NewFloat64(-1)},
+		{NewFloat64(18.7000), NewFloat64(-2)},
+		{NewFloat64(19.3000), NewFloat64(0)},
+		{NewFloat64(19.3000), NewFloat64(-1)},
+		{NewFloat64(19.3000), NewFloat64(-2)},
+		{NewFloat64(14.4000), NewFloat64(-1)},
+		{NewFloat64(14.7000), NewFloat64(0)},
+		{NewFloat64(14.7000), NewFloat64(-1)},
+	}
+
+	for i, v := range tests {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropClose)
+		highest := Highest(prop, 3)
+		bars := HighestBars(prop, 3)
+		assertSeriesVal(t, "highest", i, v[0], highest)
+		assertSeriesVal(t, "bars", i, v[1], bars)
+	}
+}
+
+// TestSeriesHighestSetMax tests that the rolling window is kept after the series are trimmed
+func TestSeriesHighestSetMax(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := [][]*float64{
+		{nil, nil},
+		{nil, nil},
+		{NewFloat64(18.7000), NewFloat64(-1)},
+		{NewFloat64(18.7000), NewFloat64(-2)},
+		{NewFloat64(19.3000), NewFloat64(0)},
+		{NewFloat64(19.3000), NewFloat64(-1)},
+		{NewFloat64(19.3000), NewFloat64(-2)},
+		{NewFloat64(14.4000), NewFloat64(-1)},
+		{NewFloat64(14.7000), NewFloat64(0)},
+		{NewFloat64(14.7000), NewFloat64(-1)},
+	}
+
+	for i, v := range tests {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropClose)
+		prop.SetMax(2)
+		highest := Highest(prop, 3)
+		highest.SetMax(2)
+		bars := HighestBars(prop, 3)
+		bars.SetMax(2)
+		assertSeriesVal(t, "highest", i, v[0], highest)
+		assertSeriesVal(t, "bars", i, v[1], bars)
+	}
+}
+
+func TestMemoryLeakHighest(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		Highest(prop, 500)
+		HighestBars(prop, 500)
+		return nil
+	})
+}
+
+func BenchmarkHighest(b *testing.B) {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+
+	for n := 0; n < b.N; n++ {
+		series.Next()
+		Highest(OHLCVAttr(series, OHLCPropClose), 500)
+	}
+}
+
+func ExampleHighest() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		highest := Highest(prop, 20)
+		bars := HighestBars(prop, 20)
+		log.Printf("Highest: %+v, bars: %+v", highest.Val(), bars.Val())
+	}
+}
@@ -0,0 +1,40 @@
+package pine
+
+import (
+	"fmt"
+	"math"
+)
+
+// HMA generates a ValueSeries of Hull moving average.
+//
+// The formula for HMA is
+//   - hma = wma(2 * wma(p, l / 2) - wma(p, l), floor(sqrt(l)))
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - l - int64: lookback periods [2, ∞)
+func HMA(p ValueSeries, l int64) ValueSeries {
+	key := fmt.Sprintf("hma:%s:%d", p.ID(), l)
+	hma := getCache(key)
+	if hma == nil {
+		hma = NewValueSeries()
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+	if stop == nil {
+		return hma
+	}
+
+	half := MulConst(WMA(p, l/2), 2)
+	diff := Sub(half, WMA(p, l))
+	sqrtl := int64(math.Floor(math.Sqrt(float64(l))))
+
+	hma = WMA(diff, sqrtl)
+
+	setCache(key, hma)
+
+	hma.SetCurrent(stop.t)
+
+	return hma
+}
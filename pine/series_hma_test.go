@@ -0,0 +1,54 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesHMAReference tests HMA(close, 9) against wma(2 * wma(close, 4) - wma(close, 9), 3) computed with TA-Lib's WMA
+func TestSeriesHMAReference(t *testing.T) {
+	exp := []float64{
+		97.6726, 98.5210, 99.9442, 100.4961, 100.2570, 99.4701, 98.2561, 97.8275,
+		97.3504, 96.8903, 97.1581, 96.9789, 96.5070, 96.3787, 97.2354, 98.8266,
+		100.7160, 101.9020, 101.6823, 99.8801, 98.3653, 97.4088, 97.4710, 98.0182,
+		98.0401, 97.9499, 96.6820, 95.1891, 93.9814, 93.4536, 92.8962, 92.3406,
+		91.5219, 90.7998, 90.0645, 88.5655, 86.5235, 85.0471, 85.3460, 85.8038,
+		85.8990, 86.3178, 85.9493, 85.7943, 85.0823, 83.8027, 83.5344, 85.0052,
+		87.0440, 88.4401,
+	}
+
+	assertReference(t, "hma", 10, exp, func(o OHLCVSeries) ValueSeries {
+		return HMA(OHLCVAttr(o, OHLCPropClose), 9)
+	})
+}
+
+// TestSeriesHMAFlat tests that a flat window returns the flat value
+func TestSeriesHMAFlat(t *testing.T) {
+	assertFlat(t, "hma", 4, func(p ValueSeries) ValueSeries {
+		return HMA(p, 4)
+	})
+}
+
+func TestMemoryLeakHMA(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		HMA(prop, 9)
+		return nil
+	})
+}
+
+func ExampleHMA() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		hma := HMA(prop, 9)
+		log.Printf("HMA: %+v", hma.Val())
+	}
+}
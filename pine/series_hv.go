@@ -0,0 +1,46 @@
+package pine
+
+import (
+	"fmt"
+	"math"
+)
+
+// HV generates a ValueSeries of historical volatility, which is the standard deviation of the close to close log returns.
+//
+// The formula for HV is
+//   - hv = sqrt(variance(ln(close / close[1]), l) * ppy)
+//
+// where variance is the sample variance with the denominator of l - 1 as in Stdev.
+// Multiply by 100 for the percentage as in TradingView's Historical Volatility.
+//
+// The arguments are:
+//   - o: OHLCVSeries - source of data
+//   - l: int64 - lookback periods [2, ∞)
+//   - ppy: float64 - OHLCVs per year to annualize the volatility. See PeriodsPerYear. Use 1 for the volatility per OHLCV
+func HV(o OHLCVSeries, l int64, ppy float64) ValueSeries {
+	key := fmt.Sprintf("hv:%s:%d:%+v", o.ID(), l, ppy)
+	hv := getCache(key)
+	if hv == nil {
+		hv = NewValueSeries()
+	}
+
+	stop := o.Current()
+	if stop == nil || l < 2 {
+		return hv
+	}
+
+	ret := ohlcvTerm(o, "hvreturn", func(cur *OHLCV) *float64 {
+		if cur.prev == nil {
+			return nil
+		}
+		return NewFloat64(math.Log(cur.C / cur.prev.C))
+	})
+
+	hv = annualizedVolatility(Variance(ret, l), ppy)
+
+	setCache(key, hv)
+
+	hv.SetCurrent(stop.S)
+
+	return hv
+}
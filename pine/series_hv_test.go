@@ -0,0 +1,87 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesHVReference tests HV(10, 252) against TA-Lib's VAR of the log returns scaled to the sample variance on referenceTestData
+func TestSeriesHVReference(t *testing.T) {
+	assertReference(t, "hv", 10, []float64{
+		0.3564, 0.3186, 0.3228, 0.2932, 0.2901, 0.2912, 0.2574, 0.2598,
+		0.2703, 0.2465, 0.2765, 0.3060, 0.2855, 0.2880, 0.3104, 0.3112,
+		0.2997, 0.2897, 0.2841, 0.3213, 0.3208, 0.2823, 0.2823, 0.2692,
+		0.2579, 0.2593, 0.3010, 0.3048, 0.2974, 0.2692, 0.2448, 0.2471,
+		0.2348, 0.2334, 0.2297, 0.2474, 0.2025, 0.2264, 0.3044, 0.3371,
+		0.3288, 0.3841, 0.4202, 0.4364, 0.4449, 0.4222, 0.4652, 0.4985,
+		0.4740, 0.4341,
+	}, func(o OHLCVSeries) ValueSeries {
+		return HV(o, 10, 252)
+	})
+}
+
+// TestSeriesHVFlat tests that flat closes have no volatility
+func TestSeriesHVFlat(t *testing.T) {
+	assertFlatOHLCV(t, "hv", 3, NewFloat64(0), func(o OHLCVSeries) ValueSeries {
+		return HV(o, 3, 252)
+	})
+}
+
+// TestSeriesHVSourceTrimmed tests that HV resumes from the first remaining OHLCV
+// when the last OHLCV it was generated for is trimmed from the source between calls
+func TestSeriesHVSourceTrimmed(t *testing.T) {
+	data := referenceTestData()
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 12; i++ {
+		series.Next()
+		HV(series, 10, 252)
+	}
+
+	// skip the 13th to 16th OHLCV and trim the first 4
+	for i := 12; i < 16; i++ {
+		series.Next()
+	}
+	series.SetMax(int64(len(data) - 4))
+
+	// the remaining OHLCVs are expected to be generated as if the series started at the 5th OHLCV
+	fresh, err := NewOHLCVSeries(data[4:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 4; i < 15; i++ {
+		fresh.Next()
+	}
+
+	for i := 15; i < len(data); i++ {
+		fresh.Next()
+		hv := HV(series, 10, 252)
+		assertSeriesVal(t, "hv", i, HV(fresh, 10, 252).Val(), hv)
+		series.Next()
+	}
+}
+
+func TestMemoryLeakHV(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		HV(o, 3, 252)
+		return nil
+	})
+}
+
+func ExampleHV() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		hv := HV(series, 3, 252)
+		log.Printf("HV: %+v", hv.Val())
+	}
+}
@@ -0,0 +1,99 @@
+package pine
+
+import (
+	"fmt"
+	"math"
+)
+
+// Ichimoku generates ValueSeries of Ichimoku Kinko Hyo's tenkan-sen, kijun-sen, senkou span A, senkou span B and chikou span in that order.
+//
+// The formula for Ichimoku is
+//   - donchian(l) = (highest(high, l) + lowest(low, l)) / 2
+//   - tenkan = donchian(convl)
+//   - kijun = donchian(basel)
+//   - senkou A = (tenkan + kijun) / 2 displaced by disp - 1 bars into the future
+//   - senkou B = donchian(spanbl) displaced by disp - 1 bars into the future
+//   - chikou = close displaced by disp - 1 bars into the past
+//
+// Senkou spans are set at projected future times (see Offset), so the current value of senkou spans is the cloud at the current bar.
+// Chikou span is set at the time of disp - 1 bars ago and does not have a current value.
+//
+// The arguments are:
+//   - o: OHLCVSeries - source of data
+//   - convl: int64 - conversion line length. 9 is commonly used
+//   - basel: int64 - base line length. 26 is commonly used
+//   - spanbl: int64 - leading span B length. 52 is commonly used
+//   - disp: int64 - displacement. 26 is commonly used
+func Ichimoku(o OHLCVSeries, convl, basel, spanbl, disp int64) (tenkan, kijun, senkouA, senkouB, chikou ValueSeries) {
+	tenkankey := fmt.Sprintf("ichimokutenkan:%s:%d:%d:%d:%d", o.ID(), convl, basel, spanbl, disp)
+	tenkan = getCache(tenkankey)
+	if tenkan == nil {
+		tenkan = NewValueSeries()
+	}
+
+	kijunkey := fmt.Sprintf("ichimokukijun:%s:%d:%d:%d:%d", o.ID(), convl, basel, spanbl, disp)
+	kijun = getCache(kijunkey)
+	if kijun == nil {
+		kijun = NewValueSeries()
+	}
+
+	senkouAkey := fmt.Sprintf("ichimokusenkoua:%s:%d:%d:%d:%d", o.ID(), convl, basel, spanbl, disp)
+	senkouA = getCache(senkouAkey)
+	if senkouA == nil {
+		senkouA = NewValueSeries()
+	}
+
+	senkouBkey := fmt.Sprintf("ichimokusenkoub:%s:%d:%d:%d:%d", o.ID(), convl, basel, spanbl, disp)
+	senkouB = getCache(senkouBkey)
+	if senkouB == nil {
+		senkouB = NewValueSeries()
+	}
+
+	chikoukey := fmt.Sprintf("ichimokuchikou:%s:%d:%d:%d:%d", o.ID(), convl, basel, spanbl, disp)
+	chikou = getCache(chikoukey)
+	if chikou == nil {
+		chikou = NewValueSeries()
+	}
+
+	stop := o.Current()
+	if stop == nil {
+		return tenkan, kijun, senkouA, senkouB, chikou
+	}
+
+	c := OHLCVAttr(o, OHLCPropClose)
+
+	_, _, tenkan = Donchian(o, convl)
+	_, _, kijun = Donchian(o, basel)
+	leadA := DivConst(Add(tenkan, kijun), 2)
+	_, _, leadB := Donchian(o, spanbl)
+
+	senkouA = Offset(leadA, int(disp-1))
+	senkouB = Offset(leadB, int(disp-1))
+	chikou = Offset(c, -int(disp-1))
+
+	tenkan.SetCurrent(stop.S)
+	kijun.SetCurrent(stop.S)
+	senkouA.SetCurrent(stop.S)
+	senkouB.SetCurrent(stop.S)
+	chikou.SetCurrent(stop.S)
+
+	setCache(tenkankey, tenkan)
+	setCache(kijunkey, kijun)
+	setCache(senkouAkey, senkouA)
+	setCache(senkouBkey, senkouB)
+	setCache(chikoukey, chikou)
+
+	return tenkan, kijun, senkouA, senkouB, chikou
+}
+
+// IchimokuCloud returns the upper and lower boundaries of the Ichimoku cloud at the current bar.
+// nil is returned if the cloud is not available at the current bar.
+func IchimokuCloud(o OHLCVSeries, convl, basel, spanbl, disp int64) (upper, lower *float64) {
+	_, _, senkouA, senkouB, _ := Ichimoku(o, convl, basel, spanbl, disp)
+	a := senkouA.Val()
+	b := senkouB.Val()
+	if a == nil || b == nil {
+		return nil, nil
+	}
+	return NewFloat64(math.Max(*a, *b)), NewFloat64(math.Min(*a, *b))
+}
@@ -0,0 +1,120 @@
+package pine
+
+import (
+	"fmt"
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesIchimokuNoData tests no data scenario
+func TestSeriesIchimokuNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tenkan, kijun, senkouA, senkouB, chikou := Ichimoku(series, 9, 26, 52, 26)
+	if tenkan == nil || kijun == nil || senkouA == nil || senkouB == nil || chikou == nil {
+		t.Fatal("Expected to be non nil but got nil")
+	}
+	if tenkan.Val() != nil || kijun.Val() != nil || senkouA.Val() != nil || senkouB.Val() != nil || chikou.Val() != nil {
+		t.Error("Expected to be nil but got non nil")
+	}
+}
+
+// TestSeriesIchimokuIteration tests the output against values of the Pine Script reference formula
+func TestSeriesIchimokuIteration(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// array in order of tenkan, kijun, senkou A, senkou B at the current bar
+	tests := [][]*float64{
+		{nil, nil, nil, nil},
+		{NewFloat64(15.4000), nil, nil, nil},
+		{NewFloat64(14.7000), NewFloat64(15.0000), nil, nil},
+		{NewFloat64(14.9500), NewFloat64(14.9500), nil, nil},
+		{NewFloat64(15.4000), NewFloat64(14.9500), NewFloat64(14.8500), nil},
+		{NewFloat64(15.5000), NewFloat64(15.5000), NewFloat64(14.9500), NewFloat64(15.0000)},
+		{NewFloat64(16.3500), NewFloat64(15.5000), NewFloat64(15.1750), NewFloat64(14.9500)},
+		{NewFloat64(15.1000), NewFloat64(15.1000), NewFloat64(15.5000), NewFloat64(15.0500)},
+		{NewFloat64(15.1000), NewFloat64(15.1000), NewFloat64(15.9250), NewFloat64(15.5000)},
+		{NewFloat64(14.5000), NewFloat64(14.9500), NewFloat64(15.1000), NewFloat64(15.1000)},
+	}
+
+	for i, v := range tests {
+		series.Next()
+		tenkan, kijun, senkouA, senkouB, chikou := Ichimoku(series, 2, 3, 4, 3)
+		assertSeriesVal(t, "tenkan", i, v[0], tenkan)
+		assertSeriesVal(t, "kijun", i, v[1], kijun)
+		assertSeriesVal(t, "senkouA", i, v[2], senkouA)
+		assertSeriesVal(t, "senkouB", i, v[3], senkouB)
+		assertSeriesVal(t, "chikou", i, nil, chikou)
+
+		upper, lower := IchimokuCloud(series, 2, 3, 4, 3)
+		if (upper == nil) != (v[2] == nil || v[3] == nil) || (lower == nil) != (upper == nil) {
+			t.Errorf("Expected cloud to exist: %t but got %+v, %+v for iteration: %d", v[2] != nil && v[3] != nil, upper, lower, i)
+		}
+		if upper != nil && *upper < *lower {
+			t.Errorf("Expected upper %+v to be above lower %+v for iteration: %d", *upper, *lower, i)
+		}
+	}
+
+	// senkou spans are projected 2 bars into the future
+	_, _, senkouA, senkouB, chikou := Ichimoku(series, 2, 3, 4, 3)
+	futureVals := [][]float64{{15.1000, 15.1000}, {14.7250, 14.9500}}
+	for i, v := range futureVals {
+		ft := data[9].S.Add(time.Duration(i+1) * 5 * time.Minute)
+		a := senkouA.Get(ft)
+		b := senkouB.Get(ft)
+		if a == nil || b == nil {
+			t.Fatalf("Expected future senkou spans to exist at %+v", ft)
+		}
+		if fmt.Sprintf("%.04f", a.v) != fmt.Sprintf("%.04f", v[0]) {
+			t.Errorf("Expected future senkou A to be %+v but got %+v", v[0], a.v)
+		}
+		if fmt.Sprintf("%.04f", b.v) != fmt.Sprintf("%.04f", v[1]) {
+			t.Errorf("Expected future senkou B to be %+v but got %+v", v[1], b.v)
+		}
+	}
+
+	// chikou span is the close displaced 2 bars into the past
+	for i := 0; i < 8; i++ {
+		c := chikou.Get(data[i].S)
+		if c == nil || c.v != data[i+2].C {
+			t.Errorf("Expected chikou to be %+v but got %+v at %d", data[i+2].C, c, i)
+		}
+	}
+	if chikou.Get(data[8].S) != nil {
+		t.Error("Expected chikou to be nil for the last 2 bars")
+	}
+}
+
+func TestMemoryLeakIchimoku(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		Ichimoku(o, 9, 26, 52, 26)
+		return nil
+	})
+}
+
+func ExampleIchimoku() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		tenkan, kijun, _, _, _ := Ichimoku(series, 9, 26, 52, 26)
+		upper, lower := IchimokuCloud(series, 9, 26, 52, 26)
+		log.Printf("Tenkan: %+v, kijun: %+v, cloud: %+v - %+v", tenkan.Val(), kijun.Val(), upper, lower)
+	}
+}
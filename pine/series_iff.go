@@ -0,0 +1,53 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// Iff generates a ValueSeries of a where cond is true (non-zero) and b where cond is false (zero), which is cond ? a : b in PineScript.
+//
+// The values are generated at the times of cond.
+// There is no value where cond does not have a value or where the selected branch does not have a value.
+// A time without a value is filled in once the selected branch has been generated at the time, until the time is trimmed from cond.
+//
+// Parameters
+//   - cond - ValueSeries: condition, i.e. Crossover
+//   - a - ValueSeries: value where cond is true
+//   - b - ValueSeries: value where cond is false
+func Iff(cond, a, b ValueSeries) ValueSeries {
+	key := fmt.Sprintf("iff:%s:%s:%s", cond.ID(), a.ID(), b.ID())
+	return operationJoin(cond, key, func(c *Value) *float64 {
+		if c.v != 0 {
+			return branchValue(a, c)
+		}
+		return branchValue(b, c)
+	}, true, true)
+}
+
+// IffConst generates a ValueSeries of a where cond is true (non-zero) and b where cond is false (zero).
+// There is no value where cond does not have a value.
+//
+// Parameters
+//   - cond - ValueSeries: condition, i.e. Crossover
+//   - a - float64: value where cond is true
+//   - b - float64: value where cond is false
+func IffConst(cond ValueSeries, a, b float64) ValueSeries {
+	key := fmt.Sprintf("iffconst:%s:%+v:%+v", cond.ID(), a, b)
+	return operationJoin(cond, key, func(c *Value) *float64 {
+		if c.v != 0 {
+			return NewFloat64(a)
+		}
+		return NewFloat64(b)
+	}, true, false)
+}
+
+// branchValue returns the value of the branch at the time of c or nil if the branch is nil or it does not have a value at the time
+func branchValue(branch ValueSeries, c *Value) *float64 {
+	if branch == nil {
+		return nil
+	}
+	if v := branch.Get(c.t); v != nil {
+		return NewFloat64(v.v)
+	}
+	return nil
+}
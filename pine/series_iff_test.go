@@ -0,0 +1,155 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesIffNoData tests no data scenario
+func TestSeriesIffNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := OHLCVAttr(series, OHLCPropClose)
+	iff := Iff(Rising(c, 1), OHLCVAttr(series, OHLCPropHigh), OHLCVAttr(series, OHLCPropLow))
+	if iff == nil {
+		t.Fatal("Expected to be non nil but got nil")
+	}
+	if iff.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *iff.Val())
+	}
+}
+
+// TestSeriesIffIteration tests that the branch is selected by the condition and there is no value where the selected branch does not have a value
+//
+// close                         | 16.5 | 18.7 | 18.2 | 11.9 | 19.3    | 14.2 | 14.4    | 11.0 | 14.7    | 10.3 |
+// rising(close, 1)              |      | 1    | 0    | 0    | 1       | 0    | 1       | 0    | 1       | 0    |
+// sma(close, 3)                 |      |      | ...  | ...  | 16.4667 | ...  | 15.9667 | ...  | 13.3667 | ...  |
+// low                           | 11.1 | 12.3 | 10.3 | 11.7 | 11.2    | 13.5 | 12.9    | 10.3 | 12.4    | 10.0 |
+// iff(rising, sma, low)         |      |      | 10.3 | 11.7 | 16.4667 | 13.5 | 15.9667 | 10.3 | 13.3667 | 10.0 |
+// iffconst(rising, 1, -1)       |      | 1    | -1   | -1   | 1       | -1   | 1       | -1   | 1       | -1   |
+func TestSeriesIffIteration(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// array in order of iff, iffconst
+	tests := [][]*float64{
+		{nil, nil},
+		{nil, NewFloat64(1)},
+		{NewFloat64(10.3), NewFloat64(-1)},
+		{NewFloat64(11.7), NewFloat64(-1)},
+		{NewFloat64(16.4667), NewFloat64(1)},
+		{NewFloat64(13.5), NewFloat64(-1)},
+		{NewFloat64(15.9667), NewFloat64(1)},
+		{NewFloat64(10.3), NewFloat64(-1)},
+		{NewFloat64(13.3667), NewFloat64(1)},
+		{NewFloat64(10.0), NewFloat64(-1)},
+	}
+
+	for i, v := range tests {
+		series.Next()
+		c := OHLCVAttr(series, OHLCPropClose)
+		rising := Rising(c, 1)
+		iff := Iff(rising, SMA(c, 3), OHLCVAttr(series, OHLCPropLow))
+		iffconst := IffConst(rising, 1, -1)
+		assertSeriesVal(t, "iff", i, v[0], iff)
+		assertSeriesVal(t, "iffconst", i, v[1], iffconst)
+	}
+}
+
+// TestSeriesIffLateBranch tests that a time without a value is filled once the selected branch is generated
+func TestSeriesIffLateBranch(t *testing.T) {
+	now := time.Now()
+	cond := NewValueSeries()
+	a := NewValueSeries()
+	b := NewValueSeries()
+	cond.Set(now, 1)
+	cond.SetCurrent(now)
+
+	iff := Iff(cond, a, b)
+	if iff.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *iff.Val())
+	}
+
+	a.Set(now, 5)
+	iff = Iff(cond, a, b)
+	if iff.Val() == nil || *iff.Val() != 5 {
+		t.Errorf("Expected to be 5 but got %+v", iff.Val())
+	}
+}
+
+// TestSeriesIffMidSeriesBranch tests that a time without a value before the last value is filled in order
+// once the selected branch is generated at the time
+func TestSeriesIffMidSeriesBranch(t *testing.T) {
+	now := time.Now()
+	times := []time.Time{now, now.Add(time.Second), now.Add(2 * time.Second)}
+	cond := NewValueSeries()
+	a := NewValueSeries()
+	b := NewValueSeries()
+	for _, v := range times {
+		cond.Set(v, 1)
+	}
+	cond.SetCurrent(times[2])
+	a.Set(times[1], 2)
+	a.Set(times[2], 3)
+
+	iff := Iff(cond, a, b)
+	if iff.Get(times[0]) != nil {
+		t.Errorf("Expected to be nil but got %+v", iff.Get(times[0]).v)
+	}
+
+	a.Set(times[0], 1)
+	iff = Iff(cond, a, b)
+
+	exp := []float64{1, 2, 3}
+	v := iff.GetFirst()
+	for i, e := range exp {
+		if v == nil || !v.t.Equal(times[i]) || v.v != e {
+			t.Fatalf("Expected %+v at %+v but got %+v for index: %d", e, times[i], v, i)
+		}
+		v = v.next
+	}
+	if *iff.Val() != 3 {
+		t.Errorf("Expected the current value to be 3 but got %+v", *iff.Val())
+	}
+}
+
+func TestMemoryLeakIff(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		c := OHLCVAttr(o, OHLCPropClose)
+		Iff(Rising(c, 1), OHLCVAttr(o, OHLCPropHigh), OHLCVAttr(o, OHLCPropLow))
+		return nil
+	})
+}
+
+func ExampleIff() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		c := OHLCVAttr(series, OHLCPropClose)
+		// stop = close > sma(close, 20) ? low : high
+		above := Operate(c, SMA(c, 20), "above", func(a, b float64) float64 {
+			if a > b {
+				return 1
+			}
+			return 0
+		})
+		stop := Iff(above, OHLCVAttr(series, OHLCPropLow), OHLCVAttr(series, OHLCPropHigh))
+		log.Printf("Stop: %+v", stop.Val())
+	}
+}
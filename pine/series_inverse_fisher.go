@@ -0,0 +1,17 @@
+package pine
+
+import (
+	"math"
+)
+
+// InverseFisher generates a ValueSeries of Ehlers' inverse Fisher transform, which compresses the source into the range of (-1, 1).
+// The source is expected to be roughly in the range of [-5, 5], i.e. 0.1 * (rsi - 50).
+//
+// The formula for InverseFisher is
+//   - ift = (exp(2 * p) - 1) / (exp(2 * p) + 1)
+//
+// Parameters
+//   - p - ValueSeries: source data
+func InverseFisher(p ValueSeries) ValueSeries {
+	return operationConst(p, "inversefisher", math.Tanh, true)
+}
@@ -0,0 +1,67 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesInverseFisherNoData tests no data scenario
+func TestSeriesInverseFisherNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prop := OHLCVAttr(series, OHLCPropClose)
+	ifish := InverseFisher(MulConst(SubConst(prop, 15), 0.5))
+	if ifish == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if ifish.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *ifish.Val())
+	}
+}
+
+// TestSeriesInverseFisherReference tests InverseFisher((close - 92) / 5) against TA-Lib's TANH on referenceTestData
+func TestSeriesInverseFisherReference(t *testing.T) {
+	assertReference(t, "inversefisher", 0, []float64{
+		0.9762, 0.9059, 0.8658, 0.5717, 0.7064, 0.7469, 0.9118, 0.8076,
+		0.6561, 0.8312, 0.8683, 0.9114, 0.9527, 0.8791, 0.8755, 0.8596,
+		0.7591, 0.8862, 0.7496, 0.7221, 0.8823, 0.6718, 0.6279, 0.7983,
+		0.9087, 0.9318, 0.9592, 0.9474, 0.8883, 0.7034, 0.8722, 0.8281,
+		0.8764, 0.8782, 0.7739, 0.8343, 0.4235, 0.4526, 0.4526, 0.4668,
+		0.0759, 0.0838, -0.1625, -0.1820, -0.3952, -0.7802, -0.8773, -0.8164,
+		-0.5299, -0.8420, -0.8717, -0.6584, -0.8988, -0.8293, -0.9205, -0.9571,
+		-0.8538, -0.5497, -0.6458, -0.7192,
+	}, func(o OHLCVSeries) ValueSeries {
+		return InverseFisher(MulConst(SubConst(OHLCVAttr(o, OHLCPropClose), 92), 0.2))
+	})
+}
+
+func TestMemoryLeakInverseFisher(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		InverseFisher(MulConst(SubConst(prop, 15), 0.5))
+		return nil
+	})
+}
+
+func ExampleInverseFisher() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		ifish := InverseFisher(MulConst(SubConst(prop, 15), 0.5))
+		log.Printf("InverseFisher: %+v", ifish.Val())
+	}
+}
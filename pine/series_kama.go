@@ -0,0 +1,62 @@
+package pine
+
+import (
+	"fmt"
+	"math"
+)
+
+// KAMA generates a ValueSeries of Kaufman's adaptive moving average.
+// The smoothing constant adapts to the efficiency ratio of the price movement.
+//
+// The formula for KAMA is
+//   - er = abs(p - p[l]) / sum(abs(p - p[1]), l)
+//   - sc = (er * (2 / (fastl + 1) - 2 / (slowl + 1)) + 2 / (slowl + 1))^2
+//   - kama = kama[1] + sc * (p - kama[1])
+//
+// The first KAMA value is seeded with the previous source value as kama[1], as TA-Lib does.
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - l - int64: lookback periods of efficiency ratio [1, ∞)
+//   - fastl - int64: fast EMA length. 2 is commonly used
+//   - slowl - int64: slow EMA length. 30 is commonly used
+func KAMA(p ValueSeries, l, fastl, slowl int64) ValueSeries {
+	key := fmt.Sprintf("kama:%s:%d:%d:%d", p.ID(), l, fastl, slowl)
+	kama := getCache(key)
+	if kama == nil {
+		kama = NewValueSeries()
+	}
+
+	if p == nil || p.GetCurrent() == nil {
+		return kama
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+
+	fastsc := 2.0 / float64(fastl+1)
+	slowsc := 2.0 / float64(slowl+1)
+
+	kama = generateRecursive(*stop, p, kama, int(l+1), func(w []float64, prev *float64) float64 {
+		cur := w[len(w)-1]
+		if prev == nil {
+			prev = NewFloat64(w[len(w)-2])
+		}
+		var volatility float64
+		for i := 1; i < len(w); i++ {
+			volatility = volatility + math.Abs(w[i]-w[i-1])
+		}
+		var er float64
+		if volatility != 0 {
+			er = math.Abs(cur-w[0]) / volatility
+		}
+		sc := math.Pow(er*(fastsc-slowsc)+slowsc, 2)
+		return *prev + sc*(cur-*prev)
+	})
+
+	setCache(key, kama)
+
+	kama.SetCurrent(stop.t)
+
+	return kama
+}
@@ -0,0 +1,61 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesKAMAReference tests KAMA(close, 10, 2, 30) against TA-Lib's KAMA, which uses the fast and slow lengths of 2 and 30
+func TestSeriesKAMAReference(t *testing.T) {
+	exp := []float64{
+		97.9887, 97.9971, 98.0831, 98.1143, 98.1314, 98.1370, 98.0966, 98.1118,
+		98.0980, 98.0705, 98.0757, 97.9930, 97.7881, 97.7831, 97.7997, 97.8462,
+		98.0681, 98.1319, 98.1548, 98.1458, 98.1487, 98.1441, 98.1669, 98.1775,
+		98.1454, 98.1414, 97.6674, 97.3257, 97.1075, 97.0342, 96.1766, 95.5584,
+		94.3675, 93.4539, 92.4972, 90.1865, 88.2109, 87.6694, 87.7994, 87.5177,
+		87.2451, 87.2819, 87.1013, 87.0567, 86.9029, 86.7382, 86.7317, 86.7687,
+		86.7797, 86.7886,
+	}
+
+	assertReference(t, "kama", 10, exp, func(o OHLCVSeries) ValueSeries {
+		return KAMA(OHLCVAttr(o, OHLCPropClose), 10, 2, 30)
+	})
+}
+
+// TestSeriesKAMAFlat tests that a flat window returns the flat value
+func TestSeriesKAMAFlat(t *testing.T) {
+	assertFlat(t, "kama", 10, func(p ValueSeries) ValueSeries {
+		return KAMA(p, 10, 2, 30)
+	})
+}
+
+// TestSeriesKAMASourceTrimmed tests that trimming the source to 12 values does not change the output
+func TestSeriesKAMASourceTrimmed(t *testing.T) {
+	assertSourceTrimmed(t, "kama", 12, func(p ValueSeries) ValueSeries {
+		return KAMA(p, 10, 2, 30)
+	})
+}
+
+func TestMemoryLeakKAMA(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		KAMA(prop, 10, 2, 30)
+		return nil
+	})
+}
+
+func ExampleKAMA() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		kama := KAMA(prop, 10, 2, 30)
+		log.Printf("KAMA: %+v", kama.Val())
+	}
+}
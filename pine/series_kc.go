@@ -1,7 +1,13 @@
 package pine
 
 // KC generates ValueSeries of ketler channel's middle, upper and lower in that order.
+// EMA is used for the basis and the range.
 func KC(src ValueSeries, o OHLCVSeries, l int64, mult float64, usetr bool) (middle, upper, lower ValueSeries) {
+	return KCWithMA(src, o, l, mult, usetr, MATypeEMA)
+}
+
+// KCWithMA generates ValueSeries of ketler channel's middle, upper and lower in that order using the moving average of type mat for the basis and the range.
+func KCWithMA(src ValueSeries, o OHLCVSeries, l int64, mult float64, usetr bool, mat MAType) (middle, upper, lower ValueSeries) {
 
 	lower = NewValueSeries()
 	upper = NewValueSeries()
@@ -13,7 +19,7 @@ func KC(src ValueSeries, o OHLCVSeries, l int64, mult float64, usetr bool) (midd
 	}
 
 	var span ValueSeries
-	basis := EMA(src, l)
+	basis := MA(src, l, mat)
 
 	if usetr {
 		span = OHLCVAttr(o, OHLCPropTR)
@@ -23,7 +29,7 @@ func KC(src ValueSeries, o OHLCVSeries, l int64, mult float64, usetr bool) (midd
 		span = Sub(h, l)
 	}
 
-	rangeEma := EMA(span, l)
+	rangeEma := MA(span, l, mat)
 
 	middle = basis
 	rangeEmaMul := MulConst(rangeEma, mult)
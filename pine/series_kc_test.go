@@ -103,6 +103,38 @@ func TestSeriesKCIteration(t *testing.T) {
 	}
 }
 
+// TestSeriesKCWithMA tests KC with SMA against values of the reference formula
+func TestSeriesKCWithMA(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// array in order of m, u, l
+	tests := [][]*float64{
+		{nil, nil, nil},
+		{nil, nil, nil},
+		{NewFloat64(17.8000), nil, nil},
+		{NewFloat64(16.2667), NewFloat64(35.6000), NewFloat64(-3.0667)},
+		{NewFloat64(16.4667), NewFloat64(37.0500), NewFloat64(-4.1167)},
+		{NewFloat64(15.1333), NewFloat64(33.8833), NewFloat64(-3.6167)},
+		{NewFloat64(15.9667), NewFloat64(33.6333), NewFloat64(-1.7000)},
+		{NewFloat64(13.2000), NewFloat64(31.9500), NewFloat64(-5.5500)},
+		{NewFloat64(13.3667), NewFloat64(33.5333), NewFloat64(-6.8000)},
+		{NewFloat64(12.0000), NewFloat64(33.0000), NewFloat64(-9.0000)},
+	}
+
+	for i, v := range tests {
+		series.Next()
+		c := OHLCVAttr(series, OHLCPropClose)
+		m, u, l := KCWithMA(c, series, 3, 2.5, true, MATypeSMA)
+		assertSeriesVal(t, "m", i, v[0], m)
+		assertSeriesVal(t, "u", i, v[1], u)
+		assertSeriesVal(t, "l", i, v[2], l)
+	}
+}
+
 func TestMemoryLeakKC(t *testing.T) {
 	testMemoryLeak(t, func(o OHLCVSeries) error {
 		KC(OHLCVAttr(o, OHLCPropClose), o, 4, 2.5, false)
@@ -0,0 +1,244 @@
+package pine
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// LinReg generates a ValueSeries of the linear regression curve.
+// A line is fitted to the last l values using the least squares method and the value of the line at the offset from the current value is returned.
+//
+// The formula for LinReg is
+//   - linreg = intercept + slope * (l - 1 - offset)
+//
+// where x of the oldest value in the window is 0 and x of the current value is l - 1.
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - l - int: lookback periods [2, ∞)
+//   - offset - int: number of values back from the current value
+func LinReg(p ValueSeries, l int, offset int) ValueSeries {
+	key := fmt.Sprintf("linreg:%s:%d:%d", p.ID(), l, offset)
+	lr := getCache(key)
+	if lr == nil {
+		lr = NewValueSeries()
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+	if stop == nil {
+		return lr
+	}
+
+	slope, intercept, _, _ := getLinReg(p, l)
+	x := float64(l - 1 - offset)
+	lr = Operate(slope, intercept, fmt.Sprintf("linreg:%d", offset), func(s, i float64) float64 {
+		return i + s*x
+	})
+
+	setCache(key, lr)
+
+	lr.SetCurrent(stop.t)
+
+	return lr
+}
+
+// LinRegSlope generates a ValueSeries of the slope of the linear regression line of the last l values.
+// The slope is the change per value.
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - l - int: lookback periods [2, ∞)
+func LinRegSlope(p ValueSeries, l int) ValueSeries {
+	slope, _, _, _ := getLinReg(p, l)
+	return slope
+}
+
+// LinRegIntercept generates a ValueSeries of the intercept of the linear regression line of the last l values.
+// The intercept is the value of the line at the oldest value of the window.
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - l - int: lookback periods [2, ∞)
+func LinRegIntercept(p ValueSeries, l int) ValueSeries {
+	_, intercept, _, _ := getLinReg(p, l)
+	return intercept
+}
+
+// LinRegR2 generates a ValueSeries of the coefficient of determination (R²) of the linear regression line of the last l values in the range of [0, 1].
+// R² is 1 if all values in the window are the same.
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - l - int: lookback periods [2, ∞)
+func LinRegR2(p ValueSeries, l int) ValueSeries {
+	_, _, r2, _ := getLinReg(p, l)
+	return r2
+}
+
+// getLinReg generates the slope, the intercept, R² and the standard error of the linear regression line.
+//
+// The window and its running sums are kept in linRegState so that each value is calculated in O(1)
+// by sliding the window instead of fitting the whole window again.
+//
+// The standard error is nil if l is less than 3.
+func getLinReg(p ValueSeries, l int) (slope, intercept, r2, se ValueSeries) {
+	slopekey := fmt.Sprintf("linregslope:%s:%d", p.ID(), l)
+	slope = getCache(slopekey)
+	if slope == nil {
+		slope = NewValueSeries()
+	}
+
+	interceptkey := fmt.Sprintf("linregintercept:%s:%d", p.ID(), l)
+	intercept = getCache(interceptkey)
+	if intercept == nil {
+		intercept = NewValueSeries()
+	}
+
+	r2key := fmt.Sprintf("linregr2:%s:%d", p.ID(), l)
+	r2 = getCache(r2key)
+	if r2 == nil {
+		r2 = NewValueSeries()
+	}
+
+	sekey := fmt.Sprintf("linregse:%s:%d", p.ID(), l)
+	se = getCache(sekey)
+	if se == nil {
+		se = NewValueSeries()
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+	if stop == nil || l < 2 {
+		return slope, intercept, r2, se
+	}
+
+	n := float64(l)
+	sx := n * (n - 1) / 2
+	sxx := (n - 1) * n * (2*n - 1) / 6
+	denom := n*sxx - sx*sx
+
+	statekey := fmt.Sprintf("linreg:%s:%d", p.ID(), l)
+	s := linRegCache[statekey]
+
+	var f *Value
+	if s == nil {
+		s = &linRegState{}
+		f = p.GetFirst()
+	} else {
+		f = valueAfter(p, s.last)
+	}
+
+	for {
+		if f == nil {
+			break
+		}
+
+		s.push(f.v, l)
+		s.last = f.t
+
+		if len(s.window) == l {
+			// the slope and the sums of squares do not depend on the shift k but the intercept does
+			sl := (n*s.sxy - sx*s.sy) / denom
+			i := (s.sy - sl*sx) / n
+			slope.Set(f.t, sl)
+			intercept.Set(f.t, i+s.k)
+
+			// total and residual sum of squares
+			sst := s.syy - s.sy*s.sy/n
+			sse := math.Max(s.syy-i*s.sy-sl*s.sxy, 0)
+			if sst > 0 {
+				r2.Set(f.t, math.Min(math.Max(1-sse/sst, 0), 1))
+			} else {
+				r2.Set(f.t, 1)
+			}
+			if l > 2 {
+				se.Set(f.t, math.Sqrt(sse/(n-2)))
+			}
+		}
+
+		if f.t.Equal(stop.t) {
+			break
+		}
+		f = f.next
+	}
+
+	setState(linRegCache, statekey, s)
+
+	setCache(slopekey, slope)
+	setCache(interceptkey, intercept)
+	setCache(r2key, r2)
+	setCache(sekey, se)
+
+	slope.SetCurrent(stop.t)
+	intercept.SetCurrent(stop.t)
+	r2.SetCurrent(stop.t)
+	se.SetCurrent(stop.t)
+
+	return slope, intercept, r2, se
+}
+
+// linRegState is the state of the linear regression carried over to the next call
+type linRegState struct {
+	// last l values in arrival order where x of the oldest value is 0
+	window []float64
+	// running sums of y, x * y and y^2 of the window where y is shifted by k
+	sy, sxy, syy float64
+	// shift of the values in the running sums, which keeps them small to avoid losing precision
+	k float64
+	// number of slides since the running sums were calculated from the window
+	slides int
+	// time of the last processed value
+	last time.Time
+}
+
+var linRegCache map[string]*linRegState = make(map[string]*linRegState)
+
+// push appends y to the window of l values and updates the running sums.
+// As the window slides, x of every remaining value decreases by 1 so
+//   - sum(x * y) = sum(x * y)[1] - (sum(y)[1] - y[l]) + (l - 1) * y
+//
+// The running sums are calculated from the window again every l slides so that rounding errors do not accumulate.
+func (s *linRegState) push(y float64, l int) {
+	if len(s.window) == 0 {
+		s.k = y
+	}
+	if len(s.window) < l {
+		yk := y - s.k
+		s.sy += yk
+		s.sxy += float64(len(s.window)) * yk
+		s.syy += yk * yk
+		s.window = append(s.window, y)
+		return
+	}
+
+	old := s.window[0]
+	s.window = append(s.window[1:], y)
+	s.slides++
+	if s.slides >= l {
+		s.resum()
+		return
+	}
+	oldk, yk := old-s.k, y-s.k
+	s.sxy = s.sxy - (s.sy - oldk) + float64(l-1)*yk
+	s.sy = s.sy - oldk + yk
+	s.syy = s.syy - oldk*oldk + yk*yk
+}
+
+// resum shifts the values by the mean of the window and calculates the running sums from the window
+func (s *linRegState) resum() {
+	s.k = 0
+	for _, y := range s.window {
+		s.k += y
+	}
+	s.k /= float64(len(s.window))
+
+	s.sy, s.sxy, s.syy, s.slides = 0, 0, 0, 0
+	for x, y := range s.window {
+		yk := y - s.k
+		s.sy += yk
+		s.sxy += float64(x) * yk
+		s.syy += yk * yk
+	}
+}
@@ -0,0 +1,53 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// LinRegChannel generates ValueSeries of the linear regression channel's middle, upper and lower in that order.
+// The channel is the linear regression curve surrounded by the multiple of the standard error of the regression.
+//
+// The formula for LinRegChannel is
+//   - middle = linreg(p, l, 0)
+//   - se = sqrt(sum((y - (intercept + slope * x))^2) / (l - 2))
+//   - upper = middle + mult * se
+//   - lower = middle - mult * se
+//
+// The arguments are:
+//   - p: ValueSeries - source data
+//   - l: int - lookback periods [3, ∞)
+//   - mult: float64 - multiplier of the standard error
+func LinRegChannel(p ValueSeries, l int, mult float64) (middle, upper, lower ValueSeries) {
+	upperkey := fmt.Sprintf("linregupper:%s:%d:%v", p.ID(), l, mult)
+	upper = getCache(upperkey)
+	if upper == nil {
+		upper = NewValueSeries()
+	}
+
+	lowerkey := fmt.Sprintf("linreglower:%s:%d:%v", p.ID(), l, mult)
+	lower = getCache(lowerkey)
+	if lower == nil {
+		lower = NewValueSeries()
+	}
+
+	middle = LinReg(p, l, 0)
+
+	// current available value
+	stop := p.GetCurrent()
+	if stop == nil {
+		return middle, upper, lower
+	}
+
+	_, _, _, se := getLinReg(p, l)
+	band := MulConst(se, mult)
+	upper = Add(middle, band)
+	lower = Sub(middle, band)
+
+	upper.SetCurrent(stop.t)
+	lower.SetCurrent(stop.t)
+
+	setCache(upperkey, upper)
+	setCache(lowerkey, lower)
+
+	return middle, upper, lower
+}
@@ -0,0 +1,79 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesLinRegChannelNoData tests no data scenario
+func TestSeriesLinRegChannelNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	middle, upper, lower := LinRegChannel(OHLCVAttr(series, OHLCPropClose), 4, 2)
+	if middle == nil || upper == nil || lower == nil {
+		t.Fatal("Expected to be non nil but got nil")
+	}
+	if middle.Val() != nil || upper.Val() != nil || lower.Val() != nil {
+		t.Error("Expected to be nil but got non nil")
+	}
+}
+
+// TestSeriesLinRegChannelIteration tests the output against values of the reference formula
+func TestSeriesLinRegChannelIteration(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// array in order of middle, upper, lower
+	tests := [][]*float64{
+		{nil, nil, nil},
+		{nil, nil, nil},
+		{nil, nil, nil},
+		{NewFloat64(14.1800), NewFloat64(20.2698), NewFloat64(8.0902)},
+		{NewFloat64(16.3500), NewFloat64(24.6705), NewFloat64(8.0295)},
+		{NewFloat64(15.2100), NewFloat64(23.5385), NewFloat64(6.8815)},
+		{NewFloat64(15.3100), NewFloat64(22.8997), NewFloat64(7.7203)},
+		{NewFloat64(11.0200), NewFloat64(14.0804), NewFloat64(7.9596)},
+		{NewFloat64(13.2900), NewFloat64(17.4821), NewFloat64(9.0979)},
+		{NewFloat64(11.3100), NewFloat64(16.1684), NewFloat64(6.4516)},
+	}
+
+	for i, v := range tests {
+		series.Next()
+		middle, upper, lower := LinRegChannel(OHLCVAttr(series, OHLCPropClose), 4, 2)
+		assertSeriesVal(t, "middle", i, v[0], middle)
+		assertSeriesVal(t, "upper", i, v[1], upper)
+		assertSeriesVal(t, "lower", i, v[2], lower)
+	}
+}
+
+func TestMemoryLeakLinRegChannel(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		LinRegChannel(OHLCVAttr(o, OHLCPropClose), 4, 2)
+		return nil
+	})
+}
+
+func ExampleLinRegChannel() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		middle, upper, lower := LinRegChannel(OHLCVAttr(series, OHLCPropClose), 4, 2)
+		log.Printf("middle: %+v, upper: %+v, lower: %+v", middle.Val(), upper.Val(), lower.Val())
+	}
+}
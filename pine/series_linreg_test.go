@@ -0,0 +1,166 @@
+package pine
+
+import (
+	"log"
+	"math"
+	"testing"
+	"time"
+)
+
+// TestSeriesLinRegNoData tests no data scenario
+func TestSeriesLinRegNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prop := OHLCVAttr(series, OHLCPropClose)
+	linreg := LinReg(prop, 4, 0)
+	if linreg == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if linreg.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *linreg.Val())
+	}
+}
+
+// TestSeriesLinRegReference tests LinReg(14, 0) against TA-Lib's LINEARREG on referenceTestData
+func TestSeriesLinRegReference(t *testing.T) {
+	exp := []float64{
+		98.5440, 99.2580, 99.5074, 99.2697, 99.1294, 98.4903, 97.8223, 98.1800,
+		97.5254, 96.5783, 96.3857, 96.8506, 97.6331, 98.9671, 99.7746, 100.0046,
+		99.4863, 99.4166, 99.3843, 99.3040, 99.1357, 98.8063, 98.3403, 96.8003,
+		95.6017, 94.7940, 94.2654, 93.5391, 92.9960, 92.1000, 91.0180, 90.1206,
+		88.5529, 87.0217, 86.1657, 86.1423, 85.6529, 84.8309, 84.9977, 84.4780,
+		84.6103, 84.1551, 83.5351, 83.8457, 85.1383, 86.1329, 86.5514,
+	}
+
+	assertReference(t, "linreg", 13, exp, func(o OHLCVSeries) ValueSeries {
+		return LinReg(OHLCVAttr(o, OHLCPropClose), 14, 0)
+	})
+}
+
+// TestSeriesLinRegOffsetReference tests LinReg(14, -1), which is the line projected to the next value, against TA-Lib's TSF on referenceTestData
+func TestSeriesLinRegOffsetReference(t *testing.T) {
+	exp := []float64{
+		98.5477, 99.4185, 99.7179, 99.4613, 99.2580, 98.5155, 97.7477, 98.1688,
+		97.4303, 96.3401, 96.1233, 96.6490, 97.5445, 99.0796, 99.9874, 100.2496,
+		99.6745, 99.5749, 99.5499, 99.4359, 99.2167, 98.8562, 98.2971, 96.5359,
+		95.1863, 94.3110, 93.7652, 93.0293, 92.4973, 91.5501, 90.3598, 89.4210,
+		87.7345, 86.1175, 85.2679, 85.3299, 84.8987, 84.0487, 84.3114, 83.8191,
+		84.0647, 83.6312, 83.0254, 83.4445, 84.9598, 86.1266, 86.6019,
+	}
+
+	assertReference(t, "linreg", 13, exp, func(o OHLCVSeries) ValueSeries {
+		return LinReg(OHLCVAttr(o, OHLCPropClose), 14, -1)
+	})
+}
+
+// TestSeriesLinRegFitReference tests the slope and the intercept against TA-Lib's LINEARREG_SLOPE and LINEARREG_INTERCEPT
+// and R² against the square of TA-Lib's CORREL of the closes and their x on referenceTestData
+func TestSeriesLinRegFitReference(t *testing.T) {
+	assertReference(t, "slope", 13, []float64{
+		0.0037, 0.1605, 0.2105, 0.1916, 0.1286, 0.0252, -0.0746, -0.0112,
+		-0.0951, -0.2382, -0.2624, -0.2016, -0.0886, 0.1124, 0.2128, 0.2450,
+		0.1882, 0.1584, 0.1656, 0.1319, 0.0810, 0.0499, -0.0431, -0.2644,
+		-0.4155, -0.4830, -0.5003, -0.5098, -0.4987, -0.5499, -0.6582, -0.6996,
+		-0.8184, -0.9042, -0.8978, -0.8124, -0.7542, -0.7822, -0.6863, -0.6589,
+		-0.5456, -0.5239, -0.5098, -0.4012, -0.1785, -0.0063, 0.0504,
+	}, func(o OHLCVSeries) ValueSeries {
+		return LinRegSlope(OHLCVAttr(o, OHLCPropClose), 14)
+	})
+
+	assertReference(t, "intercept", 13, []float64{
+		98.4960, 97.1720, 96.7711, 96.7789, 97.4577, 98.1626, 98.7920, 98.3257,
+		98.7617, 99.6746, 99.7971, 99.4709, 98.7854, 97.5057, 97.0083, 96.8197,
+		97.0394, 97.3577, 97.2314, 97.5889, 98.0829, 98.1580, 98.9011, 100.2369,
+		101.0026, 101.0731, 100.7689, 100.1666, 99.4797, 99.2486, 99.5749, 99.2151,
+		99.1914, 98.7769, 97.8371, 96.7034, 95.4571, 94.9991, 93.9194, 93.0434,
+		91.7026, 90.9663, 90.1620, 89.0614, 87.4589, 86.2143, 85.8957,
+	}, func(o OHLCVSeries) ValueSeries {
+		return LinRegIntercept(OHLCVAttr(o, OHLCPropClose), 14)
+	})
+
+	assertReference(t, "r2", 13, []float64{
+		0.0001, 0.1616, 0.2919, 0.2352, 0.1353, 0.0055, 0.0467, 0.0011,
+		0.0692, 0.4184, 0.5016, 0.2788, 0.0501, 0.0754, 0.2279, 0.3000,
+		0.1633, 0.1197, 0.1316, 0.0874, 0.0359, 0.0131, 0.0113, 0.3330,
+		0.6271, 0.7177, 0.7373, 0.7382, 0.7349, 0.7633, 0.9064, 0.9234,
+		0.9314, 0.9227, 0.9217, 0.8508, 0.8430, 0.8610, 0.7560, 0.7424,
+		0.6647, 0.6505, 0.6467, 0.5093, 0.1290, 0.0002, 0.0121,
+	}, func(o OHLCVSeries) ValueSeries {
+		return LinRegR2(OHLCVAttr(o, OHLCPropClose), 14)
+	})
+}
+
+// TestSeriesLinRegDrift tests that the running sums do not drift from a fit of the whole window
+// after sliding over many large values whose variance in the window is small
+func TestSeriesLinRegDrift(t *testing.T) {
+	start := time.Now()
+	data := OHLCVTestData(start, 5000, 60*1000)
+	for i := range data {
+		data[i].C = 1e6 + float64((i*7)%10)*0.01
+	}
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var slope, r2 ValueSeries
+	for range data {
+		series.Next()
+		c := OHLCVAttr(series, OHLCPropClose)
+		slope, r2 = LinRegSlope(c, 10), LinRegR2(c, 10)
+	}
+
+	// least squares of the last 10 closes with the mean subtracted
+	w := data[len(data)-10:]
+	var my float64
+	for _, v := range w {
+		my += v.C / 10
+	}
+	var sxy, sxx, syy float64
+	for i, v := range w {
+		x, y := float64(i)-4.5, v.C-my
+		sxy += x * y
+		sxx += x * x
+		syy += y * y
+	}
+	expSlope := sxy / sxx
+	expR2 := sxy * sxy / (sxx * syy)
+
+	if math.Abs(*slope.Val()-expSlope) > 1e-6 {
+		t.Errorf("Expected slope to be %+v but got %+v", expSlope, *slope.Val())
+	}
+	if math.Abs(*r2.Val()-expR2) > 1e-6 {
+		t.Errorf("Expected r2 to be %+v but got %+v", expR2, *r2.Val())
+	}
+}
+
+func TestMemoryLeakLinReg(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		LinReg(prop, 4, 0)
+		return nil
+	})
+}
+
+func ExampleLinReg() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		linreg := LinReg(prop, 4, 0)
+		log.Printf("LinReg: %+v", linreg.Val())
+	}
+}
@@ -0,0 +1,66 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// Lowest generates a ValueSeries of the lowest value for a given number of bars back.
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - l - int64: lookback periods [1, ∞)
+func Lowest(p ValueSeries, l int64) ValueSeries {
+	lo, _ := getLowest(p, l)
+	return lo
+}
+
+// LowestBars generates a ValueSeries of the offset to the lowest value for a given number of bars back.
+// The offset is 0 or negative, e.g. -2 means the lowest value was 2 bars ago.
+// If multiple values are the lowest, the most recent one is used.
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - l - int64: lookback periods [1, ∞)
+func LowestBars(p ValueSeries, l int64) ValueSeries {
+	_, bars := getLowest(p, l)
+	return bars
+}
+
+func getLowest(p ValueSeries, l int64) (ValueSeries, ValueSeries) {
+	key := fmt.Sprintf("lowest:%s:%d", p.ID(), l)
+	lowest := getCache(key)
+	if lowest == nil {
+		lowest = NewValueSeries()
+	}
+
+	barskey := fmt.Sprintf("lowestbars:%s:%d", p.ID(), l)
+	bars := getCache(barskey)
+	if bars == nil {
+		bars = NewValueSeries()
+	}
+
+	if p == nil || p.GetCurrent() == nil {
+		return lowest, bars
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+
+	d := getDequeCache(key)
+	if d == nil {
+		d = newMonotonicDeque(func(a, b float64) bool {
+			return a < b
+		})
+	}
+
+	generateExtreme(*stop, p, lowest, bars, d, l)
+
+	setDequeCache(key, d)
+	setCache(key, lowest)
+	setCache(barskey, bars)
+
+	lowest.SetCurrent(stop.t)
+	bars.SetCurrent(stop.t)
+
+	return lowest, bars
+}
@@ -0,0 +1,130 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesLowestNoData tests no data scenario
+func TestSeriesLowestNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prop := OHLCVAttr(series, OHLCPropClose)
+	lowest := Lowest(prop, 3)
+	if lowest == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if lowest.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *lowest.Val())
+	}
+}
+
+// TestSeriesLowestIteration tests the output against values of the Pine Script reference formula
+func TestSeriesLowestIteration(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// array in order of Lowest, LowestBars
+	tests := [][]*float64{
+		{nil, nil},
+		{nil, nil},
+		{NewFloat64(16.5000), NewFloat64(-2)},
+		{NewFloat64(11.9000), NewFloat64(0)},
+		{NewFloat64(11.9000), NewFloat64(-1)},
+		{NewFloat64(11.9000), NewFloat64(-2)},
+		{NewFloat64(14.2000), NewFloat64(-1)},
+		{NewFloat64(11.0000), NewFloat64(0)},
+		{NewFloat64(11.0000), NewFloat64(-1)},
+		{NewFloat64(10.3000), NewFloat64(0)},
+	}
+
+	for i, v := range tests {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropClose)
+		lowest := Lowest(prop, 3)
+		bars := LowestBars(prop, 3)
+		assertSeriesVal(t, "lowest", i, v[0], lowest)
+		assertSeriesVal(t, "bars", i, v[1], bars)
+	}
+}
+
+// TestSeriesLowestSetMax tests that the rolling window is kept after the series are trimmed
+func TestSeriesLowestSetMax(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := [][]*float64{
+		{nil, nil},
+		{nil, nil},
+		{NewFloat64(16.5000), NewFloat64(-2)},
+		{NewFloat64(11.9000), NewFloat64(0)},
+		{NewFloat64(11.9000), NewFloat64(-1)},
+		{NewFloat64(11.9000), NewFloat64(-2)},
+		{NewFloat64(14.2000), NewFloat64(-1)},
+		{NewFloat64(11.0000), NewFloat64(0)},
+		{NewFloat64(11.0000), NewFloat64(-1)},
+		{NewFloat64(10.3000), NewFloat64(0)},
+	}
+
+	for i, v := range tests {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropClose)
+		prop.SetMax(2)
+		lowest := Lowest(prop, 3)
+		lowest.SetMax(2)
+		bars := LowestBars(prop, 3)
+		bars.SetMax(2)
+		assertSeriesVal(t, "lowest", i, v[0], lowest)
+		assertSeriesVal(t, "bars", i, v[1], bars)
+	}
+}
+
+func TestMemoryLeakLowest(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		Lowest(prop, 500)
+		LowestBars(prop, 500)
+		return nil
+	})
+}
+
+func BenchmarkLowest(b *testing.B) {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+
+	for n := 0; n < b.N; n++ {
+		series.Next()
+		Lowest(OHLCVAttr(series, OHLCPropClose), 500)
+	}
+}
+
+func ExampleLowest() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		lowest := Lowest(prop, 20)
+		bars := LowestBars(prop, 20)
+		log.Printf("Lowest: %+v, bars: %+v", lowest.Val(), bars.Val())
+	}
+}
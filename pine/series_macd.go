@@ -26,19 +26,28 @@ import (
 //   - histLine: ValueSeries - MACD Histogram
 //   - err: error
 func MACD(src ValueSeries, fastlen, slowlen, siglen int64) (ValueSeries, ValueSeries, ValueSeries) {
-	macdlineKey := fmt.Sprintf("macdline:%s:%d:%d:%d", src.ID(), fastlen, slowlen, siglen)
+	return MACDWithMA(src, fastlen, slowlen, siglen, MATypeEMA, MATypeEMA)
+}
+
+// MACDWithMA generates a ValueSeries of MACD using the moving average of type oscmat for the MACD Line and sigmat for the Signal Line.
+//
+// The arguments are same as MACD with the addition of:
+//   - oscmat: MAType - moving average type of the fast and slow series
+//   - sigmat: MAType - moving average type of the signal line
+func MACDWithMA(src ValueSeries, fastlen, slowlen, siglen int64, oscmat, sigmat MAType) (ValueSeries, ValueSeries, ValueSeries) {
+	macdlineKey := fmt.Sprintf("macdline:%s:%d:%d:%d:%d:%d", src.ID(), fastlen, slowlen, siglen, oscmat, sigmat)
 	macdline := getCache(macdlineKey)
 	if macdline == nil {
 		macdline = NewValueSeries()
 	}
 
-	signalLineKey := fmt.Sprintf("macdsignal:%s:%d:%d:%d", src.ID(), fastlen, slowlen, siglen)
+	signalLineKey := fmt.Sprintf("macdsignal:%s:%d:%d:%d:%d:%d", src.ID(), fastlen, slowlen, siglen, oscmat, sigmat)
 	signalLine := getCache(signalLineKey)
 	if signalLine == nil {
 		signalLine = NewValueSeries()
 	}
 
-	macdHistogramKey := fmt.Sprintf("macdhistogram:%s:%d:%d:%d", src.ID(), fastlen, slowlen, siglen)
+	macdHistogramKey := fmt.Sprintf("macdhistogram:%s:%d:%d:%d:%d:%d", src.ID(), fastlen, slowlen, siglen, oscmat, sigmat)
 	macdHistogram := getCache(macdHistogramKey)
 	if macdHistogram == nil {
 		macdHistogram = NewValueSeries()
@@ -56,14 +65,14 @@ func MACD(src ValueSeries, fastlen, slowlen, siglen int64) (ValueSeries, ValueSe
 		return macdline, signalLine, macdHistogram
 	}
 
-	fast := EMA(src, fastlen)
+	fast := MA(src, fastlen, oscmat)
 
-	slow := EMA(src, slowlen)
+	slow := MA(src, slowlen, oscmat)
 
 	macdline = Sub(fast, slow)
 	macdline.SetCurrent(stop.t)
 
-	signalLine = EMA(macdline, siglen)
+	signalLine = MA(macdline, siglen, sigmat)
 	signalLine.SetCurrent(stop.t)
 
 	macdHistogram = Sub(macdline, signalLine)
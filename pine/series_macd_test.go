@@ -160,6 +160,38 @@ func TestSeriesMACDIteration(t *testing.T) {
 	}
 }
 
+// TestSeriesMACDWithMA tests MACD with SMA against values of the reference formula
+func TestSeriesMACDWithMA(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// array in order of macd, signal, histogram
+	tests := [][]*float64{
+		{nil, nil, nil},
+		{nil, nil, nil},
+		{NewFloat64(0.6500), nil, nil},
+		{NewFloat64(-1.2167), NewFloat64(-0.2833), NewFloat64(-0.9333)},
+		{NewFloat64(-0.8667), NewFloat64(-1.0417), NewFloat64(0.1750)},
+		{NewFloat64(1.6167), NewFloat64(0.3750), NewFloat64(1.2417)},
+		{NewFloat64(-1.6667), NewFloat64(-0.0250), NewFloat64(-1.6417)},
+		{NewFloat64(-0.5000), NewFloat64(-1.0833), NewFloat64(0.5833)},
+		{NewFloat64(-0.5167), NewFloat64(-0.5083), NewFloat64(-0.0083)},
+		{NewFloat64(0.5000), NewFloat64(-0.0083), NewFloat64(0.5083)},
+	}
+
+	for i, v := range tests {
+		series.Next()
+		c := OHLCVAttr(series, OHLCPropClose)
+		macd, signal, histogram := MACDWithMA(c, 2, 3, 2, MATypeSMA, MATypeSMA)
+		assertSeriesVal(t, "macd", i, v[0], macd)
+		assertSeriesVal(t, "signal", i, v[1], signal)
+		assertSeriesVal(t, "histogram", i, v[2], histogram)
+	}
+}
+
 func TestMemoryLeakMACD(t *testing.T) {
 	testMemoryLeak(t, func(o OHLCVSeries) error {
 		MACD(OHLCVAttr(o, OHLCPropClose), 12, 26, 9)
@@ -0,0 +1,173 @@
+package pine
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// MAMA generates ValueSeries of Ehlers' MESA adaptive moving average (MAMA) and following adaptive moving average (FAMA) in that order.
+//
+// The dominant cycle is measured with the Hilbert transform and the rate of change of its phase adapts alpha between slowl and fastl.
+//
+// The formula for MAMA is
+//   - deltaPhase = max(phase[1] - phase, 1)
+//   - alpha = max(fastl / deltaPhase, slowl)
+//   - mama = alpha * p + (1 - alpha) * mama[1]
+//   - fama = 0.5 * alpha * mama + (1 - 0.5 * alpha) * fama[1]
+//
+// mama and fama start from 0 as in Ehlers' reference implementation and the Hilbert transform starts from the 13th value as in TA-Lib.
+// The values are generated from the 33rd value, which is the lookback of TA-Lib's MAMA, so that the start from 0 has faded out.
+//
+// Parameters
+//   - p - ValueSeries: source data, i.e. hl2
+//   - fastl - float64: fast limit, i.e. 0.5
+//   - slowl - float64: slow limit, i.e. 0.05
+func MAMA(p ValueSeries, fastl, slowl float64) (mama, fama ValueSeries) {
+	key := fmt.Sprintf("mama:%s:%v:%v", p.ID(), fastl, slowl)
+	mama = getCache(key)
+	if mama == nil {
+		mama = NewValueSeries()
+	}
+
+	famakey := fmt.Sprintf("fama:%s:%v:%v", p.ID(), fastl, slowl)
+	fama = getCache(famakey)
+	if fama == nil {
+		fama = NewValueSeries()
+	}
+
+	if p == nil || p.GetCurrent() == nil {
+		return mama, fama
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+
+	s := mamaCache[key]
+
+	var f *Value
+	if s == nil {
+		s = &mamaState{}
+		f = p.GetFirst()
+	} else {
+		f = valueAfter(p, s.last)
+	}
+
+	for {
+		if f == nil {
+			break
+		}
+
+		if m, fm, ok := s.next(f.v, fastl, slowl); ok {
+			mama.Set(f.t, m)
+			fama.Set(f.t, fm)
+		}
+		s.last = f.t
+
+		if f.t.Equal(stop.t) {
+			break
+		}
+		f = f.next
+	}
+
+	setState(mamaCache, key, s)
+
+	mama.SetCurrent(stop.t)
+	fama.SetCurrent(stop.t)
+
+	setCache(key, mama)
+	setCache(famakey, fama)
+
+	return mama, fama
+}
+
+// mamaState is the state of the Hilbert transform carried over to the next call.
+// Index 0 of the arrays is the latest value and index i is the value i values ago.
+type mamaState struct {
+	price     [4]float64
+	smooth    [7]float64
+	detrender [7]float64
+	i1        [7]float64
+	q1        [7]float64
+
+	i2, q2, re, im float64
+	period, phase  float64
+	mama, fama     float64
+
+	// number of values processed
+	count int
+	// time of the last processed value
+	last time.Time
+}
+
+var mamaCache map[string]*mamaState = make(map[string]*mamaState)
+
+const (
+	// TA-Lib warms up the smoother of the price with 12 values before the Hilbert transform
+	mamaWarmUp = 13
+	// TA-Lib's lookback of MAMA plus one
+	mamaFirst = 33
+)
+
+// shiftIn shifts the values of a by one and sets v as the latest value
+func shiftIn(a []float64, v float64) {
+	copy(a[1:], a[:len(a)-1])
+	a[0] = v
+}
+
+// hilbert applies the Hilbert transform on the latest value of a
+func hilbert(a []float64, period float64) float64 {
+	return (0.0962*a[0] + 0.5769*a[2] - 0.5769*a[4] - 0.0962*a[6]) * (0.075*period + 0.54)
+}
+
+// next processes price and returns mama and fama. ok is false if there are not enough values yet
+func (s *mamaState) next(price, fastl, slowl float64) (mama, fama float64, ok bool) {
+	shiftIn(s.price[:], price)
+	s.count++
+	if s.count < mamaWarmUp {
+		return 0, 0, false
+	}
+
+	shiftIn(s.smooth[:], (4*s.price[0]+3*s.price[1]+2*s.price[2]+s.price[3])/10)
+	shiftIn(s.detrender[:], hilbert(s.smooth[:], s.period))
+
+	// in-phase and quadrature components
+	shiftIn(s.q1[:], hilbert(s.detrender[:], s.period))
+	shiftIn(s.i1[:], s.detrender[3])
+
+	// advance the phase of i1 and q1 by 90 degrees
+	ji := hilbert(s.i1[:], s.period)
+	jq := hilbert(s.q1[:], s.period)
+
+	// phasor addition for 3 bar averaging
+	i2 := 0.2*(s.i1[0]-jq) + 0.8*s.i2
+	q2 := 0.2*(s.q1[0]+ji) + 0.8*s.q2
+
+	// homodyne discriminator
+	re := 0.2*(i2*s.i2+q2*s.q2) + 0.8*s.re
+	im := 0.2*(i2*s.q2-q2*s.i2) + 0.8*s.im
+	s.i2, s.q2, s.re, s.im = i2, q2, re, im
+
+	period := s.period
+	if im != 0 && re != 0 {
+		period = 360 / (math.Atan(im/re) * 180 / math.Pi)
+	}
+	period = math.Min(period, 1.5*s.period)
+	period = math.Max(period, 0.67*s.period)
+	period = math.Min(math.Max(period, 6), 50)
+	s.period = 0.2*period + 0.8*s.period
+
+	phase := s.phase
+	if s.i1[0] != 0 {
+		phase = math.Atan(s.q1[0]/s.i1[0]) * 180 / math.Pi
+	}
+	deltaPhase := math.Max(s.phase-phase, 1)
+	s.phase = phase
+
+	alpha := math.Max(fastl/deltaPhase, slowl)
+
+	s.mama = alpha*price + (1-alpha)*s.mama
+	s.fama = 0.5*alpha*s.mama + (1-0.5*alpha)*s.fama
+
+	return s.mama, s.fama, s.count >= mamaFirst
+}
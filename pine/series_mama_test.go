@@ -0,0 +1,105 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesMAMANoData tests no data scenario
+func TestSeriesMAMANoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mama, fama := MAMA(OHLCVAttr(series, OHLCPropHL2), 0.5, 0.05)
+	if mama == nil || fama == nil {
+		t.Fatal("Expected to be non nil but got nil")
+	}
+	if mama.Val() != nil || fama.Val() != nil {
+		t.Error("Expected to be nil but got non nil")
+	}
+}
+
+// TestSeriesMAMAReference tests MAMA(hl2, 0.5, 0.05) against TA-Lib's MAMA on referenceTestData
+func TestSeriesMAMAReference(t *testing.T) {
+	assertReference(t, "mama", 32, []float64{
+		98.5645, 98.6081, 98.3090, 98.2853, 98.1341, 97.9864, 96.2307, 95.9603,
+		95.8298, 95.6938, 95.5056, 95.2729, 94.9602, 91.7851, 89.7024, 89.4026,
+		89.3164, 89.1839, 86.9619, 86.9529, 86.8758, 86.8300, 85.9699, 84.8000,
+		84.7802, 86.1526, 87.4413, 87.6523,
+	}, func(o OHLCVSeries) ValueSeries {
+		mama, _ := MAMA(OHLCVAttr(o, OHLCPropHL2), 0.5, 0.05)
+		return mama
+	})
+
+	assertReference(t, "fama", 32, []float64{
+		92.6796, 92.8278, 94.1981, 94.3003, 94.3962, 94.4859, 94.9221, 95.1817,
+		95.1979, 95.2103, 95.2177, 95.2190, 95.2107, 94.3543, 93.4182, 93.2734,
+		93.1745, 93.0747, 91.5465, 91.3955, 91.2825, 91.1712, 90.3723, 88.9792,
+		88.8743, 88.1939, 88.0057, 87.9794,
+	}, func(o OHLCVSeries) ValueSeries {
+		_, fama := MAMA(OHLCVAttr(o, OHLCPropHL2), 0.5, 0.05)
+		return fama
+	})
+}
+
+func TestSeriesMAMASourceTrimmed(t *testing.T) {
+	assertSourceTrimmed(t, "mama", 3, func(p ValueSeries) ValueSeries {
+		mama, _ := MAMA(p, 0.5, 0.05)
+		return mama
+	})
+}
+
+// TestSeriesMAMALastTrimmed tests that MAMA resumes when the last value it processed is trimmed from the source
+func TestSeriesMAMALastTrimmed(t *testing.T) {
+	data := referenceTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 40; i++ {
+		series.Next()
+		MAMA(OHLCVAttr(series, OHLCPropHL2), 0.5, 0.05)
+	}
+	for i := 0; i < 5; i++ {
+		series.Next()
+	}
+	prop := OHLCVAttr(series, OHLCPropHL2)
+	prop.SetMax(3)
+
+	mama, fama := MAMA(prop, 0.5, 0.05)
+	if mama.Val() == nil || fama.Val() == nil {
+		t.Fatal("Expected to be non nil but got nil")
+	}
+	if !mama.GetLast().t.Equal(data[44].S) {
+		t.Errorf("Expected the last value to be at %+v but got %+v", data[44].S, mama.GetLast().t)
+	}
+}
+
+func TestMemoryLeakMAMA(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		MAMA(OHLCVAttr(o, OHLCPropHL2), 0.5, 0.05)
+		return nil
+	})
+}
+
+func ExampleMAMA() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		mama, fama := MAMA(OHLCVAttr(series, OHLCPropHL2), 0.5, 0.05)
+		log.Printf("mama: %+v, fama: %+v", mama.Val(), fama.Val())
+	}
+}
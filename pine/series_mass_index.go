@@ -0,0 +1,42 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// MassIndex generates a ValueSeries of Mass Index, which identifies trend reversals from the widening of the range between high and low.
+//
+// The formula for MassIndex is
+//   - span = high - low
+//   - mass = sum(ema(span, emal) / ema(ema(span, emal), emal), l)
+//
+// The ratio has no value while the double smoothed span is 0, i.e. high equals low from the start.
+//
+// The arguments are:
+//   - o: OHLCVSeries - source of data
+//   - emal: int64 - lookback periods of the EMAs, i.e. 9
+//   - l: int64 - lookback periods of the summation, i.e. 10 or 25
+func MassIndex(o OHLCVSeries, emal, l int64) ValueSeries {
+	key := fmt.Sprintf("massindex:%s:%d:%d", o.ID(), emal, l)
+	mass := getCache(key)
+	if mass == nil {
+		mass = NewValueSeries()
+	}
+
+	stop := o.Current()
+	if stop == nil {
+		return mass
+	}
+
+	span := Sub(OHLCVAttr(o, OHLCPropHigh), OHLCVAttr(o, OHLCPropLow))
+	e1 := EMA(span, emal)
+	e2 := EMA(e1, emal)
+
+	mass = Sum(divNonZero(e1, e2), int(l))
+
+	setCache(key, mass)
+
+	mass.SetCurrent(stop.S)
+
+	return mass
+}
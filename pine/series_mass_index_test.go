@@ -0,0 +1,46 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesMassIndexReference tests MassIndex(9, 25) against the formula composed of TA-Lib's EMA and SUM on referenceTestData
+func TestSeriesMassIndexReference(t *testing.T) {
+	assertReference(t, "massindex", 40, []float64{
+		24.9398, 24.7852, 24.7511, 24.6964, 24.7096, 24.7812, 24.8023, 24.8991,
+		24.9058, 24.9317, 24.9513, 25.0480, 25.1770, 25.2539, 25.2035, 25.2728,
+		25.4025, 25.5626, 25.6625, 25.7170,
+	}, func(o OHLCVSeries) ValueSeries {
+		return MassIndex(o, 9, 25)
+	})
+}
+
+// TestSeriesMassIndexFlat tests that OHLCVs without a range have no value instead of dividing by 0
+func TestSeriesMassIndexFlat(t *testing.T) {
+	assertFlatOHLCV(t, "massindex", 0, nil, func(o OHLCVSeries) ValueSeries {
+		return MassIndex(o, 3, 2)
+	})
+}
+
+func TestMemoryLeakMassIndex(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		MassIndex(o, 3, 3)
+		return nil
+	})
+}
+
+func ExampleMassIndex() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		mass := MassIndex(series, 3, 3)
+		log.Printf("MassIndex: %+v", mass.Val())
+	}
+}
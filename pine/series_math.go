@@ -0,0 +1,185 @@
+package pine
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// Abs generates a ValueSeries of the absolute values of p
+func Abs(p ValueSeries) ValueSeries {
+	return operationConst(p, "abs", math.Abs, true)
+}
+
+// Sign generates a ValueSeries of the sign of p, which is 1 if positive, -1 if negative and 0 if zero
+func Sign(p ValueSeries) ValueSeries {
+	return operationConst(p, "sign", func(v float64) float64 {
+		switch {
+		case v > 0:
+			return 1
+		case v < 0:
+			return -1
+		}
+		return 0
+	}, true)
+}
+
+// Log generates a ValueSeries of the natural logarithm of p.
+// The value is NaN where p is negative and -Inf where p is zero.
+func Log(p ValueSeries) ValueSeries {
+	return operationConst(p, "log", math.Log, true)
+}
+
+// Log10 generates a ValueSeries of the base 10 logarithm of p.
+// The value is NaN where p is negative and -Inf where p is zero.
+func Log10(p ValueSeries) ValueSeries {
+	return operationConst(p, "log10", math.Log10, true)
+}
+
+// Exp generates a ValueSeries of e raised to the power of p
+func Exp(p ValueSeries) ValueSeries {
+	return operationConst(p, "exp", math.Exp, true)
+}
+
+// Sqrt generates a ValueSeries of the square root of p.
+// The value is NaN where p is negative.
+func Sqrt(p ValueSeries) ValueSeries {
+	return operationConst(p, "sqrt", math.Sqrt, true)
+}
+
+// Round generates a ValueSeries of p rounded to the precision decimal places with ties rounding up as in PineScript's math.round.
+// Ties are taken from the decimal value so 1.005 rounds to 1.01 even though its float64 is slightly less.
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - precision - int: number of decimal places. 0 rounds to the nearest integer
+func Round(p ValueSeries, precision int) ValueSeries {
+	unit := new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(precision))), nil))
+	if precision < 0 {
+		unit.Inv(unit)
+	}
+	return operationConst(p, fmt.Sprintf("round:%d", precision), func(v float64) float64 {
+		return roundHalfUp(v, unit)
+	}, true)
+}
+
+// RoundToTick generates a ValueSeries of p rounded to the nearest multiple of tick with ties rounding up as in PineScript's math.round_to_mintick.
+// It panics if tick is not positive since it is a programming error.
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - tick - float64: minimum price movement, i.e. 0.25
+func RoundToTick(p ValueSeries, tick float64) ValueSeries {
+	if !(tick > 0) || math.IsInf(tick, 0) {
+		panic(fmt.Sprintf("pine: RoundToTick tick must be positive, got %v", tick))
+	}
+	unit := decimalRat(tick)
+	return operationConst(p, fmt.Sprintf("roundtotick:%+v", tick), func(v float64) float64 {
+		return roundHalfUp(v, unit)
+	}, true)
+}
+
+// roundHalfUp rounds v to the nearest multiple of unit with ties rounding up.
+// v is taken as its shortest decimal representation so that decimal ties are not lost to binary error.
+func roundHalfUp(v float64, unit *big.Rat) float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return v
+	}
+	r := decimalRat(v)
+	r.Quo(r, unit)
+	r.Add(r, big.NewRat(1, 2))
+	// Euclidean division floors since the denominator is positive
+	n := new(big.Int).Div(r.Num(), r.Denom())
+	f, _ := r.SetInt(n).Mul(r, unit).Float64()
+	return f
+}
+
+// decimalRat returns the exact value of the shortest decimal representation of v
+func decimalRat(v float64) *big.Rat {
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(v, 'g', -1, 64))
+	return r
+}
+
+// Floor generates a ValueSeries of the greatest integers less than or equal to p
+func Floor(p ValueSeries) ValueSeries {
+	return operationConst(p, "floor", math.Floor, true)
+}
+
+// Ceil generates a ValueSeries of the least integers greater than or equal to p
+func Ceil(p ValueSeries) ValueSeries {
+	return operationConst(p, "ceil", func(v float64) float64 {
+		// avoid negative zero
+		return math.Ceil(v) + 0
+	}, true)
+}
+
+// Max generates a ValueSeries of the greater value of a and b.
+// There is no value where either of them does not have a value.
+func Max(a, b ValueSeries) ValueSeries {
+	return Operate(a, b, "max", math.Max)
+}
+
+// Min generates a ValueSeries of the smaller value of a and b.
+// There is no value where either of them does not have a value.
+func Min(a, b ValueSeries) ValueSeries {
+	return Operate(a, b, "min", math.Min)
+}
+
+// Clamp generates a ValueSeries of p limited to the range of [lo, hi]
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - lo - float64: lower limit
+//   - hi - float64: upper limit
+func Clamp(p ValueSeries, lo, hi float64) ValueSeries {
+	return operationConst(p, fmt.Sprintf("clamp:%+v:%+v", lo, hi), func(v float64) float64 {
+		return math.Min(math.Max(v, lo), hi)
+	}, true)
+}
+
+// Sin generates a ValueSeries of the sine of p in radians
+func Sin(p ValueSeries) ValueSeries {
+	return operationConst(p, "sin", math.Sin, true)
+}
+
+// Cos generates a ValueSeries of the cosine of p in radians
+func Cos(p ValueSeries) ValueSeries {
+	return operationConst(p, "cos", math.Cos, true)
+}
+
+// Tan generates a ValueSeries of the tangent of p in radians
+func Tan(p ValueSeries) ValueSeries {
+	return operationConst(p, "tan", math.Tan, true)
+}
+
+// Asin generates a ValueSeries of the arcsine of p in radians.
+// The value is NaN where p is out of the range of [-1, 1].
+func Asin(p ValueSeries) ValueSeries {
+	return operationConst(p, "asin", math.Asin, true)
+}
+
+// Acos generates a ValueSeries of the arccosine of p in radians.
+// The value is NaN where p is out of the range of [-1, 1].
+func Acos(p ValueSeries) ValueSeries {
+	return operationConst(p, "acos", math.Acos, true)
+}
+
+// Atan generates a ValueSeries of the arctangent of p in radians
+func Atan(p ValueSeries) ValueSeries {
+	return operationConst(p, "atan", math.Atan, true)
+}
+
+// ToDegrees generates a ValueSeries of p converted from radians to degrees
+func ToDegrees(p ValueSeries) ValueSeries {
+	return operationConst(p, "todegrees", func(v float64) float64 {
+		return v * 180 / math.Pi
+	}, true)
+}
+
+// ToRadians generates a ValueSeries of p converted from degrees to radians
+func ToRadians(p ValueSeries) ValueSeries {
+	return operationConst(p, "toradians", func(v float64) float64 {
+		return v * math.Pi / 180
+	}, true)
+}
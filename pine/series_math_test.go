@@ -0,0 +1,302 @@
+package pine
+
+import (
+	"log"
+	"math"
+	"testing"
+	"time"
+)
+
+// TestSeriesMathNoData tests no data scenario
+func TestSeriesMathNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prop := OHLCVAttr(series, OHLCPropClose)
+	abs := Abs(prop)
+	if abs == nil {
+		t.Fatal("Expected to be non nil but got nil")
+	}
+	if abs.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *abs.Val())
+	}
+}
+
+// TestSeriesMathIteration tests each function against the values of Go's math package where
+//
+//	c = close
+//	o = open
+//	x = (close - 15) / 5
+func TestSeriesMathIteration(t *testing.T) {
+	testTable := []struct {
+		name string
+		fn   func(c, o, x ValueSeries) ValueSeries
+		vals []float64
+	}{
+		{
+			name: "abs",
+			fn: func(c, o, x ValueSeries) ValueSeries {
+				return Abs(x)
+			},
+			vals: []float64{0.3, 0.74, 0.64, 0.62, 0.86, 0.16, 0.12, 0.8, 0.06, 0.94},
+		},
+		{
+			name: "sign",
+			fn: func(c, o, x ValueSeries) ValueSeries {
+				return Sign(x)
+			},
+			vals: []float64{1, 1, 1, -1, 1, -1, -1, -1, -1, -1},
+		},
+		{
+			name: "log",
+			fn: func(c, o, x ValueSeries) ValueSeries {
+				return Log(c)
+			},
+			vals: []float64{2.8034, 2.9285, 2.9014, 2.4765, 2.9601, 2.6532, 2.6672, 2.3979, 2.6878, 2.3321},
+		},
+		{
+			name: "log10",
+			fn: func(c, o, x ValueSeries) ValueSeries {
+				return Log10(c)
+			},
+			vals: []float64{1.2175, 1.2718, 1.2601, 1.0755, 1.2856, 1.1523, 1.1584, 1.0414, 1.1673, 1.0128},
+		},
+		{
+			name: "exp",
+			fn: func(c, o, x ValueSeries) ValueSeries {
+				return Exp(x)
+			},
+			vals: []float64{1.3499, 2.0959, 1.8965, 0.5379, 2.3632, 0.8521, 0.8869, 0.4493, 0.9418, 0.3906},
+		},
+		{
+			name: "sqrt",
+			fn: func(c, o, x ValueSeries) ValueSeries {
+				return Sqrt(c)
+			},
+			vals: []float64{4.062, 4.3243, 4.2661, 3.4496, 4.3932, 3.7683, 3.7947, 3.3166, 3.8341, 3.2094},
+		},
+		{
+			name: "round",
+			fn: func(c, o, x ValueSeries) ValueSeries {
+				return Round(x, 1)
+			},
+			vals: []float64{0.3, 0.7, 0.6, -0.6, 0.9, -0.2, -0.1, -0.8, -0.1, -0.9},
+		},
+		{
+			name: "roundtotick",
+			fn: func(c, o, x ValueSeries) ValueSeries {
+				return RoundToTick(c, 0.25)
+			},
+			vals: []float64{16.5, 18.75, 18.25, 12, 19.25, 14.25, 14.5, 11, 14.75, 10.25},
+		},
+		{
+			name: "floor",
+			fn: func(c, o, x ValueSeries) ValueSeries {
+				return Floor(x)
+			},
+			vals: []float64{0, 0, 0, -1, 0, -1, -1, -1, -1, -1},
+		},
+		{
+			name: "ceil",
+			fn: func(c, o, x ValueSeries) ValueSeries {
+				return Ceil(x)
+			},
+			vals: []float64{1, 1, 1, 0, 1, 0, 0, 0, 0, 0},
+		},
+		{
+			name: "max",
+			fn: func(c, o, x ValueSeries) ValueSeries {
+				return Max(c, o)
+			},
+			vals: []float64{16.5, 18.7, 18.2, 19.2, 19.3, 19.4, 19.1, 11, 18.8, 17.1},
+		},
+		{
+			name: "min",
+			fn: func(c, o, x ValueSeries) ValueSeries {
+				return Min(c, o)
+			},
+			vals: []float64{11.3, 12.9, 11, 11.9, 18.1, 14.2, 14.4, 10.6, 14.7, 10.3},
+		},
+		{
+			name: "clamp",
+			fn: func(c, o, x ValueSeries) ValueSeries {
+				return Clamp(x, -0.5, 0.5)
+			},
+			vals: []float64{0.3, 0.5, 0.5, -0.5, 0.5, -0.16, -0.12, -0.5, -0.06, -0.5},
+		},
+		{
+			name: "sin",
+			fn: func(c, o, x ValueSeries) ValueSeries {
+				return Sin(x)
+			},
+			vals: []float64{0.2955, 0.6743, 0.5972, -0.581, 0.7578, -0.1593, -0.1197, -0.7174, -0.06, -0.8076},
+		},
+		{
+			name: "cos",
+			fn: func(c, o, x ValueSeries) ValueSeries {
+				return Cos(x)
+			},
+			vals: []float64{0.9553, 0.7385, 0.8021, 0.8139, 0.6524, 0.9872, 0.9928, 0.6967, 0.9982, 0.5898},
+		},
+		{
+			name: "tan",
+			fn: func(c, o, x ValueSeries) ValueSeries {
+				return Tan(x)
+			},
+			vals: []float64{0.3093, 0.9131, 0.7445, -0.7139, 1.1616, -0.1614, -0.1206, -1.0296, -0.0601, -1.3692},
+		},
+		{
+			name: "asin",
+			fn: func(c, o, x ValueSeries) ValueSeries {
+				return Asin(x)
+			},
+			vals: []float64{0.3047, 0.8331, 0.6945, -0.6687, 1.0353, -0.1607, -0.1203, -0.9273, -0.06, -1.2226},
+		},
+		{
+			name: "acos",
+			fn: func(c, o, x ValueSeries) ValueSeries {
+				return Acos(x)
+			},
+			vals: []float64{1.2661, 0.7377, 0.8763, 2.2395, 0.5355, 1.7315, 1.6911, 2.4981, 1.6308, 2.7934},
+		},
+		{
+			name: "atan",
+			fn: func(c, o, x ValueSeries) ValueSeries {
+				return Atan(x)
+			},
+			vals: []float64{0.2915, 0.6371, 0.5693, -0.555, 0.7103, -0.1587, -0.1194, -0.6747, -0.0599, -0.7545},
+		},
+		{
+			name: "todegrees",
+			fn: func(c, o, x ValueSeries) ValueSeries {
+				return ToDegrees(x)
+			},
+			vals: []float64{17.1887, 42.3989, 36.6693, -35.5234, 49.2744, -9.1673, -6.8755, -45.8366, -3.4377, -53.858},
+		},
+		{
+			name: "toradians",
+			fn: func(c, o, x ValueSeries) ValueSeries {
+				return ToRadians(c)
+			},
+			vals: []float64{0.288, 0.3264, 0.3176, 0.2077, 0.3368, 0.2478, 0.2513, 0.192, 0.2566, 0.1798},
+		},
+	}
+
+	for _, v := range testTable {
+		data := OHLCVStaticTestData()
+		series, err := NewOHLCVSeries(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i, exp := range v.vals {
+			series.Next()
+			c := OHLCVAttr(series, OHLCPropClose)
+			o := OHLCVAttr(series, OHLCPropOpen)
+			x := DivConst(SubConst(c, 15), 5)
+			assertSeriesVal(t, v.name, i, NewFloat64(exp), v.fn(c, o, x))
+		}
+	}
+}
+
+// TestSeriesMathExpression tests that an expression tree of the functions is generated incrementally
+//
+// c                        | 16.5 | 18.7   | 18.2 | 11.9   |
+// sma(c, 2)                |      | 17.6   | 18.45| 15.05  |
+// sqrt(abs(c - sma(c, 2))) |      | 1.0488 | 0.5  | 1.7748 |
+func TestSeriesMathExpression(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		nil,
+		NewFloat64(1.0488),
+		NewFloat64(0.5),
+		NewFloat64(1.7748),
+	}
+
+	for i, v := range tests {
+		series.Next()
+		c := OHLCVAttr(series, OHLCPropClose)
+		expr := Sqrt(Abs(Sub(c, SMA(c, 2))))
+		assertSeriesVal(t, "expr", i, v, expr)
+	}
+}
+
+func TestSeriesRoundTie(t *testing.T) {
+	data := OHLCVTestData(time.Now(), 4, 5*60*1000)
+	for i, v := range []float64{1.005, -1.005, 2.675, 1.05} {
+		data[i].C = v
+	}
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		round float64
+		tick  float64
+	}{
+		{round: 1.01, tick: 1},
+		{round: -1, tick: -1},
+		{round: 2.68, tick: 2.7},
+		{round: 1.05, tick: 1.1},
+	}
+	for i, v := range tests {
+		series.Next()
+		c := OHLCVAttr(series, OHLCPropClose)
+		assertSeriesVal(t, "round", i, NewFloat64(v.round), Round(c, 2))
+		assertSeriesVal(t, "roundtotick", i, NewFloat64(v.tick), RoundToTick(c, 0.1))
+	}
+}
+
+func TestSeriesRoundToTickInvalid(t *testing.T) {
+	series, err := NewOHLCVSeries(OHLCVStaticTestData())
+	if err != nil {
+		t.Fatal(err)
+	}
+	series.Next()
+
+	for _, tick := range []float64{0, -0.25, math.NaN()} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Expected to panic for tick %v but did not", tick)
+				}
+			}()
+			RoundToTick(OHLCVAttr(series, OHLCPropClose), tick)
+		}()
+	}
+}
+
+func TestMemoryLeakMath(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		c := OHLCVAttr(o, OHLCPropClose)
+		Clamp(Log(Max(c, OHLCVAttr(o, OHLCPropOpen))), 0, 10)
+		return nil
+	})
+}
+
+func ExampleRoundToTick() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		c := OHLCVAttr(series, OHLCPropClose)
+		stop := RoundToTick(SubConst(c, 2), 0.25)
+		log.Printf("Stop: %+v", stop.Val())
+	}
+}
@@ -0,0 +1,40 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// Median generates a ValueSeries of the median of the last l values.
+// If l is even, the median is the average of the two middle values.
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - l - int: lookback periods [1, ∞)
+func Median(p ValueSeries, l int) ValueSeries {
+	key := fmt.Sprintf("median:%s:%d", p.ID(), l)
+	med := getCache(key)
+	if med == nil {
+		med = NewValueSeries()
+	}
+
+	if p == nil || p.GetCurrent() == nil {
+		return med
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+
+	med = generateSorted(*stop, p, med, key, l, func(w *sortedWindow) float64 {
+		n := len(w.sorted)
+		if n%2 == 1 {
+			return w.sorted[n/2]
+		}
+		return (w.sorted[n/2-1] + w.sorted[n/2]) / 2
+	})
+
+	setCache(key, med)
+
+	med.SetCurrent(stop.t)
+
+	return med
+}
@@ -0,0 +1,131 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesMedianNoData tests no data scenario
+func TestSeriesMedianNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prop := OHLCVAttr(series, OHLCPropClose)
+	median := Median(prop, 4)
+	if median == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if median.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *median.Val())
+	}
+}
+
+// TestSeriesMedianIteration tests Median(4) against the values worked out by hand from the sorted windows of closes
+//
+// t=4:  11.9 16.5 18.2 18.7 -> (16.5 + 18.2) / 2 = 17.35
+// t=5:  11.9 18.2 18.7 19.3 -> (18.2 + 18.7) / 2 = 18.45
+// t=6:  11.9 14.2 18.2 19.3 -> (14.2 + 18.2) / 2 = 16.2
+// t=7:  11.9 14.2 14.4 19.3 -> (14.2 + 14.4) / 2 = 14.3
+// t=8:  11.0 14.2 14.4 19.3 -> (14.2 + 14.4) / 2 = 14.3
+// t=9:  11.0 14.2 14.4 14.7 -> (14.2 + 14.4) / 2 = 14.3
+// t=10: 10.3 11.0 14.4 14.7 -> (11.0 + 14.4) / 2 = 12.7
+func TestSeriesMedianIteration(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		nil,
+		nil,
+		nil,
+		NewFloat64(17.3500),
+		NewFloat64(18.4500),
+		NewFloat64(16.2000),
+		NewFloat64(14.3000),
+		NewFloat64(14.3000),
+		NewFloat64(14.3000),
+		NewFloat64(12.7000),
+	}
+
+	for i, v := range tests {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropClose)
+		median := Median(prop, 4)
+		assertSeriesVal(t, "median", i, v, median)
+	}
+}
+
+// TestSeriesMedianNaN tests that a window with NaN is nil and does not corrupt the following windows
+//
+// t=time.Time         | 1  | 2  | 3   | 4  | 5  | 6  |
+// close / open        | 10 | 12 | NaN | 14 | 11 | 13 |
+// median(p, 3)        |    |    |     |    |    | 13 |
+func TestSeriesMedianNaN(t *testing.T) {
+	start := time.Now()
+	data := OHLCVTestData(start, 6, 5*60*1000)
+	for i, v := range []float64{10, 12, 0, 14, 11, 13} {
+		data[i].O = 1
+		data[i].C = v
+	}
+	data[2].O = 0
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{nil, nil, nil, nil, nil, NewFloat64(13)}
+
+	for i, v := range tests {
+		series.Next()
+		p := Div(OHLCVAttr(series, OHLCPropClose), OHLCVAttr(series, OHLCPropOpen))
+		median := Median(p, 3)
+		assertSeriesVal(t, "median", i, v, median)
+	}
+}
+
+// TestSeriesMedianFlat tests that a flat window returns the flat value
+func TestSeriesMedianFlat(t *testing.T) {
+	assertFlat(t, "median", 3, func(p ValueSeries) ValueSeries {
+		return Median(p, 4)
+	})
+}
+
+// TestSeriesMedianSourceTrimmed tests that trimming the source to 4 values does not change the output
+func TestSeriesMedianSourceTrimmed(t *testing.T) {
+	assertSourceTrimmed(t, "median", 4, func(p ValueSeries) ValueSeries {
+		return Median(p, 4)
+	})
+}
+
+func TestMemoryLeakMedian(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		Median(prop, 4)
+		return nil
+	})
+}
+
+func ExampleMedian() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		median := Median(prop, 4)
+		log.Printf("Median: %+v", median.Val())
+	}
+}
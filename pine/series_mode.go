@@ -0,0 +1,50 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// Mode generates a ValueSeries of the most frequently occurring value of the last l values.
+// If multiple values have the same frequency, the smallest value is used.
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - l - int: lookback periods [1, ∞)
+func Mode(p ValueSeries, l int) ValueSeries {
+	key := fmt.Sprintf("mode:%s:%d", p.ID(), l)
+	mode := getCache(key)
+	if mode == nil {
+		mode = NewValueSeries()
+	}
+
+	if p == nil || p.GetCurrent() == nil {
+		return mode
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+
+	mode = generateSorted(*stop, p, mode, key, l, func(w *sortedWindow) float64 {
+		// equal values are next to each other in the sorted window
+		best := w.sorted[0]
+		bestct := 0
+		for i := 0; i < len(w.sorted); {
+			j := i
+			for j < len(w.sorted) && w.sorted[j] == w.sorted[i] {
+				j++
+			}
+			if j-i > bestct {
+				best = w.sorted[i]
+				bestct = j - i
+			}
+			i = j
+		}
+		return best
+	})
+
+	setCache(key, mode)
+
+	mode.SetCurrent(stop.t)
+
+	return mode
+}
@@ -0,0 +1,93 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesModeNoData tests no data scenario
+func TestSeriesModeNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prop := OHLCVAttr(series, OHLCPropClose)
+	mode := Mode(prop, 4)
+	if mode == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if mode.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *mode.Val())
+	}
+}
+
+// TestSeriesModeIteration tests Mode(4) against the values worked out by hand. The smallest value wins a tie
+//
+// p=ValueSeries   | 1 | 2 | 2 | 3 | 3 | 1 | 5 | 5 | 6 | 6 |
+// mode(p, 4)      |   |   |   | 2 | 2 | 3 | 3 | 5 | 5 | 5 |
+func TestSeriesModeIteration(t *testing.T) {
+	data := OHLCVStaticTestData()
+	for i, v := range []float64{1, 2, 2, 3, 3, 1, 5, 5, 6, 6} {
+		data[i].C = v
+	}
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		nil,
+		nil,
+		nil,
+		NewFloat64(2.0000),
+		NewFloat64(2.0000),
+		NewFloat64(3.0000),
+		NewFloat64(3.0000),
+		NewFloat64(5.0000),
+		NewFloat64(5.0000),
+		NewFloat64(5.0000),
+	}
+
+	for i, v := range tests {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropClose)
+		mode := Mode(prop, 4)
+		assertSeriesVal(t, "mode", i, v, mode)
+	}
+}
+
+// TestSeriesModeFlat tests that a flat window returns the flat value
+func TestSeriesModeFlat(t *testing.T) {
+	assertFlat(t, "mode", 3, func(p ValueSeries) ValueSeries {
+		return Mode(p, 4)
+	})
+}
+
+func TestMemoryLeakMode(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		Mode(prop, 4)
+		return nil
+	})
+}
+
+func ExampleMode() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		mode := Mode(prop, 4)
+		log.Printf("Mode: %+v", mode.Val())
+	}
+}
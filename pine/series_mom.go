@@ -0,0 +1,35 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// MOM generates a ValueSeries of momentum, which is the difference between the current value and the value l bars ago.
+//
+// The formula for MOM is
+//   - mom = p - p[l]
+//
+// arguments are
+//   - p: ValueSeries - source data
+//   - l: int - number of bars to lookback. 1 is the previous bar
+func MOM(p ValueSeries, l int) ValueSeries {
+	key := fmt.Sprintf("mom:%s:%d", p.ID(), l)
+	mom := getCache(key)
+	if mom == nil {
+		mom = NewValueSeries()
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+	if stop == nil {
+		return mom
+	}
+
+	mom = Change(p, l)
+
+	setCache(key, mom)
+
+	mom.SetCurrent(stop.t)
+
+	return mom
+}
@@ -0,0 +1,75 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesMOMNoData tests no data scenario
+func TestSeriesMOMNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prop := OHLCVAttr(series, OHLCPropClose)
+	mom := MOM(prop, 2)
+	if mom == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if mom.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *mom.Val())
+	}
+}
+
+// TestSeriesMOMReference tests MOM(10) against TA-Lib's MOM on referenceTestData
+func TestSeriesMOMReference(t *testing.T) {
+	exp := []float64{
+		-4.4200, 0.1600, 2.7200, 3.6100, 2.3800, 1.6300, -2.7200, 1.4200,
+		0.9300, -1.4000, 0.3000, -3.6100, -5.6100, -1.3900, 0.8200, 1.9000,
+		4.7100, 2.0100, 2.2100, -0.1900, -0.2200, 1.8400, 3.1100, 1.3700,
+		-2.4500, -2.3500, -7.4200, -6.5900, -4.6300, -1.8400, -6.3300, -5.4900,
+		-7.6200, -7.7600, -7.2400, -11.2400, -9.0800, -8.1700, -5.3900, -8.6700,
+		-7.0800, -4.3700, -6.5100, -5.0100, -5.8700, -4.3200, 0.4700, 2.6400,
+		-0.8900, 1.6100,
+	}
+
+	assertReference(t, "mom", 10, exp, func(o OHLCVSeries) ValueSeries {
+		return MOM(OHLCVAttr(o, OHLCPropClose), 10)
+	})
+}
+
+// TestSeriesMOMSourceTrimmed tests that trimming the source to 11 values does not change the output
+func TestSeriesMOMSourceTrimmed(t *testing.T) {
+	assertSourceTrimmed(t, "mom", 11, func(p ValueSeries) ValueSeries {
+		return MOM(p, 10)
+	})
+}
+
+func TestMemoryLeakMOM(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		MOM(prop, 2)
+		return nil
+	})
+}
+
+func ExampleMOM() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		mom := MOM(prop, 2)
+		log.Printf("MOM: %+v", mom.Val())
+	}
+}
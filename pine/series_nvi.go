@@ -0,0 +1,53 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// NVI generates a ValueSeries of negative volume index.
+// The index only changes by the rate of change of close when the volume decreases from the previous bar.
+// It starts from 1 and survives trimming of the series since each value only depends on the previous value.
+//
+// The formula for NVI is
+//   - nvi = nvi[1] + change(close) / close[1] * nvi[1] if volume < volume[1], otherwise nvi[1]
+//
+// The arguments are:
+//   - o: OHLCVSeries - source of data
+func NVI(o OHLCVSeries) ValueSeries {
+	return getVolumeIndex(o, "nvi", func(v, prevv float64) bool {
+		return v < prevv
+	})
+}
+
+func getVolumeIndex(o OHLCVSeries, ns string, cond func(v, prevv float64) bool) ValueSeries {
+	key := fmt.Sprintf("%s:%s", ns, o.ID())
+	vi := getCache(key)
+	if vi == nil {
+		vi = NewValueSeries()
+	}
+
+	stop := o.Current()
+	if stop == nil {
+		return vi
+	}
+
+	vi = generateOHLCVRecursive(o, vi, func(cur *OHLCV, prev *float64) *float64 {
+		previ := 1.0
+		if prev != nil && *prev != 0 {
+			previ = *prev
+		}
+		if cur.prev == nil || cur.C == 0 || cur.prev.C == 0 {
+			return NewFloat64(previ)
+		}
+		if cond(cur.V, cur.prev.V) {
+			return NewFloat64(previ + (cur.C-cur.prev.C)/cur.prev.C*previ)
+		}
+		return NewFloat64(previ)
+	})
+
+	setCache(key, vi)
+
+	vi.SetCurrent(stop.S)
+
+	return vi
+}
@@ -0,0 +1,95 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesNVINoData tests no data scenario
+func TestSeriesNVINoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nvi := NVI(series)
+	if nvi == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if nvi.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *nvi.Val())
+	}
+}
+
+// TestSeriesNVIReference tests NVI against the running product of 1 + rocp with TA-Lib's ROCP for the bars whose volume decreased on referenceTestData
+func TestSeriesNVIReference(t *testing.T) {
+	exp := []float64{
+		1.0000, 0.9657, 0.9657, 0.9331, 0.9331, 0.9373, 0.9373, 0.9373,
+		0.9212, 0.9212, 0.9275, 0.9275, 0.9275, 0.9052, 0.9045, 0.9045,
+		0.8908, 0.9096, 0.9096, 0.9068, 0.9291, 0.9022, 0.8986, 0.8986,
+		0.8986, 0.8986, 0.9104, 0.9104, 0.8928, 0.8928, 0.9145, 0.9145,
+		0.9228, 0.9228, 0.9070, 0.9070, 0.9070, 0.9087, 0.9087, 0.9096,
+		0.8889, 0.8893, 0.8893, 0.8883, 0.8883, 0.8573, 0.8573, 0.8573,
+		0.8849, 0.8532, 0.8532, 0.8532, 0.8205, 0.8340, 0.8144, 0.8144,
+		0.8460, 0.8782, 0.8708, 0.8708,
+	}
+
+	assertReference(t, "nvi", 0, exp, func(o OHLCVSeries) ValueSeries {
+		return NVI(o)
+	})
+}
+
+// TestSeriesNVISetMax tests that the running total is kept after the series is trimmed
+func TestSeriesNVISetMax(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		NewFloat64(1.0000),
+		NewFloat64(1.0000),
+		NewFloat64(1.0000),
+		NewFloat64(1.0000),
+		NewFloat64(1.0000),
+		NewFloat64(1.0000),
+		NewFloat64(1.0141),
+		NewFloat64(0.7746),
+		NewFloat64(0.7746),
+		NewFloat64(0.5428),
+	}
+
+	for i, v := range tests {
+		series.Next()
+		nvi := NVI(series)
+		nvi.SetMax(1)
+		assertSeriesVal(t, "nvi", i, v, nvi)
+	}
+}
+
+func TestMemoryLeakNVI(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		NVI(o)
+		return nil
+	})
+}
+
+func ExampleNVI() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		nvi := NVI(series)
+		log.Printf("NVI: %+v", nvi.Val())
+	}
+}
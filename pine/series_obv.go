@@ -0,0 +1,48 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// OBV generates a ValueSeries of on-balance volume.
+// It is a running total that survives trimming of the series since each value only depends on the previous value.
+//
+// The formula for OBV is
+//   - obv = cum(sign(change(close)) * volume)
+//
+// The first value is 0 since there is no previous close.
+//
+// The arguments are:
+//   - o: OHLCVSeries - source of data
+func OBV(o OHLCVSeries) ValueSeries {
+	key := fmt.Sprintf("obv:%s", o.ID())
+	obv := getCache(key)
+	if obv == nil {
+		obv = NewValueSeries()
+	}
+
+	stop := o.Current()
+	if stop == nil {
+		return obv
+	}
+
+	obv = generateOHLCVRecursive(o, obv, func(cur *OHLCV, prev *float64) *float64 {
+		if cur.prev == nil || prev == nil {
+			return NewFloat64(0)
+		}
+		chg := cur.C - cur.prev.C
+		var sign float64
+		if chg > 0 {
+			sign = 1
+		} else if chg < 0 {
+			sign = -1
+		}
+		return NewFloat64(*prev + sign*cur.V)
+	})
+
+	setCache(key, obv)
+
+	obv.SetCurrent(stop.S)
+
+	return obv
+}
@@ -0,0 +1,132 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesOBVNoData tests no data scenario
+func TestSeriesOBVNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	obv := OBV(series)
+	if obv == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if obv.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *obv.Val())
+	}
+}
+
+// TestSeriesOBVReference tests OBV against TA-Lib's OBV on referenceTestData.
+// TA-Lib starts the running total with the first volume while OBV starts with 0, so the first volume is subtracted from TA-Lib's values
+func TestSeriesOBVReference(t *testing.T) {
+	exp := []float64{
+		0.0000, -1153.0000, -2725.0000, -3548.0000, -2060.0000, -1260.0000, -140.0000, -1533.0000,
+		-2752.0000, -1097.0000, 83.0000, 2018.0000, 3955.0000, 2304.0000, 1201.0000, -755.0000,
+		-2068.0000, -842.0000, -2840.0000, -4632.0000, -3279.0000, -4293.0000, -5184.0000, -3864.0000,
+		-1973.0000, -27.0000, 1325.0000, -565.0000, -1601.0000, -2686.0000, -1738.0000, -3173.0000,
+		-2324.0000, -694.0000, -1859.0000, -356.0000, -2007.0000, -790.0000, -790.0000, 855.0000,
+		-230.0000, 825.0000, -505.0000, -1607.0000, -3153.0000, -4378.0000, -5967.0000, -4068.0000,
+		-2774.0000, -3788.0000, -4835.0000, -2978.0000, -4754.0000, -3081.0000, -4340.0000, -6137.0000,
+		-4476.0000, -3256.0000, -4138.0000, -5373.0000,
+	}
+
+	assertReference(t, "obv", 0, exp, func(o OHLCVSeries) ValueSeries {
+		return OBV(o)
+	})
+}
+
+// TestSeriesOBVSetMax tests that the running total is kept after the series is trimmed
+func TestSeriesOBVSetMax(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		NewFloat64(0.0000),
+		NewFloat64(13.0000),
+		NewFloat64(-0.8000),
+		NewFloat64(-16.7000),
+		NewFloat64(0.1000),
+		NewFloat64(-19.0000),
+		NewFloat64(-4.3000),
+		NewFloat64(-16.0000),
+		NewFloat64(1.4000),
+		NewFloat64(-13.6000),
+	}
+
+	for i, v := range tests {
+		series.Next()
+		obv := OBV(series)
+		obv.SetMax(1)
+		assertSeriesVal(t, "obv", i, v, obv)
+	}
+}
+
+// TestSeriesOBVSourceTrimmed tests that OBV resumes from the first remaining OHLCV
+// when the last OHLCV it was generated for is trimmed from the source between calls
+func TestSeriesOBVSourceTrimmed(t *testing.T) {
+	data := OHLCVStaticTestData()
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		series.Next()
+		OBV(series)
+	}
+
+	// skip the 4th to 7th OHLCV and trim the first 4
+	for i := 3; i < 7; i++ {
+		series.Next()
+	}
+	series.SetMax(int64(len(data) - 4))
+
+	// the remaining OHLCVs are expected to be generated as if the series started at the 5th OHLCV
+	fresh, err := NewOHLCVSeries(data[4:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	fresh.Next()
+	fresh.Next()
+
+	for i := 6; i < len(data); i++ {
+		fresh.Next()
+		obv := OBV(series)
+		assertSeriesVal(t, "obv", i, OBV(fresh).Val(), obv)
+		series.Next()
+	}
+}
+
+func TestMemoryLeakOBV(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		OBV(o)
+		return nil
+	})
+}
+
+func ExampleOBV() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		obv := OBV(series)
+		log.Printf("OBV: %+v", obv.Val())
+	}
+}
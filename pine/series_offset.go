@@ -0,0 +1,132 @@
+package pine
+
+import (
+	"fmt"
+	"time"
+)
+
+// Offset generates a ValueSeries of p shifted by n bars, which is similar to the offset parameter of plot in PineScript.
+//
+// A positive n displaces the values into the future. The value at each bar is the value of p n bars before it,
+// so gaps or irregular intervals between bars do not affect it. Since the bars beyond the current one do not exist yet,
+// the last n values are set at times projected from the interval between the current value and its previous value,
+// i.e. t + k * interval. Projected values are replaced by the ones at the actual bars as they become available.
+//
+// A negative n displaces the values into the past onto the time of the value n bars ago.
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - n - int: number of bars to displace. Positive is future and negative is past
+func Offset(p ValueSeries, n int) ValueSeries {
+	key := fmt.Sprintf("offset:%s:%d", p.ID(), n)
+	offset := getCache(key)
+	if offset == nil {
+		offset = NewValueSeries()
+	}
+
+	if p == nil || p.GetCurrent() == nil {
+		return offset
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+
+	s := offsetCache[key]
+
+	var f *Value
+	if s == nil {
+		s = &offsetState{}
+		f = p.GetFirst()
+	} else {
+		if n > 0 {
+			dropProjected(offset, s.last)
+		}
+		f = valueAfter(p, s.last)
+	}
+	for {
+		if f == nil {
+			break
+		}
+
+		if v := offsetPrev(f, n); v != nil {
+			if n > 0 {
+				offset.Set(f.t, v.v)
+			} else {
+				offset.Set(v.t, f.v)
+			}
+		}
+		s.last = f.t
+
+		if f.t.Equal(stop.t) {
+			break
+		}
+		f = f.next
+	}
+
+	if n > 0 {
+		projectOffset(offset, stop, n)
+	}
+
+	setCache(key, offset)
+	setState(offsetCache, key, s)
+
+	offset.SetCurrent(stop.t)
+
+	return offset
+}
+
+// offsetState is the state of Offset carried over to the next call
+type offsetState struct {
+	// time of the last displaced source value
+	last time.Time
+}
+
+var offsetCache map[string]*offsetState = make(map[string]*offsetState)
+
+// offsetPrev returns the value |n| values before v or nil if it does not exist
+func offsetPrev(v *Value, n int) *Value {
+	if n < 0 {
+		n = -n
+	}
+	for i := 0; i < n; i++ {
+		v = v.prev
+		if v == nil {
+			return nil
+		}
+	}
+	return v
+}
+
+// projectOffset sets the last n values up to stop at the projected times of the n bars after stop
+func projectOffset(offset ValueSeries, stop *Value, n int) {
+	if stop.prev == nil {
+		return
+	}
+	interval := stop.t.Sub(stop.prev.t)
+
+	// src is displaced onto k bars after stop
+	src, k := stop, n
+	for k > 1 && src.prev != nil {
+		src = src.prev
+		k--
+	}
+	for {
+		offset.Set(stop.t.Add(time.Duration(k)*interval), src.v)
+		if src.t.Equal(stop.t) {
+			break
+		}
+		src = src.next
+		k++
+	}
+}
+
+// dropProjected removes the values projected after the last displaced source value
+func dropProjected(offset ValueSeries, last time.Time) {
+	vs, ok := offset.(*valueSeries)
+	if !ok {
+		return
+	}
+	for vs.last != nil && vs.last.t.After(last) {
+		vs.pop()
+	}
+}
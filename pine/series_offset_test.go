@@ -0,0 +1,183 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesOffsetNoData tests no data scenario
+func TestSeriesOffsetNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prop := OHLCVAttr(series, OHLCPropClose)
+	offset := Offset(prop, 2)
+	if offset == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if offset.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *offset.Val())
+	}
+}
+
+// TestSeriesOffsetFuture tests displacement into the future
+//
+// t=time.Time     | 1  |  2  | 3  | 4  | 5 | 6 |
+// p=ValueSeries   | 13 |  15 | 17 | 18 |   |   |
+// offset(p, 2)    |    |     | 13 | 15 | 17| 18|
+func TestSeriesOffsetFuture(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+	data[0].C = 13
+	data[1].C = 15
+	data[2].C = 17
+	data[3].C = 18
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{nil, nil, NewFloat64(13), NewFloat64(15)}
+
+	var offset ValueSeries
+	for i, v := range tests {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropClose)
+		offset = Offset(prop, 2)
+		assertSeriesVal(t, "offset", i, v, offset)
+	}
+
+	for i, v := range []float64{17, 18} {
+		ft := data[3].S.Add(time.Duration(i+1) * 5 * time.Minute)
+		if f := offset.Get(ft); f == nil || f.v != v {
+			t.Errorf("Expected projected value to be %+v but got %+v at %+v", v, f, ft)
+		}
+	}
+}
+
+// TestSeriesOffsetGaps tests that the displacement into the future follows the bars when the interval is irregular
+//
+// t=minutes       | 0  |  5  | 10 | 60 | 65 |
+// p=ValueSeries   | 13 |  15 | 17 | 18 | 20 |
+// offset(p, 2)    |    |     | 13 | 15 | 17 |
+//
+// The values projected at 15 and 20 minutes after the 3rd bar are replaced by the ones at the 4th and 5th bars
+func TestSeriesOffsetGaps(t *testing.T) {
+
+	start := time.Now().Truncate(time.Hour)
+	closes := []float64{13, 15, 17, 18, 20}
+	mins := []int{0, 5, 10, 60, 65}
+	data := OHLCVTestData(start, 5, 5*60*1000)
+	for i := range data {
+		data[i].S = start.Add(time.Duration(mins[i]) * time.Minute)
+		data[i].C = closes[i]
+	}
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{nil, nil, NewFloat64(13), NewFloat64(15), NewFloat64(17)}
+
+	var offset ValueSeries
+	for i, v := range tests {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropClose)
+		offset = Offset(prop, 2)
+		assertSeriesVal(t, "offset", i, v, offset)
+	}
+
+	for _, m := range []int{15, 20} {
+		if f := offset.Get(start.Add(time.Duration(m) * time.Minute)); f != nil {
+			t.Errorf("Expected the projected value at %d minutes to be dropped but got %+v", m, f.v)
+		}
+	}
+
+	exp := []struct {
+		m int
+		v float64
+	}{{10, 13}, {60, 15}, {65, 17}, {70, 18}, {75, 20}}
+	v := offset.GetFirst()
+	for i, e := range exp {
+		if v == nil {
+			t.Fatalf("Expected %d values but got %d", len(exp), i)
+		}
+		if !v.t.Equal(start.Add(time.Duration(e.m)*time.Minute)) || v.v != e.v {
+			t.Errorf("Expected %+v at %d minutes but got %+v at %+v", e.v, e.m, v.v, v.t)
+		}
+		v = v.next
+	}
+	if v != nil {
+		t.Errorf("Expected no more values but got %+v at %+v", v.v, v.t)
+	}
+}
+
+// TestSeriesOffsetPast tests displacement into the past
+//
+// t=time.Time     | 1  |  2  | 3  | 4  |
+// p=ValueSeries   | 13 |  15 | 17 | 18 |
+// offset(p, -2)   | 17 |  18 |    |    |
+func TestSeriesOffsetPast(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+	data[0].C = 13
+	data[1].C = 15
+	data[2].C = 17
+	data[3].C = 18
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var offset ValueSeries
+	for i := 0; i < 4; i++ {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropClose)
+		offset = Offset(prop, -2)
+		assertSeriesVal(t, "offset", i, nil, offset)
+	}
+
+	exp := []*float64{NewFloat64(17), NewFloat64(18), nil, nil}
+	for i, v := range exp {
+		f := offset.Get(data[i].S)
+		if (f == nil) != (v == nil) || (f != nil && f.v != *v) {
+			t.Errorf("Expected %+v but got %+v at %d", v, f, i)
+		}
+	}
+}
+
+func TestMemoryLeakOffset(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		Offset(prop, 26)
+		Offset(prop, -26)
+		return nil
+	})
+}
+
+func ExampleOffset() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		offset := Offset(prop, 26)
+		log.Printf("Close 26 bars ago: %+v", offset.Val())
+	}
+}
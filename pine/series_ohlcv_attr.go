@@ -32,6 +32,10 @@ func getOHLCVAttr(stop OHLCV, src OHLCVSeries, dest ValueSeries, p OHLCProp) Val
 
 	firstVal := dest.GetLast()
 	if firstVal != nil {
+		// already generated up to stop
+		if !firstVal.t.Before(stop.S) {
+			return dest
+		}
 		if v := src.Get(firstVal.t); v != nil && v.next != nil {
 			startt = v.next.S
 		}
@@ -76,6 +80,8 @@ func getOHLCVAttr(stop OHLCV, src OHLCVSeries, dest ValueSeries, p OHLCProp) Val
 				d := v.H - v.L
 				propVal = &d
 			}
+		case OHLCPropHL2:
+			propVal = NewFloat64((v.H + v.L) / 2)
 		case OHLCPropHLC3:
 			propVal = NewFloat64((v.H + v.L + v.C) / 3)
 		default:
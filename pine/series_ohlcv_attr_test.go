@@ -51,6 +51,25 @@ func TestNewOHLCVGetSeries(t *testing.T) {
 	}
 }
 
+// TestNewOHLCVGetHL2 tests that OHLCPropHL2 returns the midpoint of high and low
+func TestNewOHLCVGetHL2(t *testing.T) {
+	data := OHLCVStaticTestData()
+
+	s, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, v := range data {
+		s.Next()
+		exp := (v.H + v.L) / 2
+		val := OHLCVAttr(s, OHLCPropHL2).Val()
+		if val == nil || *val != exp {
+			t.Errorf("Expected %+v but got %+v for i: %d", exp, val, i)
+		}
+	}
+}
+
 func TestNewOHLCVGetTrueRange(t *testing.T) {
 	data := OHLCVStaticTestData()
 
@@ -96,6 +115,28 @@ func TestNewOHLCVGetTrueRange(t *testing.T) {
 	}
 }
 
+// TestNewOHLCVAttrRepeated tests that calling OHLCVAttr more than once at the same bar does not generate values ahead of the current bar
+func TestNewOHLCVAttrRepeated(t *testing.T) {
+	data := OHLCVStaticTestData()
+
+	s, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.Next()
+	s.Next()
+	OHLCVAttr(s, OHLCPropClose)
+	vals := OHLCVAttr(s, OHLCPropClose)
+
+	if vals.Len() != 2 {
+		t.Errorf("Expected 2 values but got %d", vals.Len())
+	}
+	if vals.Get(data[2].S) != nil {
+		t.Errorf("Expected no value ahead of the current bar but got %+v", vals.Get(data[2].S))
+	}
+}
+
 func TestMemoryLeakOHLCVAttr(t *testing.T) {
 	testMemoryLeak(t, func(o OHLCVSeries) error {
 		OHLCVAttr(o, OHLCPropTR)
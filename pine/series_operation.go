@@ -2,6 +2,7 @@ package pine
 
 import (
 	"fmt"
+	"time"
 )
 
 // Operate operates on two series. Enabling caching means it starts from where it was left off.
@@ -17,6 +18,21 @@ func OperateNoCache(a, b ValueSeries, ns string, op func(b, c float64) float64)
 // operation operates on a and b ValueSeries using op function. use ns as a unique cache identifier
 func operation(a, b ValueSeries, ns string, op func(a, b float64) float64, cache bool) ValueSeries {
 	key := fmt.Sprintf("operation:%s:%s:%s", a.ID(), b.ID(), ns)
+	return operationJoin(a, key, func(av *Value) *float64 {
+		newv := b.Get(av.t)
+		if newv == nil {
+			return nil
+		}
+		return NewFloat64(op(av.v, newv.v))
+	}, cache, false)
+}
+
+// operationJoin generates a ValueSeries of pick for every value of a from where it was left off. use key as the cache key
+//
+// pick returns nil if there is no value at the time, i.e. the joined series does not have a value.
+// If retry is true, the times without a value are tried again in the following calls until they are trimmed from a,
+// so that the value is filled in once the joined series has been generated at the time.
+func operationJoin(a ValueSeries, key string, pick func(av *Value) *float64, cache, retry bool) ValueSeries {
 	dest := getCache(key)
 	if dest == nil {
 		dest = NewValueSeries()
@@ -24,8 +40,16 @@ func operation(a, b ValueSeries, ns string, op func(a, b float64) float64, cache
 
 	firstaVal := operationGetStart(a, dest)
 
+	var gaps []time.Time
+	if retry {
+		gaps = retryJoinGaps(a, dest, firstaVal, joinGapCache[key], pick)
+	}
+
 	// nowhere to start
 	if firstaVal == nil {
+		if retry {
+			setState(joinGapCache, key, gaps)
+		}
 
 		// propagate current pointer if needed
 		propagateCurrent(a, dest)
@@ -39,15 +63,19 @@ func operation(a, b ValueSeries, ns string, op func(a, b float64) float64, cache
 			break
 		}
 
-		newv := b.Get(f.t)
-
-		if newv != nil {
-			dest.Set(f.t, op(f.v, newv.v))
+		if v := pick(f); v != nil {
+			dest.Set(f.t, *v)
+		} else if retry {
+			gaps = append(gaps, f.t)
 		}
 
 		f = f.next
 	}
 
+	if retry {
+		setState(joinGapCache, key, gaps)
+	}
+
 	propagateCurrent(a, dest)
 
 	if cache {
@@ -57,6 +85,35 @@ func operation(a, b ValueSeries, ns string, op func(a, b float64) float64, cache
 	return dest
 }
 
+// joinGapCache holds the times of a without a value in the order of time for operationJoin
+var joinGapCache map[string][]time.Time = make(map[string][]time.Time)
+
+// retryJoinGaps tries pick again at the times of gaps and inserts the values into dest.
+// It returns the times still without a value. The times trimmed from a and the times from start, which are generated again, are dropped.
+func retryJoinGaps(a, dest ValueSeries, start *Value, gaps []time.Time, pick func(av *Value) *float64) []time.Time {
+	vs, ok := dest.(*valueSeries)
+	if !ok {
+		return nil
+	}
+
+	kept := make([]time.Time, 0, len(gaps))
+	for _, t := range gaps {
+		if start != nil && !t.Before(start.t) {
+			continue
+		}
+		av := a.Get(t)
+		if av == nil {
+			continue
+		}
+		if v := pick(av); v != nil {
+			vs.insert(t, *v)
+			continue
+		}
+		kept = append(kept, t)
+	}
+	return kept
+}
+
 func propagateCurrent(a, dest ValueSeries) {
 	if cur := a.GetCurrent(); cur != nil {
 		dest.SetCurrent(cur.t)
@@ -69,9 +126,35 @@ func operationGetStart(a, dest ValueSeries) *Value {
 	if destlast == nil {
 		firstaVal = a.GetFirst()
 	} else if destlast != nil {
-		if v := a.Get(destlast.t); v != nil && v.next != nil {
-			firstaVal = v.next
-		}
+		firstaVal = valueAfter(a, destlast.t)
 	}
 	return firstaVal
 }
+
+// valueAfter returns the first value of a after t.
+// It scans a from the first value if t is no longer in a, e.g. a was trimmed by SetMax or t was skipped.
+func valueAfter(a ValueSeries, t time.Time) *Value {
+	if v := a.Get(t); v != nil {
+		return v.next
+	}
+	for v := a.GetFirst(); v != nil; v = v.next {
+		if v.t.After(t) {
+			return v
+		}
+	}
+	return nil
+}
+
+// ohlcvAfter returns the first OHLCV of o after t.
+// It scans o from the first OHLCV if t is no longer in o, e.g. o was trimmed by SetMax or t was skipped.
+func ohlcvAfter(o OHLCVSeries, t time.Time) *OHLCV {
+	if v := o.Get(t); v != nil {
+		return v.next
+	}
+	for v := o.GetFirst(); v != nil; v = v.next {
+		if v.S.After(t) {
+			return v
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package pine
+
+import (
+	"fmt"
+	"math"
+)
+
+// Parkinson generates a ValueSeries of Parkinson's volatility estimator, which uses the range of each OHLCV instead of the close to close return.
+// It assumes no drift and no opening jumps.
+//
+// The formula for Parkinson is
+//   - variance = sma(ln(high / low)^2, l) / (4 * ln(2))
+//   - parkinson = sqrt(variance * ppy)
+//
+// The arguments are:
+//   - o: OHLCVSeries - source of data
+//   - l: int64 - lookback periods [1, ∞)
+//   - ppy: float64 - OHLCVs per year to annualize the volatility. See PeriodsPerYear. Use 1 for the volatility per OHLCV
+func Parkinson(o OHLCVSeries, l int64, ppy float64) ValueSeries {
+	key := fmt.Sprintf("parkinson:%s:%d:%+v", o.ID(), l, ppy)
+	park := getCache(key)
+	if park == nil {
+		park = NewValueSeries()
+	}
+
+	stop := o.Current()
+	if stop == nil {
+		return park
+	}
+
+	term := ohlcvTerm(o, "parkinsonterm", func(cur *OHLCV) *float64 {
+		hl := math.Log(cur.H / cur.L)
+		return NewFloat64(hl * hl)
+	})
+
+	park = annualizedVolatility(DivConst(SMA(term, l), 4*math.Ln2), ppy)
+
+	setCache(key, park)
+
+	park.SetCurrent(stop.S)
+
+	return park
+}
@@ -0,0 +1,50 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesParkinsonReference tests Parkinson(10, 252) against the formula composed of TA-Lib's SMA on referenceTestData
+func TestSeriesParkinsonReference(t *testing.T) {
+	assertReference(t, "parkinson", 9, []float64{
+		0.3447, 0.3203, 0.3112, 0.3362, 0.3235, 0.3178, 0.3332, 0.3461,
+		0.3580, 0.3621, 0.3742, 0.3822, 0.3975, 0.3781, 0.3854, 0.3874,
+		0.3755, 0.3590, 0.3395, 0.3452, 0.3578, 0.3454, 0.3107, 0.3160,
+		0.2885, 0.2965, 0.3133, 0.3451, 0.3699, 0.3583, 0.3341, 0.3424,
+		0.3537, 0.3847, 0.3827, 0.3951, 0.4093, 0.3972, 0.3967, 0.4104,
+		0.4215, 0.4190, 0.4494, 0.4327, 0.4456, 0.4312, 0.4297, 0.4223,
+		0.4343, 0.4279, 0.4344,
+	}, func(o OHLCVSeries) ValueSeries {
+		return Parkinson(o, 10, 252)
+	})
+}
+
+// TestSeriesParkinsonFlat tests that OHLCVs without a range have no volatility
+func TestSeriesParkinsonFlat(t *testing.T) {
+	assertFlatOHLCV(t, "parkinson", 2, NewFloat64(0), func(o OHLCVSeries) ValueSeries {
+		return Parkinson(o, 3, 252)
+	})
+}
+
+func TestMemoryLeakParkinson(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		Parkinson(o, 3, 252)
+		return nil
+	})
+}
+
+func ExampleParkinson() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		parkinson := Parkinson(series, 3, 252)
+		log.Printf("Parkinson: %+v", parkinson.Val())
+	}
+}
@@ -0,0 +1,87 @@
+package pine
+
+import (
+	"fmt"
+	"math"
+)
+
+// PercentileLinearInterpolation generates a ValueSeries of the percentile of the last l values using linear interpolation between the two nearest ranks.
+//
+// The formula for the percentile of ascending values x is
+//   - pos = pct / 100 * (l - 1)
+//   - percentile = x[floor(pos)] + (x[ceil(pos)] - x[floor(pos)]) * (pos - floor(pos))
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - l - int: lookback periods [1, ∞)
+//   - pct - float64: percentage [0, 100]
+func PercentileLinearInterpolation(p ValueSeries, l int, pct float64) ValueSeries {
+	key := fmt.Sprintf("percentilelinear:%s:%d:%v", p.ID(), l, pct)
+	perc := getCache(key)
+	if perc == nil {
+		perc = NewValueSeries()
+	}
+
+	if p == nil || p.GetCurrent() == nil {
+		return perc
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+
+	perc = generateSorted(*stop, p, perc, key, l, func(w *sortedWindow) float64 {
+		pos := pct / 100 * float64(len(w.sorted)-1)
+		lo := int(math.Floor(pos))
+		hi := int(math.Ceil(pos))
+		return w.sorted[lo] + (w.sorted[hi]-w.sorted[lo])*(pos-float64(lo))
+	})
+
+	setCache(key, perc)
+
+	perc.SetCurrent(stop.t)
+
+	return perc
+}
+
+// PercentileNearestRank generates a ValueSeries of the percentile of the last l values using the nearest rank method.
+// The result is always one of the values in the window.
+//
+// The formula for the percentile of ascending values x is
+//   - rank = ceil(pct / 100 * l), which is at least 1
+//   - percentile = x[rank - 1]
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - l - int: lookback periods [1, ∞)
+//   - pct - float64: percentage [0, 100]
+func PercentileNearestRank(p ValueSeries, l int, pct float64) ValueSeries {
+	key := fmt.Sprintf("percentilenearest:%s:%d:%v", p.ID(), l, pct)
+	perc := getCache(key)
+	if perc == nil {
+		perc = NewValueSeries()
+	}
+
+	if p == nil || p.GetCurrent() == nil {
+		return perc
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+
+	perc = generateSorted(*stop, p, perc, key, l, func(w *sortedWindow) float64 {
+		rank := int(math.Ceil(pct / 100 * float64(len(w.sorted))))
+		if rank < 1 {
+			rank = 1
+		}
+		if rank > len(w.sorted) {
+			rank = len(w.sorted)
+		}
+		return w.sorted[rank-1]
+	})
+
+	setCache(key, perc)
+
+	perc.SetCurrent(stop.t)
+
+	return perc
+}
@@ -0,0 +1,149 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesPercentileLinearInterpolationNoData tests no data scenario
+func TestSeriesPercentileLinearInterpolationNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prop := OHLCVAttr(series, OHLCPropClose)
+	perc := PercentileLinearInterpolation(prop, 4, 30)
+	if perc == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if perc.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *perc.Val())
+	}
+}
+
+// TestSeriesPercentileLinearInterpolationIteration tests the 30th percentile of 4 closes against the values worked out by hand
+// from the sorted windows, where the position is 0.3 * (4 - 1) = 0.9
+//
+// t=4:  11.9 16.5 18.2 18.7 -> 11.9 + 0.9 * (16.5 - 11.9) = 16.04
+// t=5:  11.9 18.2 18.7 19.3 -> 11.9 + 0.9 * (18.2 - 11.9) = 17.57
+// t=6:  11.9 14.2 18.2 19.3 -> 11.9 + 0.9 * (14.2 - 11.9) = 13.97
+// t=7:  11.9 14.2 14.4 19.3 -> 11.9 + 0.9 * (14.2 - 11.9) = 13.97
+// t=8:  11.0 14.2 14.4 19.3 -> 11.0 + 0.9 * (14.2 - 11.0) = 13.88
+// t=9:  11.0 14.2 14.4 14.7 -> 11.0 + 0.9 * (14.2 - 11.0) = 13.88
+// t=10: 10.3 11.0 14.4 14.7 -> 10.3 + 0.9 * (11.0 - 10.3) = 10.93
+func TestSeriesPercentileLinearInterpolationIteration(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		nil,
+		nil,
+		nil,
+		NewFloat64(16.0400),
+		NewFloat64(17.5700),
+		NewFloat64(13.9700),
+		NewFloat64(13.9700),
+		NewFloat64(13.8800),
+		NewFloat64(13.8800),
+		NewFloat64(10.9300),
+	}
+
+	for i, v := range tests {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropClose)
+		perc := PercentileLinearInterpolation(prop, 4, 30)
+		assertSeriesVal(t, "percentile", i, v, perc)
+	}
+}
+
+// TestSeriesPercentileNearestRankIteration tests the 30th percentile of 4 closes against the values worked out by hand
+// from the sorted windows, where the rank is ceil(0.3 * 4) = 2
+//
+// t=4:  11.9 16.5 18.2 18.7 -> 16.5
+// t=5:  11.9 18.2 18.7 19.3 -> 18.2
+// t=6:  11.9 14.2 18.2 19.3 -> 14.2
+// t=7:  11.9 14.2 14.4 19.3 -> 14.2
+// t=8:  11.0 14.2 14.4 19.3 -> 14.2
+// t=9:  11.0 14.2 14.4 14.7 -> 14.2
+// t=10: 10.3 11.0 14.4 14.7 -> 11.0
+func TestSeriesPercentileNearestRankIteration(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		nil,
+		nil,
+		nil,
+		NewFloat64(16.5000),
+		NewFloat64(18.2000),
+		NewFloat64(14.2000),
+		NewFloat64(14.2000),
+		NewFloat64(14.2000),
+		NewFloat64(14.2000),
+		NewFloat64(11.0000),
+	}
+
+	for i, v := range tests {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropClose)
+		perc := PercentileNearestRank(prop, 4, 30)
+		assertSeriesVal(t, "percentile", i, v, perc)
+	}
+}
+
+// TestSeriesPercentileLinearInterpolationFlat tests that a flat window returns the flat value
+func TestSeriesPercentileLinearInterpolationFlat(t *testing.T) {
+	assertFlat(t, "percentile", 3, func(p ValueSeries) ValueSeries {
+		return PercentileLinearInterpolation(p, 4, 30)
+	})
+}
+
+// TestSeriesPercentileNearestRankFlat tests that a flat window returns the flat value
+func TestSeriesPercentileNearestRankFlat(t *testing.T) {
+	assertFlat(t, "percentile", 3, func(p ValueSeries) ValueSeries {
+		return PercentileNearestRank(p, 4, 30)
+	})
+}
+
+// TestSeriesPercentileLinearInterpolationSourceTrimmed tests that trimming the source to 4 values does not change the output
+func TestSeriesPercentileLinearInterpolationSourceTrimmed(t *testing.T) {
+	assertSourceTrimmed(t, "percentile", 4, func(p ValueSeries) ValueSeries {
+		return PercentileLinearInterpolation(p, 4, 30)
+	})
+}
+
+func TestMemoryLeakPercentileLinearInterpolation(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		PercentileLinearInterpolation(prop, 4, 30)
+		PercentileNearestRank(prop, 4, 30)
+		return nil
+	})
+}
+
+func ExamplePercentileLinearInterpolation() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		perc := PercentileLinearInterpolation(prop, 4, 30)
+		log.Printf("PercentileLinearInterpolation: %+v", perc.Val())
+	}
+}
@@ -0,0 +1,38 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// PercentRank generates a ValueSeries of the percentage of the previous l values that are less than or equal to the current value.
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - l - int: number of previous values to compare [1, ∞)
+func PercentRank(p ValueSeries, l int) ValueSeries {
+	key := fmt.Sprintf("percentrank:%s:%d", p.ID(), l)
+	pr := getCache(key)
+	if pr == nil {
+		pr = NewValueSeries()
+	}
+
+	if p == nil || p.GetCurrent() == nil {
+		return pr
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+
+	pr = generateSorted(*stop, p, pr, key, l+1, func(w *sortedWindow) float64 {
+		cur := w.fifo[len(w.fifo)-1]
+		// exclude the current value itself
+		ct := w.countLTE(cur) - 1
+		return 100 * float64(ct) / float64(l)
+	})
+
+	setCache(key, pr)
+
+	pr.SetCurrent(stop.t)
+
+	return pr
+}
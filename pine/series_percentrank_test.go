@@ -0,0 +1,96 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesPercentRankNoData tests no data scenario
+func TestSeriesPercentRankNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prop := OHLCVAttr(series, OHLCPropClose)
+	percentrank := PercentRank(prop, 3)
+	if percentrank == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if percentrank.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *percentrank.Val())
+	}
+}
+
+// TestSeriesPercentRankIteration tests PercentRank(3) against the values worked out by hand
+// from the number of the previous 3 closes that are less than or equal to the current close
+//
+// t=4:  16.5 18.7 18.2 <= 11.9 -> 0 / 3 * 100 = 0
+// t=5:  18.7 18.2 11.9 <= 19.3 -> 3 / 3 * 100 = 100
+// t=6:  18.2 11.9 19.3 <= 14.2 -> 1 / 3 * 100 = 33.3333
+// t=7:  11.9 19.3 14.2 <= 14.4 -> 2 / 3 * 100 = 66.6667
+// t=8:  19.3 14.2 14.4 <= 11.0 -> 0 / 3 * 100 = 0
+// t=9:  14.2 14.4 11.0 <= 14.7 -> 3 / 3 * 100 = 100
+// t=10: 14.4 11.0 14.7 <= 10.3 -> 0 / 3 * 100 = 0
+func TestSeriesPercentRankIteration(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		nil,
+		nil,
+		nil,
+		NewFloat64(0.0000),
+		NewFloat64(100.0000),
+		NewFloat64(33.3333),
+		NewFloat64(66.6667),
+		NewFloat64(0.0000),
+		NewFloat64(100.0000),
+		NewFloat64(0.0000),
+	}
+
+	for i, v := range tests {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropClose)
+		percentrank := PercentRank(prop, 3)
+		assertSeriesVal(t, "percentrank", i, v, percentrank)
+	}
+}
+
+// TestSeriesPercentRankSourceTrimmed tests that trimming the source to 4 values does not change the output
+func TestSeriesPercentRankSourceTrimmed(t *testing.T) {
+	assertSourceTrimmed(t, "percentrank", 4, func(p ValueSeries) ValueSeries {
+		return PercentRank(p, 3)
+	})
+}
+
+func TestMemoryLeakPercentRank(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		PercentRank(prop, 3)
+		return nil
+	})
+}
+
+func ExamplePercentRank() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		percentrank := PercentRank(prop, 3)
+		log.Printf("PercentRank: %+v", percentrank.Val())
+	}
+}
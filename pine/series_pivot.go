@@ -0,0 +1,186 @@
+package pine
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// PivotHigh generates a ValueSeries of pivot high values.
+//
+// A pivot high is a value that is higher than the left number of values before it and is not lower than the right number of values after it.
+// The pivot is only known once right number of values have confirmed it, so the value is set at the confirming time instead of the time of the pivot.
+// There are no values at times where no pivot has been confirmed.
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - left - int: number of values on the left of the pivot [1, ∞)
+//   - right - int: number of values on the right of the pivot [0, ∞)
+func PivotHigh(p ValueSeries, left, right int) ValueSeries {
+	pivot, _ := getPivot(p, left, right, true)
+	return pivot
+}
+
+// PivotLow generates a ValueSeries of pivot low values.
+//
+// A pivot low is a value that is lower than the left number of values before it and is not higher than the right number of values after it.
+// The pivot is only known once right number of values have confirmed it, so the value is set at the confirming time instead of the time of the pivot.
+// There are no values at times where no pivot has been confirmed.
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - left - int: number of values on the left of the pivot [1, ∞)
+//   - right - int: number of values on the right of the pivot [0, ∞)
+func PivotLow(p ValueSeries, left, right int) ValueSeries {
+	pivot, _ := getPivot(p, left, right, false)
+	return pivot
+}
+
+// PivotHighTime returns the original time of the pivot high confirmed at the current time.
+// nil is returned if no pivot high was confirmed at the current time.
+func PivotHighTime(p ValueSeries, left, right int) *time.Time {
+	_, s := getPivot(p, left, right, true)
+	return pivotTime(p, s)
+}
+
+// PivotLowTime returns the original time of the pivot low confirmed at the current time.
+// nil is returned if no pivot low was confirmed at the current time.
+func PivotLowTime(p ValueSeries, left, right int) *time.Time {
+	_, s := getPivot(p, left, right, false)
+	return pivotTime(p, s)
+}
+
+// pivotTime returns the time of the pivot confirmed at the current time of p
+func pivotTime(p ValueSeries, s *pivotState) *time.Time {
+	cur := p.GetCurrent()
+	if cur == nil || s == nil {
+		return nil
+	}
+	t, ok := s.confirmed(cur.t)
+	if !ok || t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// pivotPoint is a pivot and the time where it was confirmed
+type pivotPoint struct {
+	t        time.Time
+	confirmt time.Time
+}
+
+// pivotState is the state of the pivots carried over to the next call
+type pivotState struct {
+	// confirmed pivots in the order of the confirming time
+	points []pivotPoint
+	// time of the first value with a full window and of the last processed value
+	first time.Time
+	last  time.Time
+}
+
+// pivotMaxPoints is the number of confirmed pivots kept in pivotState
+const pivotMaxPoints = 1000
+
+var pivotCache map[string]*pivotState = make(map[string]*pivotState)
+
+// confirmed returns the time of the pivot confirmed at t.
+// ok is false if t has not been evaluated, e.g. the window was not full at t. The time is zero if no pivot was confirmed at t.
+func (s *pivotState) confirmed(t time.Time) (pt time.Time, ok bool) {
+	if s.first.IsZero() || t.Before(s.first) || t.After(s.last) {
+		return time.Time{}, false
+	}
+	i := sort.Search(len(s.points), func(i int) bool {
+		return !s.points[i].confirmt.Before(t)
+	})
+	if i < len(s.points) && s.points[i].confirmt.Equal(t) {
+		return s.points[i].t, true
+	}
+	return time.Time{}, true
+}
+
+func (s *pivotState) add(pt pivotPoint) {
+	s.points = append(s.points, pt)
+	if len(s.points) > pivotMaxPoints {
+		s.points = s.points[len(s.points)-pivotMaxPoints:]
+	}
+}
+
+// getPivot generates pivots and the state holding the time of the pivots.
+func getPivot(p ValueSeries, left, right int, high bool) (ValueSeries, *pivotState) {
+	key := fmt.Sprintf("pivot:%s:%d:%d:%t", p.ID(), left, right, high)
+	pivot := getCache(key)
+	if pivot == nil {
+		pivot = NewValueSeries()
+	}
+
+	s := pivotCache[key]
+	if s == nil {
+		s = &pivotState{}
+	}
+
+	if p == nil || p.GetCurrent() == nil {
+		return pivot, s
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+
+	f := p.GetFirst()
+	if !s.last.IsZero() {
+		f = valueAfter(p, s.last)
+	}
+	for {
+		if f == nil {
+			break
+		}
+
+		if w := windowValues(f, left+right+1); w != nil {
+			if s.first.IsZero() {
+				s.first = f.t
+			}
+			if isPivot(w, left, high) {
+				pv := f
+				for i := 0; i < right; i++ {
+					pv = pv.prev
+				}
+				pivot.Set(f.t, w[left])
+				s.add(pivotPoint{t: pv.t, confirmt: f.t})
+			}
+		}
+		s.last = f.t
+
+		if f.t.Equal(stop.t) {
+			break
+		}
+		f = f.next
+	}
+
+	setCache(key, pivot)
+	setState(pivotCache, key, s)
+
+	pivot.SetCurrent(stop.t)
+
+	return pivot, s
+}
+
+// isPivot returns true if w[left] is a pivot within w
+func isPivot(w []float64, left int, high bool) bool {
+	c := w[left]
+	for i, v := range w {
+		if i == left {
+			continue
+		}
+		// values on the left must be strictly exceeded
+		beaten := v < c
+		if !high {
+			beaten = v > c
+		}
+		if i > left {
+			beaten = beaten || v == c
+		}
+		if !beaten {
+			return false
+		}
+	}
+	return true
+}
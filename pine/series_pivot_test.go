@@ -0,0 +1,168 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesPivotNoData tests no data scenario
+func TestSeriesPivotNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prop := OHLCVAttr(series, OHLCPropClose)
+	ph := PivotHigh(prop, 2, 1)
+	if ph == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if ph.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *ph.Val())
+	}
+	if pt := PivotHighTime(prop, 2, 1); pt != nil {
+		t.Errorf("Expected to be nil but got %+v", *pt)
+	}
+}
+
+// TestSeriesPivotHighIteration tests that the pivot high is set at the confirming time
+//
+// t=time.Time         | 1    | 2    | 3    | 4    | 5    | 6    | 7    | 8    | 9    | 10   |
+// p=ValueSeries       | 16.5 | 18.7 | 18.2 | 11.9 | 19.3 | 14.2 | 14.4 | 11.0 | 14.7 | 10.3 |
+// pivothigh(p, 2, 1)  |      |      |      |      |      | 19.3 |      |      |      | 14.7 |
+// pivot time          |      |      |      |      |      | 5    |      |      |      | 9    |
+func TestSeriesPivotHighIteration(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		NewFloat64(19.3),
+		nil,
+		nil,
+		nil,
+		NewFloat64(14.7),
+	}
+	pivotIdx := map[int]int{5: 4, 9: 8}
+
+	for i, v := range tests {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropClose)
+		ph := PivotHigh(prop, 2, 1)
+		assertSeriesVal(t, "pivothigh", i, v, ph)
+
+		pt := PivotHighTime(prop, 2, 1)
+		idx, ok := pivotIdx[i]
+		if ok != (pt != nil) {
+			t.Fatalf("Expected pivot time to exist: %t but got %+v for iteration: %d", ok, pt, i)
+		}
+		if ok && !pt.Equal(data[idx].S) {
+			t.Errorf("Expected pivot time to be %+v but got %+v for iteration: %d", data[idx].S, *pt, i)
+		}
+	}
+}
+
+// TestSeriesPivotLowIteration tests that the pivot low is set at the confirming time
+//
+// t=time.Time         | 1    | 2    | 3    | 4    | 5    | 6    | 7    | 8    | 9    | 10   |
+// p=ValueSeries       | 16.5 | 18.7 | 18.2 | 11.9 | 19.3 | 14.2 | 14.4 | 11.0 | 14.7 | 10.3 |
+// pivotlow(p, 2, 1)   |      |      |      |      | 11.9 |      |      |      | 11.0 |      |
+// pivot time          |      |      |      |      | 4    |      |      |      | 8    |      |
+func TestSeriesPivotLowIteration(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		nil,
+		nil,
+		nil,
+		nil,
+		NewFloat64(11.9),
+		nil,
+		nil,
+		nil,
+		NewFloat64(11.0),
+		nil,
+	}
+	pivotIdx := map[int]int{4: 3, 8: 7}
+
+	for i, v := range tests {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropClose)
+		pl := PivotLow(prop, 2, 1)
+		assertSeriesVal(t, "pivotlow", i, v, pl)
+
+		pt := PivotLowTime(prop, 2, 1)
+		idx, ok := pivotIdx[i]
+		if ok != (pt != nil) {
+			t.Fatalf("Expected pivot time to exist: %t but got %+v for iteration: %d", ok, pt, i)
+		}
+		if ok && !pt.Equal(data[idx].S) {
+			t.Errorf("Expected pivot time to be %+v but got %+v for iteration: %d", data[idx].S, *pt, i)
+		}
+	}
+}
+
+// TestSeriesPivotPlateau tests that only the first value of equal highs becomes the pivot
+//
+// p=ValueSeries         | 5 | 7 | 7 | 5 | 5 |
+// pivothigh(p, 1, 1)    |   |   | 7 |   |   |
+func TestSeriesPivotPlateau(t *testing.T) {
+	data := OHLCVStaticTestData()[:5]
+	for i, v := range []float64{5, 7, 7, 5, 5} {
+		data[i].C = v
+	}
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{nil, nil, NewFloat64(7), nil, nil}
+
+	for i, v := range tests {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropClose)
+		assertSeriesVal(t, "pivothigh", i, v, PivotHigh(prop, 1, 1))
+	}
+}
+
+func TestMemoryLeakPivot(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		PivotHigh(prop, 5, 5)
+		PivotLow(prop, 5, 5)
+		return nil
+	})
+}
+
+func ExamplePivotHigh() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropHigh)
+		ph := PivotHigh(prop, 5, 5)
+		if ph.Val() != nil {
+			log.Printf("Pivot high: %+v at %+v", *ph.Val(), *PivotHighTime(prop, 5, 5))
+		}
+	}
+}
@@ -114,6 +114,26 @@ func TestSeriesPowIteration(t *testing.T) {
 	}
 }
 
+// TestSeriesPowCache tests that the same ValueSeries is returned for the same source so that an expression using it stays incremental
+func TestSeriesPowCache(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var id string
+	for i := 0; i < 3; i++ {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropClose)
+		pow := Pow(prop, 2)
+		if i > 0 && pow.ID() != id {
+			t.Errorf("expected %s but got %s at iteration: %d", id, pow.ID(), i)
+		}
+		id = pow.ID()
+	}
+}
+
 func TestMemoryLeakPow(t *testing.T) {
 	testMemoryLeak(t, func(o OHLCVSeries) error {
 		prop := OHLCVAttr(o, OHLCPropClose)
@@ -0,0 +1,16 @@
+package pine
+
+// PVI generates a ValueSeries of positive volume index.
+// The index only changes by the rate of change of close when the volume increases from the previous bar.
+// It starts from 1 and survives trimming of the series since each value only depends on the previous value.
+//
+// The formula for PVI is
+//   - pvi = pvi[1] + change(close) / close[1] * pvi[1] if volume > volume[1], otherwise pvi[1]
+//
+// The arguments are:
+//   - o: OHLCVSeries - source of data
+func PVI(o OHLCVSeries) ValueSeries {
+	return getVolumeIndex(o, "pvi", func(v, prevv float64) bool {
+		return v > prevv
+	})
+}
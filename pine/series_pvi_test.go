@@ -0,0 +1,95 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesPVINoData tests no data scenario
+func TestSeriesPVINoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pvi := PVI(series)
+	if pvi == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if pvi.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *pvi.Val())
+	}
+}
+
+// TestSeriesPVIReference tests PVI against the running product of 1 + rocp with TA-Lib's ROCP for the bars whose volume increased on referenceTestData
+func TestSeriesPVIReference(t *testing.T) {
+	exp := []float64{
+		1.0000, 1.0000, 0.9906, 0.9906, 1.0025, 1.0025, 1.0321, 1.0105,
+		1.0105, 1.0319, 1.0319, 1.0429, 1.0598, 1.0598, 1.0598, 1.0564,
+		1.0564, 1.0564, 1.0333, 1.0333, 1.0333, 1.0333, 1.0333, 1.0525,
+		1.0755, 1.0838, 1.0838, 1.0768, 1.0768, 1.0475, 1.0475, 1.0390,
+		1.0390, 1.0394, 1.0394, 1.0486, 1.0085, 1.0085, 1.0085, 1.0085,
+		1.0085, 1.0085, 0.9950, 0.9950, 0.9822, 0.9822, 0.9642, 0.9765,
+		0.9765, 0.9765, 0.9702, 1.0014, 1.0014, 1.0014, 1.0014, 0.9825,
+		0.9825, 0.9825, 0.9825, 0.9748,
+	}
+
+	assertReference(t, "pvi", 0, exp, func(o OHLCVSeries) ValueSeries {
+		return PVI(o)
+	})
+}
+
+// TestSeriesPVISetMax tests that the running total is kept after the series is trimmed
+func TestSeriesPVISetMax(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		NewFloat64(1.0000),
+		NewFloat64(1.1333),
+		NewFloat64(1.1030),
+		NewFloat64(0.7212),
+		NewFloat64(1.1697),
+		NewFloat64(0.8606),
+		NewFloat64(0.8606),
+		NewFloat64(0.8606),
+		NewFloat64(1.1501),
+		NewFloat64(1.1501),
+	}
+
+	for i, v := range tests {
+		series.Next()
+		pvi := PVI(series)
+		pvi.SetMax(1)
+		assertSeriesVal(t, "pvi", i, v, pvi)
+	}
+}
+
+func TestMemoryLeakPVI(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		PVI(o)
+		return nil
+	})
+}
+
+func ExamplePVI() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		pvi := PVI(series)
+		log.Printf("PVI: %+v", pvi.Val())
+	}
+}
@@ -0,0 +1,44 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// PVT generates a ValueSeries of price-volume trend.
+// It is a running total that survives trimming of the series since each value only depends on the previous value.
+//
+// The formula for PVT is
+//   - pvt = cum(change(close) / close[1] * volume)
+//
+// The first value is 0 since there is no previous close. The term is skipped where the previous close is 0.
+//
+// The arguments are:
+//   - o: OHLCVSeries - source of data
+func PVT(o OHLCVSeries) ValueSeries {
+	key := fmt.Sprintf("pvt:%s", o.ID())
+	pvt := getCache(key)
+	if pvt == nil {
+		pvt = NewValueSeries()
+	}
+
+	stop := o.Current()
+	if stop == nil {
+		return pvt
+	}
+
+	pvt = generateOHLCVRecursive(o, pvt, func(cur *OHLCV, prev *float64) *float64 {
+		if cur.prev == nil || prev == nil {
+			return NewFloat64(0)
+		}
+		if cur.prev.C == 0 {
+			return NewFloat64(*prev)
+		}
+		return NewFloat64(*prev + (cur.C-cur.prev.C)/cur.prev.C*cur.V)
+	})
+
+	setCache(key, pvt)
+
+	pvt.SetCurrent(stop.S)
+
+	return pvt
+}
@@ -0,0 +1,119 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesPVTNoData tests no data scenario
+func TestSeriesPVTNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pvt := PVT(series)
+	if pvt == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if pvt.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *pvt.Val())
+	}
+}
+
+// TestSeriesPVTReference tests PVT against cum(rocp * volume) with TA-Lib's ROCP on referenceTestData
+func TestSeriesPVTReference(t *testing.T) {
+	exp := []float64{
+		0.0000, -39.4963, -54.3443, -82.1450, -64.1796, -60.6112, -27.5305, -56.7348,
+		-77.5927, -42.5708, -34.5001, -13.9004, 17.5797, -22.1877, -23.0803, -29.4168,
+		-49.2865, -23.3681, -66.9521, -72.5023, -39.2939, -68.6079, -72.1322, -47.5779,
+		-6.2542, 8.5948, 26.3772, 14.2952, -5.8034, -35.3734, -12.3546, -23.9846,
+		-16.2672, -15.6073, -35.5269, -22.2219, -85.3915, -83.0675, -83.0675, -81.4998,
+		-106.1772, -105.7203, -123.5650, -124.7736, -144.6332, -187.4149, -216.5322, -192.2318,
+		-150.5334, -186.8575, -193.6863, -133.8182, -201.9940, -174.3313, -204.0254, -238.0239,
+		-173.5581, -127.1226, -134.5627, -144.2287,
+	}
+
+	assertReference(t, "pvt", 0, exp, func(o OHLCVSeries) ValueSeries {
+		return PVT(o)
+	})
+}
+
+// TestSeriesPVTSetMax tests that the running total is kept after the series is trimmed
+func TestSeriesPVTSetMax(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		NewFloat64(0.0000),
+		NewFloat64(1.7333),
+		NewFloat64(1.3643),
+		NewFloat64(-4.1395),
+		NewFloat64(6.3076),
+		NewFloat64(1.2604),
+		NewFloat64(1.4675),
+		NewFloat64(-1.2950),
+		NewFloat64(4.5577),
+		NewFloat64(0.0679),
+	}
+
+	for i, v := range tests {
+		series.Next()
+		pvt := PVT(series)
+		pvt.SetMax(1)
+		assertSeriesVal(t, "pvt", i, v, pvt)
+	}
+}
+
+// TestSeriesPVTZeroClose tests that the term is skipped where the previous close is 0 instead of making the running total infinite
+//
+// t=time.Time | 1  | 2   | 3  | 4   |
+// close       | 10 | 0   | 5  | 10  |
+// volume      | 1  | 2   | 3  | 4   |
+// pvt         | 0  | -2  | -2 | 2   |
+func TestSeriesPVTZeroClose(t *testing.T) {
+	data := OHLCVTestData(time.Now(), 4, 5*60*1000)
+	for i, v := range []float64{10, 0, 5, 10} {
+		data[i].C = v
+		data[i].V = float64(i + 1)
+	}
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{NewFloat64(0), NewFloat64(-2), NewFloat64(-2), NewFloat64(2)}
+	for i, v := range tests {
+		series.Next()
+		assertSeriesVal(t, "pvt", i, v, PVT(series))
+	}
+}
+
+func TestMemoryLeakPVT(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		PVT(o)
+		return nil
+	})
+}
+
+func ExamplePVT() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		pvt := PVT(series)
+		log.Printf("PVT: %+v", pvt.Val())
+	}
+}
@@ -0,0 +1,40 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// Rising generates a ValueSeries of 1.0 if the source value is greater than all of the previous l values, otherwise 0.0.
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - l - int: number of previous values to compare [1, ∞)
+func Rising(p ValueSeries, l int) ValueSeries {
+	key := fmt.Sprintf("rising:%s:%d", p.ID(), l)
+	rs := getCache(key)
+	if rs == nil {
+		rs = NewValueSeries()
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+	if stop == nil {
+		return rs
+	}
+
+	rs = generateWindow(*stop, p, rs, l+1, func(w []float64) float64 {
+		c := w[l]
+		for _, v := range w[:l] {
+			if v >= c {
+				return 0
+			}
+		}
+		return 1
+	})
+
+	setCache(key, rs)
+
+	rs.SetCurrent(stop.t)
+
+	return rs
+}
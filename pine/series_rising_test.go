@@ -0,0 +1,80 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesRisingNoData tests no data scenario
+func TestSeriesRisingNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prop := OHLCVAttr(series, OHLCPropClose)
+	rising := Rising(prop, 2)
+	if rising == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if rising.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *rising.Val())
+	}
+}
+
+// TestSeriesRisingIteration tests the output against values of the reference formula
+func TestSeriesRisingIteration(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		nil,
+		nil,
+		NewFloat64(0),
+		NewFloat64(0),
+		NewFloat64(1),
+		NewFloat64(0),
+		NewFloat64(0),
+		NewFloat64(0),
+		NewFloat64(1),
+		NewFloat64(0),
+	}
+
+	for i, v := range tests {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropClose)
+		rising := Rising(prop, 2)
+		assertSeriesVal(t, "rising", i, v, rising)
+	}
+}
+
+func TestMemoryLeakRising(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		Rising(prop, 2)
+		return nil
+	})
+}
+
+func ExampleRising() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		rising := Rising(prop, 2)
+		log.Printf("Rising: %+v", rising.Val())
+	}
+}
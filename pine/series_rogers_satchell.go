@@ -0,0 +1,46 @@
+package pine
+
+import (
+	"fmt"
+	"math"
+)
+
+// RogersSatchell generates a ValueSeries of Rogers-Satchell volatility estimator.
+// Unlike Parkinson and GarmanKlass, it is unbiased when the price has a drift but it still assumes no opening jumps.
+//
+// The formula for RogersSatchell is
+//   - variance = sma(ln(high / close) * ln(high / open) + ln(low / close) * ln(low / open), l)
+//   - rogerssatchell = sqrt(variance * ppy)
+//
+// The arguments are:
+//   - o: OHLCVSeries - source of data
+//   - l: int64 - lookback periods [1, ∞)
+//   - ppy: float64 - OHLCVs per year to annualize the volatility. See PeriodsPerYear. Use 1 for the volatility per OHLCV
+func RogersSatchell(o OHLCVSeries, l int64, ppy float64) ValueSeries {
+	key := fmt.Sprintf("rogerssatchell:%s:%d:%+v", o.ID(), l, ppy)
+	rs := getCache(key)
+	if rs == nil {
+		rs = NewValueSeries()
+	}
+
+	stop := o.Current()
+	if stop == nil {
+		return rs
+	}
+
+	rs = annualizedVolatility(rogersSatchellVariance(o, l), ppy)
+
+	setCache(key, rs)
+
+	rs.SetCurrent(stop.S)
+
+	return rs
+}
+
+// rogersSatchellVariance generates the variance per OHLCV of Rogers-Satchell volatility estimator
+func rogersSatchellVariance(o OHLCVSeries, l int64) ValueSeries {
+	term := ohlcvTerm(o, "rogerssatchellterm", func(cur *OHLCV) *float64 {
+		return NewFloat64(math.Log(cur.H/cur.C)*math.Log(cur.H/cur.O) + math.Log(cur.L/cur.C)*math.Log(cur.L/cur.O))
+	})
+	return SMA(term, l)
+}
@@ -0,0 +1,50 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesRogersSatchellReference tests RogersSatchell(10, 252) against the formula composed of TA-Lib's SMA on referenceTestData
+func TestSeriesRogersSatchellReference(t *testing.T) {
+	assertReference(t, "rogerssatchell", 9, []float64{
+		0.3620, 0.3405, 0.3393, 0.3672, 0.3519, 0.3436, 0.3609, 0.3786,
+		0.3838, 0.3953, 0.4084, 0.4190, 0.4269, 0.4101, 0.4184, 0.4199,
+		0.4054, 0.3839, 0.3707, 0.3680, 0.3785, 0.3608, 0.3279, 0.3330,
+		0.3098, 0.3147, 0.3352, 0.3701, 0.3998, 0.3925, 0.3763, 0.3869,
+		0.4008, 0.4326, 0.4311, 0.4493, 0.4538, 0.4340, 0.4312, 0.4402,
+		0.4426, 0.4403, 0.4710, 0.4440, 0.4599, 0.4434, 0.4536, 0.4434,
+		0.4527, 0.4573, 0.4685,
+	}, func(o OHLCVSeries) ValueSeries {
+		return RogersSatchell(o, 10, 252)
+	})
+}
+
+// TestSeriesRogersSatchellFlat tests that OHLCVs without a range have no volatility
+func TestSeriesRogersSatchellFlat(t *testing.T) {
+	assertFlatOHLCV(t, "rogerssatchell", 2, NewFloat64(0), func(o OHLCVSeries) ValueSeries {
+		return RogersSatchell(o, 3, 252)
+	})
+}
+
+func TestMemoryLeakRogersSatchell(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		RogersSatchell(o, 3, 252)
+		return nil
+	})
+}
+
+func ExampleRogersSatchell() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		rs := RogersSatchell(series, 3, 252)
+		log.Printf("RogersSatchell: %+v", rs.Val())
+	}
+}
@@ -0,0 +1,82 @@
+package pine
+
+import (
+	"fmt"
+	"math"
+)
+
+// RoofingFilter generates a ValueSeries of Ehlers' roofing filter.
+// A two-pole high-pass filter removes the cycles longer than hpl and the super smoother removes the cycles shorter than ssl.
+//
+// The formula for RoofingFilter is
+//   - a = (cos(0.707 * 2π / hpl) + sin(0.707 * 2π / hpl) - 1) / cos(0.707 * 2π / hpl)
+//   - hp = (1 - a / 2)^2 * (p - 2 * p[1] + p[2]) + 2 * (1 - a) * hp[1] - (1 - a)^2 * hp[2]
+//   - roof = supersmoother(hp, ssl)
+//
+// hp[1] and hp[2] are 0 if they are not available.
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - hpl - int64: critical period of the high-pass filter, i.e. 48 [1, ∞)
+//   - ssl - int64: critical period of the super smoother, i.e. 10 [1, ∞)
+func RoofingFilter(p ValueSeries, hpl, ssl int64) ValueSeries {
+	key := fmt.Sprintf("roofingfilter:%s:%d:%d", p.ID(), hpl, ssl)
+	roof := getCache(key)
+	if roof == nil {
+		roof = NewValueSeries()
+	}
+
+	if p == nil || p.GetCurrent() == nil {
+		return roof
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+
+	roof = SuperSmoother(highPass2(p, hpl), ssl)
+
+	setCache(key, roof)
+
+	roof.SetCurrent(stop.t)
+
+	return roof
+}
+
+// highPass2 generates a ValueSeries of Ehlers' two-pole high-pass filter
+func highPass2(p ValueSeries, l int64) ValueSeries {
+	key := fmt.Sprintf("highpass2:%s:%d", p.ID(), l)
+	hp := getCache(key)
+	if hp == nil {
+		hp = NewValueSeries()
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+	if stop == nil {
+		return hp
+	}
+
+	rad := 0.707 * 2 * math.Pi / float64(l)
+	a := (math.Cos(rad) + math.Sin(rad) - 1) / math.Cos(rad)
+
+	hp = generateFilter(*stop, p, hp, 2, func(v *Value, prev []*float64) *float64 {
+		w := windowValues(v, 3)
+		if w == nil {
+			return nil
+		}
+		var hp1, hp2 float64
+		if prev[0] != nil {
+			hp1 = *prev[0]
+		}
+		if prev[1] != nil {
+			hp2 = *prev[1]
+		}
+		return NewFloat64((1-a/2)*(1-a/2)*(w[2]-2*w[1]+w[0]) + 2*(1-a)*hp1 - (1-a)*(1-a)*hp2)
+	})
+
+	setCache(key, hp)
+
+	hp.SetCurrent(stop.t)
+
+	return hp
+}
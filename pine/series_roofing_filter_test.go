@@ -0,0 +1,91 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesRoofingFilterNoData tests no data scenario
+func TestSeriesRoofingFilterNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prop := OHLCVAttr(series, OHLCPropClose)
+	rf := RoofingFilter(prop, 6, 3)
+	if rf == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if rf.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *rf.Val())
+	}
+}
+
+// TestSeriesRoofingFilterReference tests RoofingFilter(close, 48, 10) against Ehlers' roofing filter indicator in Cycle Analytics for Traders on referenceTestData.
+// The indicator starts its smoother at 0 while SuperSmoother starts at the source value, so the values are compared from 24 on where the difference has decayed.
+func TestSeriesRoofingFilterReference(t *testing.T) {
+	exp := []float64{
+		10.1433, 10.5868, 11.1097, 11.3685, 10.9150, 9.5811, 8.1237, 7.1101,
+		6.4593, 6.1440, 5.7538, 5.2728, 4.4975, 3.3980, 2.5426, 2.0723,
+		1.6836, 1.2556, 0.8815, 0.5755, 0.3418, -0.1823, -1.0540, -1.6535,
+		-1.3182, -0.6221, -0.2908, 0.2143, 0.6734, 0.8474, 0.8906, 0.5470,
+		0.4696, 1.3614, 2.6593, 3.5227,
+	}
+
+	assertReferenceRange(t, "roofingfilter", 24, exp, func(o OHLCVSeries) ValueSeries {
+		return RoofingFilter(OHLCVAttr(o, OHLCPropClose), 48, 10)
+	})
+}
+
+// TestSeriesRoofingFilterFlat tests that the high-pass filter removes a flat source
+func TestSeriesRoofingFilterFlat(t *testing.T) {
+	assertFlatTo(t, "roofingfilter", 2, 0, func(p ValueSeries) ValueSeries {
+		return RoofingFilter(p, 48, 10)
+	})
+}
+
+// TestSeriesRoofingFilterResponse tests that the cycles between the two critical periods pass and the rest is removed
+func TestSeriesRoofingFilterResponse(t *testing.T) {
+	fn := func(p ValueSeries) ValueSeries {
+		return RoofingFilter(p, 48, 10)
+	}
+
+	if a := sineAmplitude(t, 20, fn); a < 0.85 {
+		t.Errorf("Expected a cycle within the pass band to pass but got the amplitude of %.4f", a)
+	}
+	if a := sineAmplitude(t, 4, fn); a > 0.2 {
+		t.Errorf("Expected a short cycle to be removed but got the amplitude of %.4f", a)
+	}
+	if a := sineAmplitude(t, 200, fn); a > 0.15 {
+		t.Errorf("Expected a long cycle to be removed but got the amplitude of %.4f", a)
+	}
+}
+
+func TestMemoryLeakRoofingFilter(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		RoofingFilter(prop, 6, 3)
+		return nil
+	})
+}
+
+func ExampleRoofingFilter() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		rf := RoofingFilter(prop, 6, 3)
+		log.Printf("RoofingFilter: %+v", rf.Val())
+	}
+}
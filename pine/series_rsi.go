@@ -13,7 +13,12 @@ import (
 //   - rs = ta.rma(u) / ta.rma(d)
 //   - res = 100 - 100 / (1 + rs)
 func RSI(p ValueSeries, l int64) ValueSeries {
-	key := fmt.Sprintf("rsi:%s:%d", p.ID(), l)
+	return RSIWithMA(p, l, MATypeRMA)
+}
+
+// RSIWithMA generates a ValueSeries of relative strength index using the moving average of type mat in place of ta.rma
+func RSIWithMA(p ValueSeries, l int64, mat MAType) ValueSeries {
+	key := fmt.Sprintf("rsi:%s:%d:%d", p.ID(), l, mat)
 	rsi := getCache(key)
 	if rsi == nil {
 		rsi = NewValueSeries()
@@ -26,7 +31,7 @@ func RSI(p ValueSeries, l int64) ValueSeries {
 	// current available value
 	stop := p.GetCurrent()
 
-	rsi = getRSI(stop, p, rsi, l)
+	rsi = getRSI(stop, p, rsi, l, mat)
 
 	setCache(key, rsi)
 
@@ -205,7 +210,7 @@ func getRSID(stop *Value, vs ValueSeries, rsid ValueSeries, l int64) ValueSeries
 	return rsid
 }
 
-func getRSI(stop *Value, vs ValueSeries, rsi ValueSeries, l int64) ValueSeries {
+func getRSI(stop *Value, vs ValueSeries, rsi ValueSeries, l int64, mat MAType) ValueSeries {
 
 	rsiukey := fmt.Sprintf("rsiu:%s:%d", vs.ID(), l)
 	rsiu := getCache(rsiukey)
@@ -240,8 +245,8 @@ func getRSI(stop *Value, vs ValueSeries, rsi ValueSeries, l int64) ValueSeries {
 		rsn = rsn.next
 	}
 
-	rmau := RMA(rsiu, l)
-	rmad := RMA(rsid, l)
+	rmau := MA(rsiu, l, mat)
+	rmad := MA(rsid, l, mat)
 
 	rmadiv := Div(rmau, rmad)
 
@@ -149,6 +149,35 @@ func TestSeriesRSINotEnoughData(t *testing.T) {
 	}
 }
 
+// TestSeriesRSIWithMA tests RSI with SMA against values of the reference formula
+func TestSeriesRSIWithMA(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		NewFloat64(40.4762),
+		NewFloat64(49.0153),
+		NewFloat64(37.8109),
+		NewFloat64(40.7666),
+		NewFloat64(28.3636),
+	}
+
+	for i, v := range tests {
+		series.Next()
+		c := OHLCVAttr(series, OHLCPropClose)
+		rsi := RSIWithMA(c, 3, MATypeSMA)
+		assertSeriesVal(t, "rsi", i, v, rsi)
+	}
+}
+
 func TestMemoryLeakRSI(t *testing.T) {
 	testMemoryLeak(t, func(o OHLCVSeries) error {
 		prop := OHLCVAttr(o, OHLCPropClose)
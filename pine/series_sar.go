@@ -0,0 +1,157 @@
+package pine
+
+import (
+	"fmt"
+	"math"
+)
+
+// SAR generates a ValueSeries of parabolic SAR (parabolic stop and reverse).
+// It follows TradingView's reference implementation of ta.sar.
+//
+// The acceleration factor starts at start and increases by inc every time a new extreme point is made, up to max.
+// When the price crosses SAR, the trend reverses and SAR starts from the extreme point of the previous trend.
+//
+// The arguments are:
+//   - o: OHLCVSeries - source of data
+//   - start: float64 - initial acceleration factor. 0.02 is commonly used
+//   - inc: float64 - increment of the acceleration factor. 0.02 is commonly used
+//   - max: float64 - maximum acceleration factor. 0.2 is commonly used
+func SAR(o OHLCVSeries, start, inc, max float64) ValueSeries {
+	key := fmt.Sprintf("sar:%s:%v:%v:%v", o.ID(), start, inc, max)
+	sar := getCache(key)
+	if sar == nil {
+		sar = NewValueSeries()
+	}
+
+	// extreme point, acceleration factor and whether SAR is below the price are the state of the next value
+	epkey := fmt.Sprintf("sarep:%s:%v:%v:%v", o.ID(), start, inc, max)
+	ep := getCache(epkey)
+	if ep == nil {
+		ep = NewValueSeries()
+	}
+
+	afkey := fmt.Sprintf("saraf:%s:%v:%v:%v", o.ID(), start, inc, max)
+	af := getCache(afkey)
+	if af == nil {
+		af = NewValueSeries()
+	}
+
+	belowkey := fmt.Sprintf("sarbelow:%s:%v:%v:%v", o.ID(), start, inc, max)
+	below := getCache(belowkey)
+	if below == nil {
+		below = NewValueSeries()
+	}
+
+	stop := o.Current()
+	if stop == nil {
+		return sar
+	}
+
+	var cur *OHLCV
+	if last := sar.GetLast(); last != nil {
+		cur = ohlcvAfter(o, last.t)
+	} else {
+		cur = o.GetFirst()
+	}
+
+	for {
+		if cur == nil {
+			break
+		}
+
+		prev := cur.prev
+		if prev != nil {
+			var result, maxMin, accel float64
+			var isBelow, isFirstTrendBar bool
+
+			if prevsar := sar.Get(prev.S); prevsar != nil {
+				result = prevsar.v
+				maxMin = ep.Get(prev.S).v
+				accel = af.Get(prev.S).v
+				isBelow = below.Get(prev.S).v == 1
+			} else {
+				// first bar with a previous bar
+				if cur.C > prev.C {
+					isBelow = true
+					maxMin = cur.H
+					result = prev.L
+				} else {
+					isBelow = false
+					maxMin = cur.L
+					result = prev.H
+				}
+				isFirstTrendBar = true
+				accel = start
+			}
+
+			result = result + accel*(maxMin-result)
+
+			if isBelow {
+				if result > cur.L {
+					isFirstTrendBar = true
+					isBelow = false
+					result = math.Max(cur.H, maxMin)
+					maxMin = cur.L
+					accel = start
+				}
+			} else {
+				if result < cur.H {
+					isFirstTrendBar = true
+					isBelow = true
+					result = math.Min(cur.L, maxMin)
+					maxMin = cur.H
+					accel = start
+				}
+			}
+
+			if !isFirstTrendBar {
+				if isBelow {
+					if cur.H > maxMin {
+						maxMin = cur.H
+						accel = math.Min(accel+inc, max)
+					}
+				} else {
+					if cur.L < maxMin {
+						maxMin = cur.L
+						accel = math.Min(accel+inc, max)
+					}
+				}
+			}
+
+			if isBelow {
+				result = math.Min(result, prev.L)
+				if prev.prev != nil {
+					result = math.Min(result, prev.prev.L)
+				}
+			} else {
+				result = math.Max(result, prev.H)
+				if prev.prev != nil {
+					result = math.Max(result, prev.prev.H)
+				}
+			}
+
+			var b float64
+			if isBelow {
+				b = 1
+			}
+			sar.Set(cur.S, result)
+			ep.Set(cur.S, maxMin)
+			af.Set(cur.S, accel)
+			below.Set(cur.S, b)
+		}
+
+		if cur.S.Equal(stop.S) {
+			break
+		}
+		cur = cur.next
+	}
+
+	setCache(key, sar)
+	setCache(epkey, ep)
+	setCache(afkey, af)
+	setCache(belowkey, below)
+
+	sar.SetCurrent(stop.S)
+
+	return sar
+}
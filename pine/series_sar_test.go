@@ -0,0 +1,214 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesSARNoData tests no data scenario
+func TestSeriesSARNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sar := SAR(series, 0.02, 0.02, 0.2)
+	if sar == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if sar.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *sar.Val())
+	}
+}
+
+// TestSeriesSARIteration tests the output against values of TradingView's reference implementation
+func TestSeriesSARIteration(t *testing.T) {
+	testTable := []struct {
+		start float64
+		inc   float64
+		max   float64
+		exp   []*float64
+	}{
+		{
+			start: 0.02,
+			inc:   0.02,
+			max:   0.2,
+			exp: []*float64{
+				nil,
+				NewFloat64(11.1000),
+				NewFloat64(19.7000),
+				NewFloat64(10.3000),
+				NewFloat64(10.3000),
+				NewFloat64(10.4860),
+				NewFloat64(10.8586),
+				NewFloat64(19.9000),
+				NewFloat64(19.9000),
+				NewFloat64(19.9000),
+			},
+		},
+		{
+			start: 0.1,
+			inc:   0.1,
+			max:   0.3,
+			exp: []*float64{
+				nil,
+				NewFloat64(11.1000),
+				NewFloat64(19.7000),
+				NewFloat64(10.3000),
+				NewFloat64(19.6000),
+				NewFloat64(11.2000),
+				NewFloat64(11.2000),
+				NewFloat64(19.9000),
+				NewFloat64(10.3000),
+				NewFloat64(19.9000),
+			},
+		},
+	}
+
+	for _, tt := range testTable {
+		series, err := NewOHLCVSeries(OHLCVStaticTestData())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, v := range tt.exp {
+			series.Next()
+			sar := SAR(series, tt.start, tt.inc, tt.max)
+			assertSeriesVal(t, "sar", i, v, sar)
+		}
+	}
+}
+
+// TestSeriesSARReference tests SAR(0.02, 0.02, 0.2) against TA-Lib's SAR(0.02, 0.2) on referenceTestData from the 13th bar,
+// when the start up of both has settled, to the 52nd bar.
+//
+// TA-Lib clamps the next SAR with the last two lows (highs) before checking for a reversal, while TradingView checks
+// the unclamped SAR against the current low (high) and clamps afterwards. The 53rd bar is where the two differ,
+// which TestSeriesSARReversalBeforeClamp covers.
+func TestSeriesSARReference(t *testing.T) {
+	exp := []float64{
+		93.5600, 93.7522, 93.9406, 94.1251, 94.3060, 94.4833, 94.6571, 94.8273,
+		94.9942, 103.1700, 103.0026, 102.8385, 102.5050, 102.1848, 94.5000, 94.6612,
+		94.8192, 102.5600, 102.4044, 102.2519, 102.1025, 101.9560, 101.8125, 101.6719,
+		101.5340, 101.1691, 100.8187, 100.4823, 100.1595, 99.6615, 99.0126, 98.0333,
+		97.1520, 96.0717, 94.6911, 93.0509, 91.3264, 89.9122, 89.3900, 83.4700,
+	}
+
+	assertReferenceRange(t, "sar", 12, exp, func(o OHLCVSeries) ValueSeries {
+		return SAR(o, 0.02, 0.02, 0.2)
+	})
+}
+
+// TestSeriesSARReversalBeforeClamp tests that a reversal is checked before the SAR is clamped by the last two lows
+// as in TradingView's reference implementation
+//
+// At the 52nd bar the trend reverses up with SAR at the extreme point 83.47 and the extreme point at the high 89.58.
+// At the 53rd bar SAR = 83.47 + 0.02 * (89.58 - 83.47) = 83.5922, which is above the low of 83.57 and reverses
+// the trend down with SAR at the extreme point 89.58. TA-Lib clamps it to 83.55 first and stays up.
+func TestSeriesSARReversalBeforeClamp(t *testing.T) {
+	data := referenceTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 53; i++ {
+		series.Next()
+		sar := SAR(series, 0.02, 0.02, 0.2)
+		switch i {
+		case 51:
+			assertSeriesVal(t, "sar", i, NewFloat64(83.47), sar)
+		case 52:
+			assertSeriesVal(t, "sar", i, NewFloat64(89.58), sar)
+		}
+	}
+}
+
+// TestSeriesSARSetMax tests that the state is carried over after the series is trimmed
+func TestSeriesSARSetMax(t *testing.T) {
+	series, err := NewOHLCVSeries(OHLCVStaticTestData())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		nil,
+		NewFloat64(11.1000),
+		NewFloat64(19.7000),
+		NewFloat64(10.3000),
+		NewFloat64(19.6000),
+		NewFloat64(11.2000),
+		NewFloat64(11.2000),
+		NewFloat64(19.9000),
+		NewFloat64(10.3000),
+		NewFloat64(19.9000),
+	}
+
+	for i, v := range tests {
+		series.Next()
+		sar := SAR(series, 0.1, 0.1, 0.3)
+		sar.SetMax(1)
+		assertSeriesVal(t, "sar", i, v, sar)
+	}
+}
+
+// TestSeriesSARSourceTrimmed tests that SAR resumes from the first remaining OHLCV
+// when the last OHLCV it was generated for is trimmed from the source between calls
+func TestSeriesSARSourceTrimmed(t *testing.T) {
+	data := OHLCVStaticTestData()
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		series.Next()
+		SAR(series, 0.02, 0.02, 0.2)
+	}
+
+	// skip the 4th to 7th OHLCV and trim the first 4
+	for i := 3; i < 7; i++ {
+		series.Next()
+	}
+	series.SetMax(int64(len(data) - 4))
+
+	// the remaining OHLCVs are expected to be generated as if the series started at the 5th OHLCV
+	fresh, err := NewOHLCVSeries(data[4:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	fresh.Next()
+	fresh.Next()
+
+	for i := 6; i < len(data); i++ {
+		fresh.Next()
+		sar := SAR(series, 0.02, 0.02, 0.2)
+		assertSeriesVal(t, "sar", i, SAR(fresh, 0.02, 0.02, 0.2).Val(), sar)
+		series.Next()
+	}
+}
+
+func TestMemoryLeakSAR(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		SAR(o, 0.02, 0.02, 0.2)
+		return nil
+	})
+}
+
+func ExampleSAR() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		sar := SAR(series, 0.02, 0.02, 0.2)
+		log.Printf("SAR: %+v", sar.Val())
+	}
+}
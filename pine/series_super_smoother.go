@@ -0,0 +1,55 @@
+package pine
+
+import (
+	"fmt"
+	"math"
+)
+
+// SuperSmoother generates a ValueSeries of Ehlers' two-pole super smoother filter.
+// It removes aliasing noise with less lag than a moving average of the same length.
+//
+// The formula for SuperSmoother is
+//   - a1 = exp(-1.414 * π / l)
+//   - c2 = 2 * a1 * cos(1.414 * π / l)
+//   - c3 = -a1 * a1
+//   - c1 = 1 - c2 - c3
+//   - ss = c1 * (p + p[1]) / 2 + c2 * ss[1] + c3 * ss[2]
+//
+// The source value is used until two previous values are available as in Ehlers' Cycle Analytics for Traders.
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - l - int64: critical period of the filter [1, ∞)
+func SuperSmoother(p ValueSeries, l int64) ValueSeries {
+	key := fmt.Sprintf("supersmoother:%s:%d", p.ID(), l)
+	ss := getCache(key)
+	if ss == nil {
+		ss = NewValueSeries()
+	}
+
+	if p == nil || p.GetCurrent() == nil {
+		return ss
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+
+	a1 := math.Exp(-1.414 * math.Pi / float64(l))
+	c2 := 2 * a1 * math.Cos(1.414*math.Pi/float64(l))
+	c3 := -a1 * a1
+	c1 := 1 - c2 - c3
+
+	ss = generateFilter(*stop, p, ss, 2, func(v *Value, prev []*float64) *float64 {
+		w := windowValues(v, 2)
+		if w == nil || prev[0] == nil || prev[1] == nil {
+			return NewFloat64(v.v)
+		}
+		return NewFloat64(c1*(w[1]+w[0])/2 + c2**prev[0] + c3**prev[1])
+	})
+
+	setCache(key, ss)
+
+	ss.SetCurrent(stop.t)
+
+	return ss
+}
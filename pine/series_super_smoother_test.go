@@ -0,0 +1,102 @@
+package pine
+
+import (
+	"log"
+	"math"
+	"testing"
+	"time"
+)
+
+// TestSeriesSuperSmootherNoData tests no data scenario
+func TestSeriesSuperSmootherNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prop := OHLCVAttr(series, OHLCPropClose)
+	ss := SuperSmoother(prop, 4)
+	if ss == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if ss.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *ss.Val())
+	}
+}
+
+// TestSeriesSuperSmootherReference tests SuperSmoother(close, 10) against Ehlers' SuperSmoother function in Cycle Analytics for Traders on referenceTestData,
+// which uses the price for the first two values
+func TestSeriesSuperSmootherReference(t *testing.T) {
+	exp := []float64{
+		103.0500, 99.5200, 97.9492, 97.0413, 96.3600, 96.1443, 96.5912, 97.2949,
+		97.4502, 97.3862, 97.5899, 98.0699, 98.8799, 99.5169, 99.6024, 99.3889,
+		98.8774, 98.4436, 98.1377, 97.6505, 97.4740, 97.4080, 96.9941, 96.7190,
+		97.0656, 97.9458, 99.0861, 100.1294, 100.5384, 99.9932, 99.1480, 98.5882,
+		98.2989, 98.3119, 98.2370, 98.0399, 97.4766, 96.4535, 95.5230, 94.8775,
+		94.2520, 93.5259, 92.7904, 92.0675, 91.3722, 90.3186, 88.7858, 87.3805,
+		86.8733, 86.8119, 86.4748, 86.3869, 86.3439, 86.0797, 85.7116, 84.9359,
+		84.3925, 84.9000, 86.0289, 86.9453,
+	}
+
+	assertReference(t, "supersmoother", 0, exp, func(o OHLCVSeries) ValueSeries {
+		return SuperSmoother(OHLCVAttr(o, OHLCPropClose), 10)
+	})
+}
+
+// TestSeriesSuperSmootherFlat tests that the filter has the gain of 1 for a flat source
+func TestSeriesSuperSmootherFlat(t *testing.T) {
+	assertFlat(t, "supersmoother", 0, func(p ValueSeries) ValueSeries {
+		return SuperSmoother(p, 10)
+	})
+}
+
+// TestSeriesSuperSmootherResponse tests the response to sines. The two-pole Butterworth filter has the gain of 1/√2 at the critical period,
+// which the average of the two latest values lowers by cos(π / l) as described in Ehlers' Cycle Analytics for Traders.
+func TestSeriesSuperSmootherResponse(t *testing.T) {
+	fn := func(p ValueSeries) ValueSeries {
+		return SuperSmoother(p, 10)
+	}
+
+	if a := sineAmplitude(t, 10, fn); math.Abs(a-math.Cos(math.Pi/10)/math.Sqrt2) > 0.01 {
+		t.Errorf("Expected the amplitude at the critical period to be %.4f but got %.4f", math.Cos(math.Pi/10)/math.Sqrt2, a)
+	}
+	if a := sineAmplitude(t, 100, fn); a < 0.99 {
+		t.Errorf("Expected a long cycle to pass but got the amplitude of %.4f", a)
+	}
+	if a := sineAmplitude(t, 4, fn); a > 0.2 {
+		t.Errorf("Expected a short cycle to be removed but got the amplitude of %.4f", a)
+	}
+}
+
+func TestSeriesSuperSmootherSourceTrimmed(t *testing.T) {
+	assertSourceTrimmed(t, "supersmoother", 3, func(p ValueSeries) ValueSeries {
+		return SuperSmoother(p, 10)
+	})
+}
+
+func TestMemoryLeakSuperSmoother(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		SuperSmoother(prop, 4)
+		return nil
+	})
+}
+
+func ExampleSuperSmoother() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		ss := SuperSmoother(prop, 4)
+		log.Printf("SuperSmoother: %+v", ss.Val())
+	}
+}
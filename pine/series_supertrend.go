@@ -0,0 +1,128 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// Supertrend generates ValueSeries of supertrend line and its direction in that order.
+// The direction is -1 when the trend is up (the line is below the price) and 1 when the trend is down.
+//
+// The formula for Supertrend is
+//   - atr = rma(tr, atrl)
+//   - upper = hl2 + factor * atr, which only moves down unless the previous close is above the previous upper
+//   - lower = hl2 - factor * atr, which only moves up unless the previous close is below the previous lower
+//   - direction flips to -1 when close crosses above the upper band and to 1 when close crosses below the lower band
+//   - supertrend = lower if direction is -1 and upper otherwise
+//
+// The arguments are:
+//   - o: OHLCVSeries - source of data
+//   - factor: float64 - multiplier of ATR
+//   - atrl: int64 - lookback length of ATR
+func Supertrend(o OHLCVSeries, factor float64, atrl int64) (supertrend, direction ValueSeries) {
+	stkey := fmt.Sprintf("supertrend:%s:%v:%d", o.ID(), factor, atrl)
+	supertrend = getCache(stkey)
+	if supertrend == nil {
+		supertrend = NewValueSeries()
+	}
+
+	dirkey := fmt.Sprintf("supertrenddir:%s:%v:%d", o.ID(), factor, atrl)
+	direction = getCache(dirkey)
+	if direction == nil {
+		direction = NewValueSeries()
+	}
+
+	// bands after ratcheting, which are the state of the next value
+	upperkey := fmt.Sprintf("supertrendupper:%s:%v:%d", o.ID(), factor, atrl)
+	upper := getCache(upperkey)
+	if upper == nil {
+		upper = NewValueSeries()
+	}
+
+	lowerkey := fmt.Sprintf("supertrendlower:%s:%v:%d", o.ID(), factor, atrl)
+	lower := getCache(lowerkey)
+	if lower == nil {
+		lower = NewValueSeries()
+	}
+
+	stop := o.Current()
+	if stop == nil {
+		return supertrend, direction
+	}
+
+	atr := ATR(OHLCVAttr(o, OHLCPropTRHL), atrl)
+
+	f := operationGetStart(atr, supertrend)
+	for {
+		if f == nil {
+			break
+		}
+
+		cur := o.Get(f.t)
+		prev := cur.prev
+
+		hl2 := (cur.H + cur.L) / 2
+		up := hl2 + factor*f.v
+		lo := hl2 - factor*f.v
+
+		var prevup, prevlo float64
+		var prevst, prevatr *Value
+		if prev != nil {
+			if v := upper.Get(prev.S); v != nil {
+				prevup = v.v
+			}
+			if v := lower.Get(prev.S); v != nil {
+				prevlo = v.v
+			}
+			prevst = supertrend.Get(prev.S)
+			prevatr = atr.Get(prev.S)
+		}
+
+		if !(lo > prevlo || (prev != nil && prev.C < prevlo)) {
+			lo = prevlo
+		}
+		if !(up < prevup || (prev != nil && prev.C > prevup)) {
+			up = prevup
+		}
+
+		var dir float64
+		switch {
+		case prevatr == nil:
+			dir = 1
+		case prevst != nil && prevst.v == prevup:
+			dir = 1
+			if cur.C > up {
+				dir = -1
+			}
+		default:
+			dir = -1
+			if cur.C < lo {
+				dir = 1
+			}
+		}
+
+		st := up
+		if dir == -1 {
+			st = lo
+		}
+
+		upper.Set(f.t, up)
+		lower.Set(f.t, lo)
+		direction.Set(f.t, dir)
+		supertrend.Set(f.t, st)
+
+		if f.t.Equal(stop.S) {
+			break
+		}
+		f = f.next
+	}
+
+	setCache(upperkey, upper)
+	setCache(lowerkey, lower)
+	setCache(stkey, supertrend)
+	setCache(dirkey, direction)
+
+	supertrend.SetCurrent(stop.S)
+	direction.SetCurrent(stop.S)
+
+	return supertrend, direction
+}
@@ -0,0 +1,120 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesSupertrendNoData tests no data scenario
+func TestSeriesSupertrendNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st, dir := Supertrend(series, 3, 10)
+	if st == nil || dir == nil {
+		t.Fatal("Expected to be non nil but got nil")
+	}
+	if st.Val() != nil || dir.Val() != nil {
+		t.Error("Expected to be nil but got non nil")
+	}
+}
+
+// TestSeriesSupertrendIteration tests Supertrend(0.5, 3) against the values worked out by hand by following
+// pine_supertrend in TradingView's reference of ta.supertrend
+//
+// t=time.Time     | 1    | 2    | 3       | 4       | 5       | 6       | 7       | 8       | 9       | 10      |
+// tr              | 8.6  | 6.8  | 8.5     | 7.9     | 8.3     | 6.3     | 6.6     | 9.6     | 8       | 7.6     |
+// atr = rma(tr,3) |      |      | 7.9667  | 7.9444  | 8.063   | 7.4753  | 7.1835  | 7.989   | 7.9927  | 7.8618  |
+// upperBand       |      |      | 18.5333 | 18.5333 | 18.5333 | 20.3877 | 19.7918 | 19.0945 | 19.0945 | 17.7309 |
+// lowerBand       |      |      | 10.5667 | 11.6778 | 11.6778 | 12.9123 | 12.9123 | 12.9123 | 11.7037 | 11.7037 |
+// direction       |      |      | 1       | 1       | -1      | -1      | -1      | 1       | 1       | 1       |
+//
+// The direction flips twice and the lower band is ratcheted at the 7th bar
+func TestSeriesSupertrendIteration(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// array in order of supertrend, direction
+	tests := [][]*float64{
+		{nil, nil},
+		{nil, nil},
+		{NewFloat64(18.5333), NewFloat64(1)},
+		{NewFloat64(18.5333), NewFloat64(1)},
+		{NewFloat64(11.6778), NewFloat64(-1)},
+		{NewFloat64(12.9123), NewFloat64(-1)},
+		{NewFloat64(12.9123), NewFloat64(-1)},
+		{NewFloat64(19.0945), NewFloat64(1)},
+		{NewFloat64(19.0945), NewFloat64(1)},
+		{NewFloat64(17.7309), NewFloat64(1)},
+	}
+
+	for i, v := range tests {
+		series.Next()
+		st, dir := Supertrend(series, 0.5, 3)
+		assertSeriesVal(t, "supertrend", i, v[0], st)
+		assertSeriesVal(t, "direction", i, v[1], dir)
+	}
+}
+
+// TestSeriesSupertrendSetMax tests that the state is carried over after the series are trimmed
+func TestSeriesSupertrendSetMax(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// array in order of supertrend, direction
+	tests := [][]*float64{
+		{nil, nil},
+		{nil, nil},
+		{NewFloat64(18.5333), NewFloat64(1)},
+		{NewFloat64(18.5333), NewFloat64(1)},
+		{NewFloat64(11.6778), NewFloat64(-1)},
+		{NewFloat64(12.9123), NewFloat64(-1)},
+		{NewFloat64(12.9123), NewFloat64(-1)},
+		{NewFloat64(19.0945), NewFloat64(1)},
+		{NewFloat64(19.0945), NewFloat64(1)},
+		{NewFloat64(17.7309), NewFloat64(1)},
+	}
+
+	for i, v := range tests {
+		series.Next()
+		st, dir := Supertrend(series, 0.5, 3)
+		st.SetMax(1)
+		dir.SetMax(1)
+		assertSeriesVal(t, "supertrend", i, v[0], st)
+		assertSeriesVal(t, "direction", i, v[1], dir)
+	}
+}
+
+func TestMemoryLeakSupertrend(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		Supertrend(o, 3, 10)
+		return nil
+	})
+}
+
+func ExampleSupertrend() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		st, dir := Supertrend(series, 3, 10)
+		log.Printf("Supertrend: %+v, direction: %+v", st.Val(), dir.Val())
+	}
+}
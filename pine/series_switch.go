@@ -0,0 +1,96 @@
+package pine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SwitchCase is a branch of Switch, which is cond => val in PineScript
+type SwitchCase struct {
+	Cond ValueSeries
+	Val  ValueSeries
+}
+
+// SwitchConstCase is a branch of SwitchConst with a constant value
+type SwitchConstCase struct {
+	Cond ValueSeries
+	Val  float64
+}
+
+// Switch generates a ValueSeries of the value of the first case whose condition is true (non-zero), otherwise the value of def.
+//
+// The values are generated at the times of the condition of the first case.
+// A condition without a value at the time is treated as false.
+// There is no value where the selected value does not have a value, including where def is nil and no condition is true.
+// A time without a value is filled in once the selected value has been generated at the time, until the time is trimmed from the condition of the first case.
+//
+// Parameters
+//   - def - ValueSeries: default value where no condition is true. nil for no value
+//   - cases - ...SwitchCase: branches evaluated in order. Val of nil has no value
+func Switch(def ValueSeries, cases ...SwitchCase) ValueSeries {
+	if len(cases) == 0 {
+		if def == nil {
+			return NewValueSeries()
+		}
+		return def
+	}
+
+	ids := make([]string, 0)
+	for _, v := range cases {
+		ids = append(ids, v.Cond.ID(), switchBranchID(v.Val))
+	}
+	ids = append(ids, switchBranchID(def))
+	key := fmt.Sprintf("switch:%s", strings.Join(ids, ":"))
+
+	return operationJoin(cases[0].Cond, key, func(c *Value) *float64 {
+		for _, v := range cases {
+			if switchCondition(v.Cond, c) {
+				return branchValue(v.Val, c)
+			}
+		}
+		return branchValue(def, c)
+	}, true, true)
+}
+
+// SwitchConst generates a ValueSeries of the constant of the first case whose condition is true (non-zero), otherwise def.
+//
+// The values are generated at the times of the condition of the first case.
+// A condition without a value at the time is treated as false.
+//
+// Parameters
+//   - def - float64: default value where no condition is true
+//   - cases - ...SwitchConstCase: branches evaluated in order
+func SwitchConst(def float64, cases ...SwitchConstCase) ValueSeries {
+	if len(cases) == 0 {
+		return NewValueSeries()
+	}
+
+	keys := make([]string, 0)
+	for _, v := range cases {
+		keys = append(keys, fmt.Sprintf("%s:%+v", v.Cond.ID(), v.Val))
+	}
+	key := fmt.Sprintf("switchconst:%s:%+v", strings.Join(keys, ":"), def)
+
+	return operationJoin(cases[0].Cond, key, func(c *Value) *float64 {
+		for _, v := range cases {
+			if switchCondition(v.Cond, c) {
+				return NewFloat64(v.Val)
+			}
+		}
+		return NewFloat64(def)
+	}, true, false)
+}
+
+// switchCondition returns true if cond has a non-zero value at the time of c
+func switchCondition(cond ValueSeries, c *Value) bool {
+	v := cond.Get(c.t)
+	return v != nil && v.v != 0
+}
+
+// switchBranchID returns the ID of the branch for the cache key. nil is a branch without a value
+func switchBranchID(branch ValueSeries) string {
+	if branch == nil {
+		return "na"
+	}
+	return branch.ID()
+}
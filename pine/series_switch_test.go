@@ -0,0 +1,124 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesSwitchNoData tests no data scenario
+func TestSeriesSwitchNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := OHLCVAttr(series, OHLCPropClose)
+	sw := Switch(c, SwitchCase{Cond: Rising(c, 1), Val: OHLCVAttr(series, OHLCPropHigh)})
+	if sw == nil {
+		t.Fatal("Expected to be non nil but got nil")
+	}
+	if sw.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *sw.Val())
+	}
+}
+
+// TestSeriesSwitchIteration tests that the first case with the true condition is selected
+//
+// close              | 16.5 | 18.7 | 18.2 | 11.9 | 19.3 | 14.2 | 14.4 | 11.0 | 14.7 | 10.3 |
+// rising(close, 1)   |      | 1    | 0    | 0    | 1    | 0    | 1    | 0    | 1    | 0    |
+// falling(close, 2)  |      |      | 0    | 1    | 0    | 0    | 0    | 1    | 0    | 1    |
+// high               | 19.7 | 19.1 | 18.8 | 19.6 | 19.5 | 19.8 | 19.5 | 19.9 | 19.0 | 17.6 |
+// low                | 11.1 | 12.3 | 10.3 | 11.7 | 11.2 | 13.5 | 12.9 | 10.3 | 12.4 | 10.0 |
+// switch             |      | 19.1 | 18.2 | 11.7 | 19.5 | 14.2 | 19.5 | 10.3 | 19.0 | 10.0 |
+// switch (no default)|      | 19.1 |      | 11.7 | 19.5 |      | 19.5 | 10.3 | 19.0 | 10.0 |
+// switchconst        |      | 1    | 0    | -1   | 1    | 0    | 1    | -1   | 1    | -1   |
+func TestSeriesSwitchIteration(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// array in order of sw, swnodef, swconst
+	tests := [][]*float64{
+		{nil, nil, nil},
+		{NewFloat64(19.1), NewFloat64(19.1), NewFloat64(1)},
+		{NewFloat64(18.2), nil, NewFloat64(0)},
+		{NewFloat64(11.7), NewFloat64(11.7), NewFloat64(-1)},
+		{NewFloat64(19.5), NewFloat64(19.5), NewFloat64(1)},
+		{NewFloat64(14.2), nil, NewFloat64(0)},
+		{NewFloat64(19.5), NewFloat64(19.5), NewFloat64(1)},
+		{NewFloat64(10.3), NewFloat64(10.3), NewFloat64(-1)},
+		{NewFloat64(19.0), NewFloat64(19.0), NewFloat64(1)},
+		{NewFloat64(10.0), NewFloat64(10.0), NewFloat64(-1)},
+	}
+
+	for i, v := range tests {
+		series.Next()
+		c := OHLCVAttr(series, OHLCPropClose)
+		rising := Rising(c, 1)
+		falling := Falling(c, 2)
+		h := OHLCVAttr(series, OHLCPropHigh)
+		l := OHLCVAttr(series, OHLCPropLow)
+
+		sw := Switch(c, SwitchCase{Cond: rising, Val: h}, SwitchCase{Cond: falling, Val: l})
+		swnodef := Switch(nil, SwitchCase{Cond: rising, Val: h}, SwitchCase{Cond: falling, Val: l})
+		swconst := SwitchConst(0, SwitchConstCase{Cond: rising, Val: 1}, SwitchConstCase{Cond: falling, Val: -1})
+		assertSeriesVal(t, "sw", i, v[0], sw)
+		assertSeriesVal(t, "swnodef", i, v[1], swnodef)
+		assertSeriesVal(t, "swconst", i, v[2], swconst)
+	}
+}
+
+// TestSeriesSwitchNilCase tests that a case without a value does not fall through to the following cases
+func TestSeriesSwitchNilCase(t *testing.T) {
+	now := time.Now()
+	c1 := NewValueSeries()
+	c2 := NewValueSeries()
+	val := NewValueSeries()
+	for i, v := range []float64{1, 0} {
+		ti := now.Add(time.Duration(i) * time.Second)
+		c1.Set(ti, v)
+		c2.Set(ti, 1)
+		val.Set(ti, 5)
+	}
+
+	sw := Switch(nil, SwitchCase{Cond: c1, Val: nil}, SwitchCase{Cond: c2, Val: val})
+	if v := sw.Get(now); v != nil {
+		t.Errorf("Expected to be nil but got %+v", v.v)
+	}
+	if v := sw.Get(now.Add(time.Second)); v == nil || v.v != 5 {
+		t.Errorf("Expected to be 5 but got %+v", v)
+	}
+}
+
+func TestMemoryLeakSwitch(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		c := OHLCVAttr(o, OHLCPropClose)
+		Switch(c, SwitchCase{Cond: Rising(c, 1), Val: OHLCVAttr(o, OHLCPropHigh)}, SwitchCase{Cond: Falling(c, 2), Val: OHLCVAttr(o, OHLCPropLow)})
+		return nil
+	})
+}
+
+func ExampleSwitch() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		c := OHLCVAttr(series, OHLCPropClose)
+		signal := SwitchConst(0,
+			SwitchConstCase{Cond: Crossover(c, SMA(c, 20)), Val: 1},
+			SwitchConstCase{Cond: Crossunder(c, SMA(c, 20)), Val: -1},
+		)
+		log.Printf("Signal: %+v", signal.Val())
+	}
+}
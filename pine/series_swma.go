@@ -0,0 +1,37 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// SWMA generates a ValueSeries of symmetrically weighted moving average with fixed length of 4.
+//
+// The formula for SWMA is
+//   - swma = p[3] * 1/6 + p[2] * 2/6 + p[1] * 2/6 + p[0] * 1/6
+//
+// Parameters
+//   - p - ValueSeries: source data
+func SWMA(p ValueSeries) ValueSeries {
+	key := fmt.Sprintf("swma:%s", p.ID())
+	swma := getCache(key)
+	if swma == nil {
+		swma = NewValueSeries()
+	}
+
+	if p == nil || p.GetCurrent() == nil {
+		return swma
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+
+	swma = generateWindow(*stop, p, swma, 4, func(w []float64) float64 {
+		return w[0]*1/6 + w[1]*2/6 + w[2]*2/6 + w[3]*1/6
+	})
+
+	setCache(key, swma)
+
+	swma.SetCurrent(stop.t)
+
+	return swma
+}
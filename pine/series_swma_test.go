@@ -0,0 +1,48 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesSWMAReference tests SWMA(close) against TA-Lib's TRIMA with a period of 4, whose weights are also 1/6, 2/6, 2/6 and 1/6
+func TestSeriesSWMAReference(t *testing.T) {
+	exp := []float64{
+		99.0833, 97.2633, 96.4517, 96.9000, 97.8400, 97.8900, 97.4517, 97.3350,
+		98.1317, 99.3133, 99.9083, 99.7967, 99.1733, 98.3850, 98.1100, 97.8833,
+		97.5483, 97.4650, 97.3183, 97.0417, 96.6533, 96.9983, 98.3650, 99.8450,
+		100.7850, 100.8083, 99.7083, 98.4367, 97.8567, 98.0683, 98.4950, 98.3900,
+		98.1317, 97.2367, 96.0217, 94.9750, 94.4250, 94.1267, 93.4467, 92.5517,
+		91.7767, 91.1417, 89.9883, 88.2700, 86.6800, 86.4533, 86.9467, 86.8983,
+		86.5700, 86.2050, 86.1350, 85.5950, 84.5567, 84.1167, 84.8583, 86.6217,
+		87.8767,
+	}
+
+	assertReference(t, "swma", 3, exp, func(o OHLCVSeries) ValueSeries {
+		return SWMA(OHLCVAttr(o, OHLCPropClose))
+	})
+}
+
+func TestMemoryLeakSWMA(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		SWMA(prop)
+		return nil
+	})
+}
+
+func ExampleSWMA() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		swma := SWMA(prop)
+		log.Printf("SWMA: %+v", swma.Val())
+	}
+}
@@ -0,0 +1,58 @@
+package pine
+
+import (
+	"fmt"
+	"math"
+)
+
+// T3 generates a ValueSeries of Tillson T3 moving average.
+// It is a weighted sum of six chained EMAs controlled by the volume factor.
+//
+// The formula for T3 is
+//   - e1 = ema(p, l), e2 = ema(e1, l), ... e6 = ema(e5, l)
+//   - c1 = -a^3
+//   - c2 = 3a^2 + 3a^3
+//   - c3 = -6a^2 - 3a - 3a^3
+//   - c4 = 1 + 3a + a^3 + 3a^2
+//   - t3 = c1 * e6 + c2 * e5 + c3 * e4 + c4 * e3
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - l - int64: lookback periods [1, ∞)
+//   - a - float64: volume factor. 0 is same as TEMA and 0.7 is commonly used
+func T3(p ValueSeries, l int64, a float64) ValueSeries {
+	key := fmt.Sprintf("t3:%s:%d:%v", p.ID(), l, a)
+	t3 := getCache(key)
+	if t3 == nil {
+		t3 = NewValueSeries()
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+	if stop == nil {
+		return t3
+	}
+
+	e1 := EMA(p, l)
+	e2 := EMA(e1, l)
+	e3 := EMA(e2, l)
+	e4 := EMA(e3, l)
+	e5 := EMA(e4, l)
+	e6 := EMA(e5, l)
+
+	c1 := -1 * math.Pow(a, 3)
+	c2 := 3*math.Pow(a, 2) + 3*math.Pow(a, 3)
+	c3 := -6*math.Pow(a, 2) - 3*a - 3*math.Pow(a, 3)
+	c4 := 1 + 3*a + math.Pow(a, 3) + 3*math.Pow(a, 2)
+
+	t3 = Add(
+		Add(MulConst(e6, c1), MulConst(e5, c2)),
+		Add(MulConst(e4, c3), MulConst(e3, c4)),
+	)
+
+	setCache(key, t3)
+
+	t3.SetCurrent(stop.t)
+
+	return t3
+}
@@ -0,0 +1,59 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesT3Reference tests T3(close, 5, 0.7) against TA-Lib's T3
+func TestSeriesT3Reference(t *testing.T) {
+	exp := []float64{
+		97.0622, 97.6296, 98.5112, 99.4011, 99.9160, 99.7632, 99.4423, 99.0523,
+		98.7815, 98.6488, 98.4179, 98.2084, 97.6185, 96.8092, 95.9854, 95.2910,
+		94.5264, 93.7714, 92.9772, 92.2280, 91.4703, 90.4171, 89.0653, 87.8118,
+		87.1573, 86.6612, 86.1736, 86.0458, 85.8276, 85.6654, 85.3521, 84.7631,
+		84.4274, 84.7648, 85.4664, 86.1777,
+	}
+
+	assertReference(t, "t3", 24, exp, func(o OHLCVSeries) ValueSeries {
+		return T3(OHLCVAttr(o, OHLCPropClose), 5, 0.7)
+	})
+}
+
+// TestSeriesT3Flat tests that a flat window returns the flat value
+func TestSeriesT3Flat(t *testing.T) {
+	assertFlat(t, "t3", 24, func(p ValueSeries) ValueSeries {
+		return T3(p, 5, 0.7)
+	})
+}
+
+// TestSeriesT3SourceTrimmed tests that trimming the source to 6 values does not change the output
+func TestSeriesT3SourceTrimmed(t *testing.T) {
+	assertSourceTrimmed(t, "t3", 6, func(p ValueSeries) ValueSeries {
+		return T3(p, 5, 0.7)
+	})
+}
+
+func TestMemoryLeakT3(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		T3(prop, 5, 0.7)
+		return nil
+	})
+}
+
+func ExampleT3() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		t3 := T3(prop, 5, 0.7)
+		log.Printf("T3: %+v", t3.Val())
+	}
+}
@@ -0,0 +1,42 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// TEMA generates a ValueSeries of triple exponential moving average.
+//
+// The formula for TEMA is
+//   - e1 = ema(p, l)
+//   - e2 = ema(e1, l)
+//   - e3 = ema(e2, l)
+//   - tema = 3 * (e1 - e2) + e3
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - l - int64: lookback periods [1, ∞)
+func TEMA(p ValueSeries, l int64) ValueSeries {
+	key := fmt.Sprintf("tema:%s:%d", p.ID(), l)
+	tema := getCache(key)
+	if tema == nil {
+		tema = NewValueSeries()
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+	if stop == nil {
+		return tema
+	}
+
+	e1 := EMA(p, l)
+	e2 := EMA(e1, l)
+	e3 := EMA(e2, l)
+
+	tema = Add(MulConst(Sub(e1, e2), 3), e3)
+
+	setCache(key, tema)
+
+	tema.SetCurrent(stop.t)
+
+	return tema
+}
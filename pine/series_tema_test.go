@@ -0,0 +1,61 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesTEMAReference tests TEMA(close, 4) against TA-Lib's TEMA
+func TestSeriesTEMAReference(t *testing.T) {
+	exp := []float64{
+		97.4708, 98.4034, 99.5345, 101.1361, 99.6732, 99.0027, 98.5088, 97.1938,
+		98.4009, 97.1885, 96.5571, 98.2613, 96.6469, 95.7846, 96.9419, 99.0628,
+		100.3554, 101.7209, 101.5390, 99.7986, 97.0173, 97.9499, 97.7669, 98.4598,
+		98.7668, 97.5447, 97.8016, 94.9894, 94.2185, 94.0964, 94.2499, 92.7024,
+		92.2717, 91.2539, 90.9201, 89.9877, 87.2929, 85.2424, 85.5904, 88.0860,
+		86.5269, 85.5371, 87.4014, 85.4296, 85.8324, 84.4252, 82.7281, 84.7437,
+		88.0832, 88.5897, 88.0615,
+	}
+
+	assertReference(t, "tema", 9, exp, func(o OHLCVSeries) ValueSeries {
+		return TEMA(OHLCVAttr(o, OHLCPropClose), 4)
+	})
+}
+
+// TestSeriesTEMAFlat tests that a flat window returns the flat value
+func TestSeriesTEMAFlat(t *testing.T) {
+	assertFlat(t, "tema", 9, func(p ValueSeries) ValueSeries {
+		return TEMA(p, 4)
+	})
+}
+
+// TestSeriesTEMASourceTrimmed tests that trimming the source to 5 values does not change the output
+func TestSeriesTEMASourceTrimmed(t *testing.T) {
+	assertSourceTrimmed(t, "tema", 5, func(p ValueSeries) ValueSeries {
+		return TEMA(p, 4)
+	})
+}
+
+func TestMemoryLeakTEMA(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		TEMA(prop, 4)
+		return nil
+	})
+}
+
+func ExampleTEMA() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		tema := TEMA(prop, 4)
+		log.Printf("TEMA: %+v", tema.Val())
+	}
+}
@@ -0,0 +1,82 @@
+package pine
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// TimeEMA generates a ValueSeries of exponential moving average which decays by the elapsed time instead of the number of values.
+// The weight of a value halves every halflife so that it works on values with irregular intervals, i.e. ticks.
+//
+// The formula for TimeEMA is
+//   - alpha = 1 - 2^(-(t - t[1]) / halflife)
+//   - ema = alpha * p + (1 - alpha) * ema[1]
+//
+// The first value is the source value.
+// The elapsed time is exact to the nanosecond but p holds a value per second, so ticks within the same second have to be aggregated first, i.e. by NewTickBarBuilder.
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - halflife - time.Duration: elapsed time for the weight of a value to halve (0, ∞)
+func TimeEMA(p ValueSeries, halflife time.Duration) ValueSeries {
+	key := fmt.Sprintf("timeema:%s:%d", p.ID(), halflife)
+	ema := getCache(key)
+	if ema == nil {
+		ema = NewValueSeries()
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+	if stop == nil || halflife <= 0 {
+		return ema
+	}
+
+	s := timeEMACache[key]
+
+	var f *Value
+	if s == nil {
+		s = &timeEMAState{}
+		f = p.GetFirst()
+	} else {
+		f = valueAfter(p, s.last)
+	}
+
+	for {
+		if f == nil {
+			break
+		}
+
+		if s.last.IsZero() {
+			s.ema = f.v
+		} else {
+			alpha := 1 - math.Exp2(-float64(f.t.Sub(s.last))/float64(halflife))
+			s.ema = alpha*f.v + (1-alpha)*s.ema
+		}
+		s.last = f.t
+		ema.Set(f.t, s.ema)
+
+		if f.t.Equal(stop.t) {
+			break
+		}
+		f = f.next
+	}
+
+	setState(timeEMACache, key, s)
+
+	setCache(key, ema)
+
+	ema.SetCurrent(stop.t)
+
+	return ema
+}
+
+// timeEMAState is the state of TimeEMA carried over to the next call.
+// The time is kept as time.Time so that the decay is exact to the nanosecond.
+type timeEMAState struct {
+	ema float64
+	// time of the last processed value
+	last time.Time
+}
+
+var timeEMACache map[string]*timeEMAState = make(map[string]*timeEMAState)
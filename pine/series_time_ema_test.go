@@ -0,0 +1,122 @@
+package pine
+
+import (
+	"log"
+	"math"
+	"testing"
+	"time"
+)
+
+// irregularTestData generates OHLCVStaticTestData with irregular intervals
+//
+// seconds | 0    | 10   | 15   | 40   | 41   | 70   | 100  | 101  | 160  | 170  |
+// close   | 16.5 | 18.7 | 18.2 | 11.9 | 19.3 | 14.2 | 14.4 | 11.0 | 14.7 | 10.3 |
+func irregularTestData() []OHLCV {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	secs := []int{0, 10, 15, 40, 41, 70, 100, 101, 160, 170}
+	data := OHLCVStaticTestData()
+	for i := range data {
+		data[i].S = start.Add(time.Duration(secs[i]) * time.Second)
+	}
+	return data
+}
+
+// TestSeriesTimeEMANoData tests no data scenario
+func TestSeriesTimeEMANoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prop := OHLCVAttr(series, OHLCPropClose)
+	ema := TimeEMA(prop, 20*time.Second)
+	if ema == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if ema.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *ema.Val())
+	}
+}
+
+// TestSeriesTimeEMAIteration tests the output against values of the reference formula with the half-life of 20 seconds
+func TestSeriesTimeEMAIteration(t *testing.T) {
+	data := irregularTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		NewFloat64(16.5000),
+		NewFloat64(17.1444),
+		NewFloat64(17.3123),
+		NewFloat64(14.1756),
+		NewFloat64(14.3502),
+		NewFloat64(14.2550),
+		NewFloat64(14.3487),
+		NewFloat64(14.2347),
+		NewFloat64(14.6398),
+		NewFloat64(13.3687),
+	}
+
+	for i, v := range tests {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropClose)
+		ema := TimeEMA(prop, 20*time.Second)
+		assertSeriesVal(t, "ema", i, v, ema)
+	}
+}
+
+// TestSeriesTimeEMASubSecond tests that the decay is exact to the millisecond
+//
+// seconds       | 0.9 | 1.2                        |
+// p             | 1   | 2                          |
+// timeema(1s)   | 1   | 1 + (1 - 2^-0.3) * (2 - 1) |
+func TestSeriesTimeEMASubSecond(t *testing.T) {
+	p, times := subSecondTestSeries()
+	tests := []float64{1, 1 + (1 - math.Exp2(-0.3))}
+	for i, v := range tests {
+		p.SetCurrent(times[i])
+		assertSeriesVal(t, "ema", i, NewFloat64(v), TimeEMA(p, time.Second))
+	}
+}
+
+// TestSeriesTimeEMASourceTrimmed tests that trimming the source to 3 values does not change the output
+func TestSeriesTimeEMASourceTrimmed(t *testing.T) {
+	assertSourceTrimmed(t, "ema", 3, func(p ValueSeries) ValueSeries {
+		return TimeEMA(p, 20*time.Minute)
+	})
+}
+
+func TestMemoryLeakTimeEMA(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		TimeEMA(prop, 20*time.Second)
+		return nil
+	})
+}
+
+func ExampleTimeEMA() {
+	start := time.Now()
+	// ticks with 100 milliseconds to 10 seconds in between
+	data := make([]OHLCV, 0)
+	for i := 0; i < 10000; i++ {
+		start = start.Add(time.Duration(100+i%100*100) * time.Millisecond)
+		p := 100 + float64(i%17)
+		data = append(data, OHLCV{O: p, H: p, L: p, C: p, V: 1, S: start})
+	}
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		ema := TimeEMA(prop, 20*time.Second)
+		log.Printf("TimeEMA: %+v", ema.Val())
+	}
+}
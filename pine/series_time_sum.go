@@ -0,0 +1,146 @@
+package pine
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimeSum generates a ValueSeries of the summation of the values within the window of time ending at the current value.
+// A value at t is within the window if t is after the time of the current value minus the window, i.e. the last 30 seconds.
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - window - time.Duration: length of the window (0, ∞)
+func TimeSum(p ValueSeries, window time.Duration) ValueSeries {
+	sum, _ := getTimeSum(p, window)
+	return sum
+}
+
+// TimeSMA generates a ValueSeries of the simple moving average of the values within the window of time ending at the current value.
+// Every value has the same weight regardless of its interval. Use TWAP to weight the values by time.
+//
+// The formula for TimeSMA is
+//   - sma = timesum(p, window) / number of values within the window
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - window - time.Duration: length of the window (0, ∞)
+func TimeSMA(p ValueSeries, window time.Duration) ValueSeries {
+	key := fmt.Sprintf("timesma:%s:%d", p.ID(), window)
+	sma := getCache(key)
+	if sma == nil {
+		sma = NewValueSeries()
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+	if stop == nil {
+		return sma
+	}
+
+	sum, count := getTimeSum(p, window)
+	sma = Div(sum, count)
+
+	setCache(key, sma)
+
+	sma.SetCurrent(stop.t)
+
+	return sma
+}
+
+// timedValue is a value of a time window
+type timedValue struct {
+	t time.Time
+	v float64
+}
+
+// timeSumState is the state of the time window carried over to the next call.
+// The times are kept as time.Time so that the window is exact to the nanosecond.
+type timeSumState struct {
+	// values within the window in the order of time
+	vals []timedValue
+	sum  float64
+	// time of the last processed value
+	last time.Time
+}
+
+var timeSumCache map[string]*timeSumState = make(map[string]*timeSumState)
+
+// push adds the value at t and removes the values at or before t - window
+func (s *timeSumState) push(t time.Time, v float64, window time.Duration) {
+	s.vals = append(s.vals, timedValue{t: t, v: v})
+	s.sum += v
+
+	start := t.Add(-window)
+	i := 0
+	for i < len(s.vals)-1 && !s.vals[i].t.After(start) {
+		s.sum -= s.vals[i].v
+		i++
+	}
+	s.vals = s.vals[i:]
+
+	// the running sum of a single value is exact
+	if len(s.vals) == 1 {
+		s.sum = v
+	}
+	s.last = t
+}
+
+// getTimeSum generates the summation and the number of the values within the window.
+//
+// The values within the window are kept in timeSumState so that the values leaving the window are subtracted
+// from the previous summation instead of summing the whole window again.
+func getTimeSum(p ValueSeries, window time.Duration) (sum, count ValueSeries) {
+	sumkey := fmt.Sprintf("timesum:%s:%d", p.ID(), window)
+	sum = getCache(sumkey)
+	if sum == nil {
+		sum = NewValueSeries()
+	}
+
+	countkey := fmt.Sprintf("timesumcount:%s:%d", p.ID(), window)
+	count = getCache(countkey)
+	if count == nil {
+		count = NewValueSeries()
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+	if stop == nil || window <= 0 {
+		return sum, count
+	}
+
+	s := timeSumCache[sumkey]
+
+	var f *Value
+	if s == nil {
+		s = &timeSumState{}
+		f = p.GetFirst()
+	} else {
+		f = valueAfter(p, s.last)
+	}
+
+	for {
+		if f == nil {
+			break
+		}
+
+		s.push(f.t, f.v, window)
+		sum.Set(f.t, s.sum)
+		count.Set(f.t, float64(len(s.vals)))
+
+		if f.t.Equal(stop.t) {
+			break
+		}
+		f = f.next
+	}
+
+	setState(timeSumCache, sumkey, s)
+
+	setCache(sumkey, sum)
+	setCache(countkey, count)
+
+	sum.SetCurrent(stop.t)
+	count.SetCurrent(stop.t)
+
+	return sum, count
+}
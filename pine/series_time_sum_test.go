@@ -0,0 +1,150 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesTimeSumNoData tests no data scenario
+func TestSeriesTimeSumNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prop := OHLCVAttr(series, OHLCPropClose)
+	sum := TimeSum(prop, 30*time.Second)
+	if sum == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if sum.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *sum.Val())
+	}
+}
+
+// TestSeriesTimeSumIteration tests the output against values of the reference formula with the window of 30 seconds
+func TestSeriesTimeSumIteration(t *testing.T) {
+	data := irregularTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		NewFloat64(16.5000),
+		NewFloat64(35.2000),
+		NewFloat64(53.4000),
+		NewFloat64(30.1000),
+		NewFloat64(49.4000),
+		NewFloat64(33.5000),
+		NewFloat64(14.4000),
+		NewFloat64(25.4000),
+		NewFloat64(14.7000),
+		NewFloat64(25.0000),
+	}
+
+	for i, v := range tests {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropClose)
+		sum := TimeSum(prop, 30*time.Second)
+		assertSeriesVal(t, "sum", i, v, sum)
+	}
+}
+
+// TestSeriesTimeSMAIteration tests the output against values of the reference formula with the window of 30 seconds
+func TestSeriesTimeSMAIteration(t *testing.T) {
+	data := irregularTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		NewFloat64(16.5000),
+		NewFloat64(17.6000),
+		NewFloat64(17.8000),
+		NewFloat64(15.0500),
+		NewFloat64(16.4667),
+		NewFloat64(16.7500),
+		NewFloat64(14.4000),
+		NewFloat64(12.7000),
+		NewFloat64(14.7000),
+		NewFloat64(12.5000),
+	}
+
+	for i, v := range tests {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropClose)
+		sma := TimeSMA(prop, 30*time.Second)
+		assertSeriesVal(t, "sma", i, v, sma)
+	}
+}
+
+// subSecondTestSeries returns a ValueSeries of 1, 2, 3, 4 and 5 at 0.9, 1.2, 2.1, 3.05 and 4.8 seconds
+// along with the times so that the windows of 1 second start and end within seconds
+func subSecondTestSeries() (ValueSeries, []time.Time) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := NewValueSeries()
+	times := make([]time.Time, 0)
+	for i, ms := range []int{900, 1200, 2100, 3050, 4800} {
+		ti := start.Add(time.Duration(ms) * time.Millisecond)
+		p.Set(ti, float64(i+1))
+		times = append(times, ti)
+	}
+	return p, times
+}
+
+// TestSeriesTimeSumSubSecond tests that the window of 1 second is exact to the millisecond
+//
+// seconds     | 0.9 | 1.2 | 2.1 | 3.05 | 4.8 |
+// p           | 1   | 2   | 3   | 4    | 5   |
+// timesum(1s) | 1   | 3   | 5   | 7    | 5   |
+func TestSeriesTimeSumSubSecond(t *testing.T) {
+	p, times := subSecondTestSeries()
+	tests := []float64{1, 3, 5, 7, 5}
+	for i, v := range tests {
+		p.SetCurrent(times[i])
+		assertSeriesVal(t, "sum", i, NewFloat64(v), TimeSum(p, time.Second))
+	}
+}
+
+// TestSeriesTimeSumSourceTrimmed tests that the values within the window are kept after they are trimmed from the source
+func TestSeriesTimeSumSourceTrimmed(t *testing.T) {
+	assertSourceTrimmed(t, "sum", 3, func(p ValueSeries) ValueSeries {
+		return TimeSum(p, 30*time.Minute)
+	})
+}
+
+func TestMemoryLeakTimeSum(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		TimeSum(prop, 30*time.Second)
+		return nil
+	})
+}
+
+func ExampleTimeSum() {
+	start := time.Now()
+	// ticks with 100 milliseconds to 10 seconds in between
+	data := make([]OHLCV, 0)
+	for i := 0; i < 10000; i++ {
+		start = start.Add(time.Duration(100+i%100*100) * time.Millisecond)
+		p := 100 + float64(i%17)
+		data = append(data, OHLCV{O: p, H: p, L: p, C: p, V: 1, S: start})
+	}
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		sum := TimeSum(prop, 30*time.Second)
+		log.Printf("TimeSum: %+v", sum.Val())
+	}
+}
@@ -0,0 +1,45 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// TSI generates a ValueSeries of true strength index, which is in the range of [-1, 1].
+//
+// The formula for TSI is
+//   - pc = change(p, 1)
+//   - tsi = ema(ema(pc, longl), shortl) / ema(ema(abs(pc), longl), shortl)
+//
+// There is no value while the double smoothed absolute change is 0, i.e. the source has been flat from the start.
+//
+// arguments are
+//   - p: ValueSeries - source data
+//   - shortl: int64 - short length
+//   - longl: int64 - long length
+func TSI(p ValueSeries, shortl, longl int64) ValueSeries {
+	key := fmt.Sprintf("tsi:%s:%d:%d", p.ID(), shortl, longl)
+	tsi := getCache(key)
+	if tsi == nil {
+		tsi = NewValueSeries()
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+	if stop == nil {
+		return tsi
+	}
+
+	pc := Change(p, 1)
+	apc := Abs(pc)
+
+	dspc := EMA(EMA(pc, longl), shortl)
+	dsapc := EMA(EMA(apc, longl), shortl)
+
+	tsi = divNonZero(dspc, dsapc)
+
+	setCache(key, tsi)
+
+	tsi.SetCurrent(stop.t)
+
+	return tsi
+}
@@ -0,0 +1,81 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesTSINoData tests no data scenario
+func TestSeriesTSINoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prop := OHLCVAttr(series, OHLCPropClose)
+	tsi := TSI(prop, 2, 3)
+	if tsi == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if tsi.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *tsi.Val())
+	}
+}
+
+// TestSeriesTSIReference tests TSI(5, 10) against ema(ema(change, 10), 5) / ema(ema(abs(change), 10), 5) with TA-Lib's EMA on referenceTestData
+func TestSeriesTSIReference(t *testing.T) {
+	exp := []float64{
+		-0.1213, -0.1475, -0.2152, -0.1531, -0.1925, -0.2244, -0.1242, -0.1693,
+		-0.2049, -0.1418, -0.0162, 0.0820, 0.1855, 0.2142, 0.1317, -0.0326,
+		-0.0229, -0.0472, -0.0249, -0.0096, -0.0726, -0.0682, -0.2113, -0.2749,
+		-0.3124, -0.3308, -0.4145, -0.4608, -0.5259, -0.5682, -0.6254, -0.7197,
+		-0.7851, -0.7206, -0.4741, -0.4325, -0.4254, -0.2779, -0.2904, -0.2378,
+		-0.2597, -0.3087, -0.2070, -0.0440, 0.0198, 0.0353,
+	}
+
+	assertReference(t, "tsi", 14, exp, func(o OHLCVSeries) ValueSeries {
+		return TSI(OHLCVAttr(o, OHLCPropClose), 5, 10)
+	})
+}
+
+// TestSeriesTSIFlat tests that a flat source has no value instead of dividing zero by zero
+func TestSeriesTSIFlat(t *testing.T) {
+	assertFlatOHLCV(t, "tsi", 0, nil, func(o OHLCVSeries) ValueSeries {
+		return TSI(OHLCVAttr(o, OHLCPropClose), 3, 5)
+	})
+}
+
+// TestSeriesTSISourceTrimmed tests that trimming the source to 3 values does not change the output
+func TestSeriesTSISourceTrimmed(t *testing.T) {
+	assertSourceTrimmed(t, "tsi", 3, func(p ValueSeries) ValueSeries {
+		return TSI(p, 5, 10)
+	})
+}
+
+func TestMemoryLeakTSI(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		TSI(prop, 2, 3)
+		return nil
+	})
+}
+
+func ExampleTSI() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		tsi := TSI(prop, 2, 3)
+		log.Printf("TSI: %+v", tsi.Val())
+	}
+}
@@ -0,0 +1,116 @@
+package pine
+
+import (
+	"fmt"
+	"time"
+)
+
+// TWAP generates a ValueSeries of time-weighted average price within the window of time ending at the current value.
+//
+// Each value is held until the next value so that it is weighted by the time until the next value.
+// The value held at the start of the window is weighted by the time from the start of the window.
+// If the window starts before the first available value, the average starts at the first available value.
+//
+// The formula for TWAP is
+//   - twap = integral of p over the window / length of the window
+//
+// The first value is the source value.
+//
+// Parameters
+//   - p - ValueSeries: source data, i.e. close
+//   - window - time.Duration: length of the window (0, ∞)
+func TWAP(p ValueSeries, window time.Duration) ValueSeries {
+	key := fmt.Sprintf("twap:%s:%d", p.ID(), window)
+	twap := getCache(key)
+	if twap == nil {
+		twap = NewValueSeries()
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+	if stop == nil || window <= 0 {
+		return twap
+	}
+
+	s := twapCache[key]
+
+	var f *Value
+	if s == nil {
+		s = &twapState{}
+		f = p.GetFirst()
+	} else {
+		f = valueAfter(p, s.last)
+	}
+
+	for {
+		if f == nil {
+			break
+		}
+
+		twap.Set(f.t, s.push(f.t, f.v, window))
+
+		if f.t.Equal(stop.t) {
+			break
+		}
+		f = f.next
+	}
+
+	setState(twapCache, key, s)
+
+	setCache(key, twap)
+
+	twap.SetCurrent(stop.t)
+
+	return twap
+}
+
+// twapState is the state of the time window carried over to the next call.
+// The times are kept as time.Time so that the weights are exact to the nanosecond.
+type twapState struct {
+	// values after the start of the window in the order of time
+	vals []timedValue
+	// integral of the values in vals from the first to the last value in seconds
+	area float64
+	// latest value which left the window. It is held at the start of the window
+	held *timedValue
+	// time of the last processed value
+	last time.Time
+}
+
+var twapCache map[string]*twapState = make(map[string]*twapState)
+
+// push adds the value at t and returns the time-weighted average of the window ending at t
+func (s *twapState) push(t time.Time, v float64, window time.Duration) float64 {
+	if n := len(s.vals); n > 0 {
+		prev := s.vals[n-1]
+		s.area += prev.v * t.Sub(prev.t).Seconds()
+	}
+	s.vals = append(s.vals, timedValue{t: t, v: v})
+	s.last = t
+
+	start := t.Add(-window)
+	for len(s.vals) > 1 && !s.vals[0].t.After(start) {
+		s.area -= s.vals[0].v * s.vals[1].t.Sub(s.vals[0].t).Seconds()
+		held := s.vals[0]
+		s.held = &held
+		s.vals = s.vals[1:]
+	}
+	if len(s.vals) == 1 {
+		s.area = 0
+	}
+
+	// integral and length from the start of the window or the first available value
+	first := s.vals[0]
+	integral := s.area
+	length := t.Sub(first.t).Seconds()
+	if s.held != nil {
+		d := first.t.Sub(start).Seconds()
+		integral += s.held.v * d
+		length += d
+	}
+
+	if length > 0 {
+		return integral / length
+	}
+	return v
+}
@@ -0,0 +1,109 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesTWAPNoData tests no data scenario
+func TestSeriesTWAPNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prop := OHLCVAttr(series, OHLCPropClose)
+	twap := TWAP(prop, 30*time.Second)
+	if twap == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if twap.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *twap.Val())
+	}
+}
+
+// TestSeriesTWAPIteration tests the output against values of the reference formula with the window of 30 seconds
+func TestSeriesTWAPIteration(t *testing.T) {
+	data := irregularTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		NewFloat64(16.5000),
+		NewFloat64(16.5000),
+		NewFloat64(17.2333),
+		NewFloat64(18.2833),
+		NewFloat64(18.0567),
+		NewFloat64(19.0533),
+		NewFloat64(14.2000),
+		NewFloat64(14.2067),
+		NewFloat64(11.0000),
+		NewFloat64(12.2333),
+	}
+
+	for i, v := range tests {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropClose)
+		twap := TWAP(prop, 30*time.Second)
+		assertSeriesVal(t, "twap", i, v, twap)
+	}
+}
+
+// TestSeriesTWAPSubSecond tests that the values are weighted to the millisecond
+//
+// seconds    | 0.9 | 1.2 | 2.1 | 3.05 | 4.8 |
+// p          | 1   | 2   | 3   | 4    | 5   |
+// twap(1s)   | 1   | 1   | 1.9 | 2.95 | 4   |
+//
+// i.e. twap at 2.1 is (1 * (1.2 - 1.1) + 2 * (2.1 - 1.2)) / 1
+func TestSeriesTWAPSubSecond(t *testing.T) {
+	p, times := subSecondTestSeries()
+	tests := []float64{1, 1, 1.9, 2.95, 4}
+	for i, v := range tests {
+		p.SetCurrent(times[i])
+		assertSeriesVal(t, "twap", i, NewFloat64(v), TWAP(p, time.Second))
+	}
+}
+
+// TestSeriesTWAPSourceTrimmed tests that the values within the window are kept after they are trimmed from the source
+func TestSeriesTWAPSourceTrimmed(t *testing.T) {
+	assertSourceTrimmed(t, "twap", 3, func(p ValueSeries) ValueSeries {
+		return TWAP(p, 30*time.Minute)
+	})
+}
+
+func TestMemoryLeakTWAP(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		TWAP(prop, 30*time.Second)
+		return nil
+	})
+}
+
+func ExampleTWAP() {
+	start := time.Now()
+	// ticks with 100 milliseconds to 10 seconds in between
+	data := make([]OHLCV, 0)
+	for i := 0; i < 10000; i++ {
+		start = start.Add(time.Duration(100+i%100*100) * time.Millisecond)
+		p := 100 + float64(i%17)
+		data = append(data, OHLCV{O: p, H: p, L: p, C: p, V: 1, S: start})
+	}
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		twap := TWAP(prop, 30*time.Second)
+		log.Printf("TWAP: %+v", twap.Val())
+	}
+}
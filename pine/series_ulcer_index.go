@@ -0,0 +1,41 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// UlcerIndex generates a ValueSeries of Ulcer Index, which measures the depth and the duration of drawdowns from the recent high.
+//
+// The formula for UlcerIndex is
+//   - drawdown = 100 * (p - highest(p, l)) / highest(p, l)
+//   - ulcer = sqrt(sma(drawdown^2, l))
+//
+// Parameters
+//   - p - ValueSeries: source data, i.e. close
+//   - l - int64: lookback periods [1, ∞)
+func UlcerIndex(p ValueSeries, l int64) ValueSeries {
+	key := fmt.Sprintf("ulcerindex:%s:%d", p.ID(), l)
+	ulcer := getCache(key)
+	if ulcer == nil {
+		ulcer = NewValueSeries()
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+	if stop == nil {
+		return ulcer
+	}
+
+	dd2 := Operate(p, Highest(p, l), "ulcerdrawdown", func(v, hh float64) float64 {
+		dd := 100 * (v - hh) / hh
+		return dd * dd
+	})
+
+	ulcer = Sqrt(SMA(dd2, l))
+
+	setCache(key, ulcer)
+
+	ulcer.SetCurrent(stop.t)
+
+	return ulcer
+}
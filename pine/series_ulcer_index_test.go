@@ -0,0 +1,58 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesUlcerIndexReference tests UlcerIndex(close, 10) against the formula composed of TA-Lib's MAX and SMA on referenceTestData
+func TestSeriesUlcerIndexReference(t *testing.T) {
+	assertReference(t, "ulcer", 18, []float64{
+		2.9652, 2.9227, 2.9961, 3.4121, 3.5739, 3.5267, 3.4379, 3.3216,
+		3.0341, 2.9564, 2.7346, 2.8312, 2.8847, 2.6516, 2.5888, 2.6902,
+		3.0368, 3.2442, 3.8749, 4.1423, 4.2990, 4.2018, 4.5906, 4.8906,
+		5.3965, 5.7742, 6.1795, 6.6048, 6.9940, 7.3734, 7.4663, 7.6735,
+		7.7811, 7.5832, 7.5135, 7.2991, 7.0436, 6.9546, 6.3278, 5.6928,
+		5.3962, 4.9338,
+	}, func(o OHLCVSeries) ValueSeries {
+		return UlcerIndex(OHLCVAttr(o, OHLCPropClose), 10)
+	})
+}
+
+// TestSeriesUlcerIndexFlat tests that a flat window has no drawdown
+func TestSeriesUlcerIndexFlat(t *testing.T) {
+	assertFlatTo(t, "ulcer", 4, 0, func(p ValueSeries) ValueSeries {
+		return UlcerIndex(p, 3)
+	})
+}
+
+// TestSeriesUlcerIndexSourceTrimmed tests that trimming the source to 3 values does not change the output
+func TestSeriesUlcerIndexSourceTrimmed(t *testing.T) {
+	assertSourceTrimmed(t, "ulcer", 3, func(p ValueSeries) ValueSeries {
+		return UlcerIndex(p, 3)
+	})
+}
+
+func TestMemoryLeakUlcerIndex(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		UlcerIndex(prop, 3)
+		return nil
+	})
+}
+
+func ExampleUlcerIndex() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		ulcer := UlcerIndex(prop, 3)
+		log.Printf("UlcerIndex: %+v", ulcer.Val())
+	}
+}
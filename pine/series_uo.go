@@ -0,0 +1,65 @@
+package pine
+
+import (
+	"fmt"
+	"math"
+)
+
+// UO generates a ValueSeries of ultimate oscillator.
+// It combines buying pressure of three different lookback periods.
+//
+// The formula for UO is
+//   - bp = close - min(low, close[1])
+//   - tr = max(high, close[1]) - min(low, close[1])
+//   - avg(l) = sum(bp, l) / sum(tr, l)
+//   - uo = 100 * (4 * avg(fastl) + 2 * avg(midl) + avg(slowl)) / 7
+//
+// There is no value where the true range of any window sums up to 0, i.e. a flat window.
+//
+// The arguments are:
+//   - o: OHLCVSeries - source of data
+//   - fastl: int - fast length. 7 is commonly used
+//   - midl: int - middle length. 14 is commonly used
+//   - slowl: int - slow length. 28 is commonly used
+func UO(o OHLCVSeries, fastl, midl, slowl int) ValueSeries {
+	key := fmt.Sprintf("uo:%s:%d:%d:%d", o.ID(), fastl, midl, slowl)
+	uo := getCache(key)
+	if uo == nil {
+		uo = NewValueSeries()
+	}
+
+	// buying pressure of each OHLCV
+	bpkey := fmt.Sprintf("uobp:%s", o.ID())
+	bp := getCache(bpkey)
+	if bp == nil {
+		bp = NewValueSeries()
+	}
+
+	stop := o.Current()
+	if stop == nil {
+		return uo
+	}
+
+	bp = generateOHLCVRecursive(o, bp, func(cur *OHLCV, _ *float64) *float64 {
+		if cur.prev == nil {
+			return nil
+		}
+		return NewFloat64(cur.C - math.Min(cur.L, cur.prev.C))
+	})
+	bp.SetCurrent(stop.S)
+	setCache(bpkey, bp)
+
+	tr := OHLCVAttr(o, OHLCPropTR)
+
+	fast := divNonZero(Sum(bp, fastl), Sum(tr, fastl))
+	mid := divNonZero(Sum(bp, midl), Sum(tr, midl))
+	slow := divNonZero(Sum(bp, slowl), Sum(tr, slowl))
+
+	uo = MulConst(Add(Add(MulConst(fast, 4), MulConst(mid, 2)), slow), 100.0/7)
+
+	setCache(key, uo)
+
+	uo.SetCurrent(stop.S)
+
+	return uo
+}
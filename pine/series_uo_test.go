@@ -0,0 +1,69 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesUONoData tests no data scenario
+func TestSeriesUONoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uo := UO(series, 2, 3, 4)
+	if uo == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if uo.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *uo.Val())
+	}
+}
+
+// TestSeriesUOReference tests UO(7, 14, 28) against TA-Lib's ULTOSC on referenceTestData
+func TestSeriesUOReference(t *testing.T) {
+	exp := []float64{
+		48.9158, 49.2546, 52.4456, 49.1262, 45.8131, 41.7581, 39.1573, 42.5460,
+		42.5013, 37.8146, 38.7709, 35.9614, 33.8453, 33.7895, 32.4105, 33.9819,
+		33.8413, 30.1774, 29.9339, 35.0912, 40.6282, 38.9699, 40.6058, 45.4877,
+		46.2134, 50.0777, 48.1830, 42.5826, 48.9088, 51.2860, 47.5589, 47.1718,
+	}
+
+	assertReference(t, "uo", 28, exp, func(o OHLCVSeries) ValueSeries {
+		return UO(o, 7, 14, 28)
+	})
+}
+
+// TestSeriesUOFlat tests that a flat window has no value instead of dividing zero by zero
+func TestSeriesUOFlat(t *testing.T) {
+	assertFlatOHLCV(t, "uo", 0, nil, func(o OHLCVSeries) ValueSeries {
+		return UO(o, 2, 3, 4)
+	})
+}
+
+func TestMemoryLeakUO(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		UO(o, 2, 3, 4)
+		return nil
+	})
+}
+
+func ExampleUO() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		uo := UO(series, 2, 3, 4)
+		log.Printf("UO: %+v", uo.Val())
+	}
+}
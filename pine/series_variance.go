@@ -30,25 +30,24 @@ func Variance(p ValueSeries, l int64) ValueSeries {
 		return vari
 	}
 
-	if vari.Get(stop.t) != nil {
-		return vari
-	}
-
-	sma := SMA(p, l)
-
-	meanv := sma.Get(stop.t)
-	if meanv == nil {
-		return vari
-	}
-	diff := SubConstNoCache(p, meanv.v)
-	sqrt := Pow(diff, 2)
-	sum := SumNoCache(sqrt, int(l))
 	denom := math.Max(float64(l-1), 1)
-	vari = DivConstNoCache(sum, denom)
-
-	vari.SetCurrent(stop.t)
+	vari = generateWindow(*stop, p, vari, int(l), func(w []float64) float64 {
+		var mean float64
+		for _, v := range w {
+			mean += v
+		}
+		mean /= float64(len(w))
+
+		var ss float64
+		for _, v := range w {
+			ss += (v - mean) * (v - mean)
+		}
+		return ss / denom
+	})
 
 	setCache(key, vari)
 
+	vari.SetCurrent(stop.t)
+
 	return vari
 }
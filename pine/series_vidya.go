@@ -0,0 +1,67 @@
+package pine
+
+import (
+	"fmt"
+	"math"
+)
+
+// VIDYA generates a ValueSeries of Chande's variable index dynamic average.
+// The smoothing factor of EMA is scaled by the absolute value of Chande momentum oscillator.
+//
+// The formula for VIDYA is
+//   - k = abs(cmo(p, l)) / 100
+//   - vidya = p * (2 / (l + 1)) * k + vidya[1] * (1 - (2 / (l + 1)) * k)
+//
+// The first VIDYA value is seeded with the source value.
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - l - int64: lookback periods [1, ∞)
+func VIDYA(p ValueSeries, l int64) ValueSeries {
+	key := fmt.Sprintf("vidya:%s:%d", p.ID(), l)
+	vidya := getCache(key)
+	if vidya == nil {
+		vidya = NewValueSeries()
+	}
+
+	if p == nil || p.GetCurrent() == nil {
+		return vidya
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+
+	alpha := 2.0 / float64(l+1)
+
+	vidya = generateRecursive(*stop, p, vidya, int(l+1), func(w []float64, prev *float64) float64 {
+		cur := w[len(w)-1]
+		if prev == nil {
+			return cur
+		}
+		k := math.Abs(cmoWindow(w)) / 100
+		return cur*alpha*k + *prev*(1-alpha*k)
+	})
+
+	setCache(key, vidya)
+
+	vidya.SetCurrent(stop.t)
+
+	return vidya
+}
+
+// cmoWindow returns Chande momentum oscillator of the changes within w
+func cmoWindow(w []float64) float64 {
+	var u, d float64
+	for i := 1; i < len(w); i++ {
+		chg := w[i] - w[i-1]
+		if chg > 0 {
+			u = u + chg
+		} else {
+			d = d - chg
+		}
+	}
+	if u+d == 0 {
+		return 0
+	}
+	return 100 * (u - d) / (u + d)
+}
@@ -0,0 +1,73 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesVIDYAIteration tests the output against values worked out by hand from the formula of VIDYA
+func TestSeriesVIDYAIteration(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		nil,
+		nil,
+		nil,
+		NewFloat64(11.9000),
+		NewFloat64(12.0563),
+		NewFloat64(12.2844),
+		NewFloat64(12.4926),
+		NewFloat64(11.7806),
+		NewFloat64(11.8806),
+		NewFloat64(11.5988),
+	}
+
+	for i, v := range tests {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropClose)
+		vidya := VIDYA(prop, 3)
+		assertSeriesVal(t, "vidya", i, v, vidya)
+	}
+}
+
+// TestSeriesVIDYAFlat tests that a flat window returns the flat value
+func TestSeriesVIDYAFlat(t *testing.T) {
+	assertFlat(t, "vidya", 3, func(p ValueSeries) ValueSeries {
+		return VIDYA(p, 3)
+	})
+}
+
+// TestSeriesVIDYASourceTrimmed tests that trimming the source to 5 values does not change the output
+func TestSeriesVIDYASourceTrimmed(t *testing.T) {
+	assertSourceTrimmed(t, "vidya", 5, func(p ValueSeries) ValueSeries {
+		return VIDYA(p, 3)
+	})
+}
+
+func TestMemoryLeakVIDYA(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		VIDYA(prop, 3)
+		return nil
+	})
+}
+
+func ExampleVIDYA() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		vidya := VIDYA(prop, 3)
+		log.Printf("VIDYA: %+v", vidya.Val())
+	}
+}
@@ -0,0 +1,80 @@
+package pine
+
+import (
+	"fmt"
+	"math"
+)
+
+// Vortex generates ValueSeries of vortex indicator's VI+ and VI- in that order.
+//
+// The formula for Vortex is
+//   - vmp = sum(abs(high - low[1]), l)
+//   - vmm = sum(abs(low - high[1]), l)
+//   - str = sum(tr, l)
+//   - VI+ = vmp / str
+//   - VI- = vmm / str
+//
+// The arguments are:
+//   - o: OHLCVSeries - source of data
+//   - l: int - lookback periods [1, ∞)
+func Vortex(o OHLCVSeries, l int) (plus, minus ValueSeries) {
+	pluskey := fmt.Sprintf("vortexplus:%s:%d", o.ID(), l)
+	plus = getCache(pluskey)
+	if plus == nil {
+		plus = NewValueSeries()
+	}
+
+	minuskey := fmt.Sprintf("vortexminus:%s:%d", o.ID(), l)
+	minus = getCache(minuskey)
+	if minus == nil {
+		minus = NewValueSeries()
+	}
+
+	// vortex movements of each OHLCV
+	vmkey := fmt.Sprintf("vortexvmp:%s", o.ID())
+	vmp := getCache(vmkey)
+	if vmp == nil {
+		vmp = NewValueSeries()
+	}
+
+	vmmkey := fmt.Sprintf("vortexvmm:%s", o.ID())
+	vmm := getCache(vmmkey)
+	if vmm == nil {
+		vmm = NewValueSeries()
+	}
+
+	stop := o.Current()
+	if stop == nil {
+		return plus, minus
+	}
+
+	vmp = generateOHLCVRecursive(o, vmp, func(cur *OHLCV, _ *float64) *float64 {
+		if cur.prev == nil {
+			return nil
+		}
+		return NewFloat64(math.Abs(cur.H - cur.prev.L))
+	})
+	vmm = generateOHLCVRecursive(o, vmm, func(cur *OHLCV, _ *float64) *float64 {
+		if cur.prev == nil {
+			return nil
+		}
+		return NewFloat64(math.Abs(cur.L - cur.prev.H))
+	})
+	vmp.SetCurrent(stop.S)
+	vmm.SetCurrent(stop.S)
+	setCache(vmkey, vmp)
+	setCache(vmmkey, vmm)
+
+	str := Sum(OHLCVAttr(o, OHLCPropTR), l)
+
+	plus = Div(Sum(vmp, l), str)
+	minus = Div(Sum(vmm, l), str)
+
+	plus.SetCurrent(stop.S)
+	minus.SetCurrent(stop.S)
+
+	setCache(pluskey, plus)
+	setCache(minuskey, minus)
+
+	return plus, minus
+}
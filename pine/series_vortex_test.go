@@ -0,0 +1,76 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesVortexNoData tests no data scenario
+func TestSeriesVortexNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plus, minus := Vortex(series, 3)
+	if plus == nil || minus == nil {
+		t.Fatal("Expected to be non nil but got nil")
+	}
+	if plus.Val() != nil || minus.Val() != nil {
+		t.Error("Expected to be nil but got non nil")
+	}
+}
+
+// TestSeriesVortexReference tests Vortex(14) against sum(abs(high - low[1]), 14) / sum(tr, 14) and sum(abs(low - high[1]), 14) / sum(tr, 14)
+// with TA-Lib's TRANGE and SUM on referenceTestData
+func TestSeriesVortexReference(t *testing.T) {
+	assertReference(t, "plus", 14, []float64{
+		0.9263, 0.9542, 0.9333, 1.0297, 0.9911, 0.9725, 0.9679, 0.9323,
+		0.9554, 0.9348, 0.9575, 0.9822, 0.9709, 0.9932, 0.9680, 0.9232,
+		0.9668, 0.9836, 1.0215, 1.0113, 0.9562, 1.0036, 0.9138, 0.9470,
+		0.8975, 0.8971, 0.8313, 0.8045, 0.8061, 0.8802, 0.8146, 0.7718,
+		0.7407, 0.7232, 0.8078, 0.7822, 0.8090, 0.8137, 0.7844, 0.7857,
+		0.7952, 0.7843, 0.8391, 0.8764, 0.9472, 0.9700,
+	}, func(o OHLCVSeries) ValueSeries {
+		plus, _ := Vortex(o, 14)
+		return plus
+	})
+
+	assertReference(t, "minus", 14, []float64{
+		1.0704, 1.0247, 0.9755, 0.9507, 0.9440, 0.9196, 0.9391, 0.9475,
+		0.9574, 0.9511, 0.9594, 0.9662, 0.9718, 0.9656, 0.9369, 0.9794,
+		0.9906, 0.9644, 0.9054, 0.9507, 1.0033, 0.9819, 0.9796, 1.0187,
+		1.1121, 1.0783, 1.1588, 1.1509, 1.1315, 1.1612, 1.1287, 1.1624,
+		1.2329, 1.2428, 1.1967, 1.2017, 1.2303, 1.1427, 1.1225, 1.1415,
+		1.1330, 1.1201, 1.1137, 0.9909, 1.0075, 0.9610,
+	}, func(o OHLCVSeries) ValueSeries {
+		_, minus := Vortex(o, 14)
+		return minus
+	})
+}
+
+func TestMemoryLeakVortex(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		Vortex(o, 3)
+		return nil
+	})
+}
+
+func ExampleVortex() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		plus, minus := Vortex(series, 3)
+		log.Printf("plus: %+v, minus: %+v", plus.Val(), minus.Val())
+	}
+}
@@ -0,0 +1,172 @@
+package pine
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// VWAPBandMode determines how the bands of VWAP are calculated
+type VWAPBandMode int
+
+const (
+	// VWAPBandStdev calculates the band width as a multiple of the volume weighted standard deviation
+	VWAPBandStdev VWAPBandMode = iota
+	// VWAPBandPercentage calculates the band width as a percentage of VWAP
+	VWAPBandPercentage
+)
+
+// VWAP generates a ValueSeries of volume weighted average price that resets at every anchor period.
+// There is no value while the anchor period has no volume, i.e. before the first anchor or when the volume is all 0.
+//
+// The formula for VWAP is
+//   - vwap = sum(p * volume) / sum(volume) from the beginning of the anchor period
+//
+// Parameters
+//   - p - ValueSeries: source data. HLC3 is commonly used
+//   - o - OHLCVSeries: OHLCV series where the volume is taken from
+//   - a - Anchor: anchor to reset the calculation
+func VWAP(p ValueSeries, o OHLCVSeries, a Anchor) ValueSeries {
+	vwap, _ := getVWAP(p, o, a)
+	return vwap
+}
+
+// VWAPBands generates ValueSeries of VWAP and its upper and lower bands in that order.
+//
+// The band width for each mode is
+//   - VWAPBandStdev: mult * sqrt(sum(p^2 * volume) / sum(volume) - vwap^2)
+//   - VWAPBandPercentage: vwap * mult / 100
+//
+// Parameters
+//   - p - ValueSeries: source data. HLC3 is commonly used
+//   - o - OHLCVSeries: OHLCV series where the volume is taken from
+//   - a - Anchor: anchor to reset the calculation
+//   - mode - VWAPBandMode: calculation mode of the band
+//   - mult - float64: multiplier of the band width
+func VWAPBands(p ValueSeries, o OHLCVSeries, a Anchor, mode VWAPBandMode, mult float64) (vwap, upper, lower ValueSeries) {
+	upperkey := fmt.Sprintf("vwapupper:%s:%s:%s:%d:%v", p.ID(), o.ID(), a.ID(), mode, mult)
+	upper = getCache(upperkey)
+	if upper == nil {
+		upper = NewValueSeries()
+	}
+
+	lowerkey := fmt.Sprintf("vwaplower:%s:%s:%s:%d:%v", p.ID(), o.ID(), a.ID(), mode, mult)
+	lower = getCache(lowerkey)
+	if lower == nil {
+		lower = NewValueSeries()
+	}
+
+	vwap, stdev := getVWAP(p, o, a)
+
+	stop := p.GetCurrent()
+	if stop == nil {
+		return vwap, upper, lower
+	}
+
+	var width ValueSeries
+	switch mode {
+	case VWAPBandPercentage:
+		width = MulConst(vwap, mult/100)
+	default:
+		width = MulConst(stdev, mult)
+	}
+
+	upper = Add(vwap, width)
+	lower = Sub(vwap, width)
+
+	upper.SetCurrent(stop.t)
+	lower.SetCurrent(stop.t)
+
+	setCache(upperkey, upper)
+	setCache(lowerkey, lower)
+
+	return vwap, upper, lower
+}
+
+// getVWAP generates VWAP and its volume weighted standard deviation
+func getVWAP(p ValueSeries, o OHLCVSeries, a Anchor) (vwap, stdev ValueSeries) {
+	vwapkey := fmt.Sprintf("vwap:%s:%s:%s", p.ID(), o.ID(), a.ID())
+	vwap = getCache(vwapkey)
+	if vwap == nil {
+		vwap = NewValueSeries()
+	}
+
+	stdevkey := fmt.Sprintf("vwapstdev:%s:%s:%s", p.ID(), o.ID(), a.ID())
+	stdev = getCache(stdevkey)
+	if stdev == nil {
+		stdev = NewValueSeries()
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+	if stop == nil {
+		return vwap, stdev
+	}
+
+	vol := OHLCVAttr(o, OHLCPropVolume)
+
+	statekey := fmt.Sprintf("vwap:%s:%s:%s", p.ID(), o.ID(), a.ID())
+	s := vwapCache[statekey]
+
+	var f *Value
+	if s == nil {
+		s = &vwapState{}
+		f = p.GetFirst()
+	} else {
+		f = valueAfter(p, s.last)
+	}
+	for {
+		if f == nil {
+			break
+		}
+
+		if v := vol.Get(f.t); v != nil {
+			// start the running totals of a new period, comparing with the last processed value which may have been trimmed
+			if a.Begins(s.last, f.t) {
+				s.sumpv, s.sumv, s.sump2v = 0, 0, 0
+				s.begun = true
+			}
+
+			if s.begun {
+				s.sumpv += f.v * v.v
+				s.sumv += v.v
+				s.sump2v += f.v * f.v * v.v
+
+				// VWAP is undefined until the period has volume
+				if s.sumv != 0 {
+					avg := s.sumpv / s.sumv
+					vwap.Set(f.t, avg)
+					stdev.Set(f.t, math.Sqrt(math.Max(s.sump2v/s.sumv-avg*avg, 0)))
+				}
+			}
+		}
+		s.last = f.t
+
+		if f.t.Equal(stop.t) {
+			break
+		}
+		f = f.next
+	}
+
+	setState(vwapCache, statekey, s)
+
+	vwap.SetCurrent(stop.t)
+	stdev.SetCurrent(stop.t)
+
+	setCache(vwapkey, vwap)
+	setCache(stdevkey, stdev)
+
+	return vwap, stdev
+}
+
+// vwapState is the state of VWAP carried over to the next call
+type vwapState struct {
+	// running totals of p * volume, volume and p^2 * volume from the beginning of the anchor period
+	sumpv, sumv, sump2v float64
+	// whether the first anchor period has begun
+	begun bool
+	// time of the last processed value
+	last time.Time
+}
+
+var vwapCache map[string]*vwapState = make(map[string]*vwapState)
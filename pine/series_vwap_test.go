@@ -0,0 +1,239 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// vwapTestData returns static test data with hourly bars which crosses a day boundary at the third bar
+func vwapTestData() []OHLCV {
+	data := OHLCVStaticTestData()
+	start := time.Date(2023, 1, 1, 22, 0, 0, 0, time.UTC)
+	for i := range data {
+		data[i].S = start.Add(time.Duration(i) * time.Hour)
+	}
+	return data
+}
+
+// TestSeriesVWAPNoData tests no data scenario
+func TestSeriesVWAPNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prop := OHLCVAttr(series, OHLCPropHLC3)
+	vwap := VWAP(prop, series, AnchorPeriodDay)
+	if vwap == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if vwap.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *vwap.Val())
+	}
+}
+
+// TestSeriesVWAPIteration tests the output against values of the Pine Script reference formula
+//
+// The calculation resets at the third bar since it is a new day
+func TestSeriesVWAPIteration(t *testing.T) {
+	series, err := NewOHLCVSeries(vwapTestData())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := [][]*float64{
+		{NewFloat64(15.7667), NewFloat64(15.7667), NewFloat64(15.7667)},
+		{NewFloat64(16.2599), NewFloat64(17.1917), NewFloat64(15.3281)},
+		{NewFloat64(15.7667), NewFloat64(15.7667), NewFloat64(15.7667)},
+		{NewFloat64(15.0350), NewFloat64(16.3983), NewFloat64(13.6718)},
+		{NewFloat64(15.6245), NewFloat64(17.5335), NewFloat64(13.7155)},
+		{NewFloat64(15.6853), NewFloat64(17.3037), NewFloat64(14.0669)},
+		{NewFloat64(15.6697), NewFloat64(17.1340), NewFloat64(14.2054)},
+		{NewFloat64(15.4234), NewFloat64(17.3039), NewFloat64(13.5429)},
+		{NewFloat64(15.4144), NewFloat64(17.1394), NewFloat64(13.6894)},
+		{NewFloat64(15.0791), NewFloat64(17.5075), NewFloat64(12.6506)},
+	}
+
+	for i, v := range tests {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropHLC3)
+		vwap, upper, lower := VWAPBands(prop, series, AnchorPeriodDay, VWAPBandStdev, 2)
+		assertSeriesVal(t, "vwap", i, v[0], vwap)
+		assertSeriesVal(t, "upper", i, v[1], upper)
+		assertSeriesVal(t, "lower", i, v[2], lower)
+	}
+}
+
+// TestSeriesVWAPPercentage tests percentage bands
+func TestSeriesVWAPPercentage(t *testing.T) {
+	series, err := NewOHLCVSeries(vwapTestData())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		NewFloat64(15.9243),
+		NewFloat64(16.4225),
+		NewFloat64(15.9243),
+		NewFloat64(15.1854),
+		NewFloat64(15.7808),
+		NewFloat64(15.8422),
+		NewFloat64(15.8264),
+		NewFloat64(15.5777),
+		NewFloat64(15.5686),
+		NewFloat64(15.2299),
+	}
+
+	for i, v := range tests {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropHLC3)
+		_, upper, _ := VWAPBands(prop, series, AnchorPeriodDay, VWAPBandPercentage, 1)
+		assertSeriesVal(t, "upper", i, v, upper)
+	}
+}
+
+// TestSeriesVWAPAnchorTime tests that no value is generated before the anchor time
+func TestSeriesVWAPAnchorTime(t *testing.T) {
+	data := vwapTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		NewFloat64(15.8333),
+		NewFloat64(15.7319),
+		NewFloat64(15.2179),
+		NewFloat64(15.2591),
+		NewFloat64(14.7535),
+	}
+
+	anchor := AnchorTime(data[5].S)
+	for i, v := range tests {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropHLC3)
+		vwap := VWAP(prop, series, anchor)
+		assertSeriesVal(t, "vwap", i, v, vwap)
+	}
+}
+
+// TestSeriesVWAPZeroVolume tests that there is no value until the anchor period has volume
+func TestSeriesVWAPZeroVolume(t *testing.T) {
+	data := vwapTestData()
+	for i := 0; i < 4; i++ {
+		data[i].V = 0
+	}
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{
+		nil,
+		nil,
+		nil,
+		nil,
+		NewFloat64(16.6667),
+		NewFloat64(16.2233),
+		NewFloat64(16.0422),
+		NewFloat64(15.6086),
+		NewFloat64(15.5558),
+		NewFloat64(15.0929),
+	}
+
+	for i, v := range tests {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropHLC3)
+		vwap, upper, _ := VWAPBands(prop, series, AnchorPeriodDay, VWAPBandStdev, 1)
+		assertSeriesVal(t, "vwap", i, v, vwap)
+		if v == nil && upper.Val() != nil {
+			t.Errorf("Expected upper to be nil but got %+v for iteration: %d", *upper.Val(), i)
+		}
+	}
+}
+
+// countingAnchor is an anchor which counts how many times Begins is called
+type countingAnchor struct {
+	Anchor
+	calls int
+}
+
+func (a *countingAnchor) Begins(prev, cur time.Time) bool {
+	a.calls++
+	return a.Anchor.Begins(prev, cur)
+}
+
+// TestSeriesVWAPBeforeAnchorScannedOnce tests that the values before the first anchor are not scanned again on every call
+func TestSeriesVWAPBeforeAnchorScannedOnce(t *testing.T) {
+	data := vwapTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	anchor := &countingAnchor{Anchor: AnchorTime(data[len(data)-1].S.Add(time.Hour))}
+	for i := range data {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropHLC3)
+		if v := VWAP(prop, series, anchor).Val(); v != nil {
+			t.Errorf("Expected to be nil but got %+v for iteration: %d", *v, i)
+		}
+	}
+
+	if anchor.calls != len(data) {
+		t.Errorf("Expected every value to be scanned once (%d) but got %d", len(data), anchor.calls)
+	}
+}
+
+// TestSeriesVWAPLastTrimmed tests that the running totals of the period are kept after the last processed value is trimmed from the source
+func TestSeriesVWAPLastTrimmed(t *testing.T) {
+	series, err := NewOHLCVSeries(vwapTestData())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 6; i++ {
+		series.Next()
+		VWAP(OHLCVAttr(series, OHLCPropHLC3), series, AnchorPeriodDay)
+	}
+	for i := 0; i < 2; i++ {
+		series.Next()
+	}
+	prop := OHLCVAttr(series, OHLCPropHLC3)
+	prop.SetMax(2)
+
+	assertSeriesVal(t, "vwap", 7, NewFloat64(15.4234), VWAP(prop, series, AnchorPeriodDay))
+}
+
+func TestMemoryLeakVWAP(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropHLC3)
+		VWAPBands(prop, o, AnchorPeriodDay, VWAPBandStdev, 2)
+		return nil
+	})
+}
+
+func ExampleVWAPBands() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropHLC3)
+		vwap, upper, lower := VWAPBands(prop, series, AnchorPeriodDay, VWAPBandStdev, 2)
+		log.Printf("VWAP: %+v, upper: %+v, lower: %+v", vwap.Val(), upper.Val(), lower.Val())
+	}
+}
@@ -0,0 +1,38 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// VWMA generates a ValueSeries of volume-weighted moving average using the volume of OHLCVSeries.
+//
+// The formula for VWMA is
+//   - vwma = sma(p * volume, l) / sma(volume, l)
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - o - OHLCVSeries: OHLCV series where the volume is taken from
+//   - l - int64: lookback periods [1, ∞)
+func VWMA(p ValueSeries, o OHLCVSeries, l int64) ValueSeries {
+	key := fmt.Sprintf("vwma:%s:%s:%d", p.ID(), o.ID(), l)
+	vwma := getCache(key)
+	if vwma == nil {
+		vwma = NewValueSeries()
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+	if stop == nil {
+		return vwma
+	}
+
+	vol := OHLCVAttr(o, OHLCPropVolume)
+
+	vwma = Div(SMA(Mul(p, vol), l), SMA(vol, l))
+
+	setCache(key, vwma)
+
+	vwma.SetCurrent(stop.t)
+
+	return vwma
+}
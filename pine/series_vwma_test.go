@@ -0,0 +1,48 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesVWMAReference tests VWMA(close, 3) against sum(close * volume, 3) / sum(volume, 3) computed with TA-Lib's MULT, SUM and DIV
+func TestSeriesVWMAReference(t *testing.T) {
+	exp := []float64{
+		100.3901, 98.1130, 97.0388, 96.2063, 97.5822, 98.1206, 97.6817, 97.2625,
+		97.5446, 98.8235, 100.0559, 100.0030, 99.8487, 98.6752, 98.0933, 98.1775,
+		97.4755, 97.2808, 97.3000, 97.2115, 97.1538, 96.5380, 98.0653, 99.3416,
+		100.4270, 100.9481, 100.7608, 99.2632, 98.0043, 97.6469, 98.3784, 98.4904,
+		98.2904, 98.0917, 96.3445, 95.5996, 94.3774, 94.4712, 93.9875, 93.3256,
+		91.9322, 91.5236, 90.6587, 89.2497, 87.3039, 86.0325, 86.6601, 87.0263,
+		86.9156, 86.7483, 86.1521, 86.2944, 84.9990, 84.1540, 84.0012, 85.2709,
+		87.2952, 88.1788,
+	}
+
+	assertReference(t, "vwma", 2, exp, func(o OHLCVSeries) ValueSeries {
+		return VWMA(OHLCVAttr(o, OHLCPropClose), o, 3)
+	})
+}
+
+func TestMemoryLeakVWMA(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		VWMA(prop, o, 3)
+		return nil
+	})
+}
+
+func ExampleVWMA() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		vwma := VWMA(prop, series, 3)
+		log.Printf("VWMA: %+v", vwma.Val())
+	}
+}
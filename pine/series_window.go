@@ -0,0 +1,136 @@
+package pine
+
+// windowValues returns l values ending at v in chronological order.
+// nil is returned if there are less than l values available.
+func windowValues(v *Value, l int) []float64 {
+	if v == nil || l < 1 {
+		return nil
+	}
+	w := make([]float64, l)
+	for i := l - 1; i >= 0; i-- {
+		if v == nil {
+			return nil
+		}
+		w[i] = v.v
+		v = v.prev
+	}
+	return w
+}
+
+// generateWindow applies fn on every rolling window of l values from src and sets the result to dest.
+// It starts from where dest was left off and stops at stop.
+func generateWindow(stop Value, src, dest ValueSeries, l int, fn func(w []float64) float64) ValueSeries {
+	f := operationGetStart(src, dest)
+	for {
+		if f == nil {
+			break
+		}
+		if w := windowValues(f, l); w != nil {
+			dest.Set(f.t, fn(w))
+		}
+		if f.t.Equal(stop.t) {
+			break
+		}
+		f = f.next
+	}
+	return dest
+}
+
+// generateRecursive applies fn on every rolling window of l values from src along with the previous value of dest and sets the result to dest.
+// prev is nil if dest does not have a value at the previous time of src.
+// It starts from where dest was left off and stops at stop.
+func generateRecursive(stop Value, src, dest ValueSeries, l int, fn func(w []float64, prev *float64) float64) ValueSeries {
+	f := operationGetStart(src, dest)
+	for {
+		if f == nil {
+			break
+		}
+		if w := windowValues(f, l); w != nil {
+			var prev *float64
+			if f.prev != nil {
+				if pv := dest.Get(f.prev.t); pv != nil {
+					prev = NewFloat64(pv.v)
+				}
+			}
+			dest.Set(f.t, fn(w, prev))
+		}
+		if f.t.Equal(stop.t) {
+			break
+		}
+		f = f.next
+	}
+	return dest
+}
+
+// generateOHLCVRecursive applies fn on every OHLCV of o along with the previous value of dest and sets the result to dest.
+// prev is nil if dest does not have a value at the previous OHLCV. No value is set if fn returns nil.
+// It starts from where dest was left off and stops at the current OHLCV.
+func generateOHLCVRecursive(o OHLCVSeries, dest ValueSeries, fn func(cur *OHLCV, prev *float64) *float64) ValueSeries {
+	stop := o.Current()
+	if stop == nil {
+		return dest
+	}
+
+	var cur *OHLCV
+	if last := dest.GetLast(); last != nil {
+		cur = ohlcvAfter(o, last.t)
+	} else {
+		cur = o.GetFirst()
+	}
+
+	for {
+		if cur == nil {
+			break
+		}
+
+		var prev *float64
+		if cur.prev != nil {
+			if pv := dest.Get(cur.prev.S); pv != nil {
+				prev = NewFloat64(pv.v)
+			}
+		}
+		if v := fn(cur, prev); v != nil {
+			dest.Set(cur.S, *v)
+		}
+
+		if cur.S.Equal(stop.S) {
+			break
+		}
+		cur = cur.next
+	}
+
+	return dest
+}
+
+// generateFilter applies fn on every value of src along with the previous n values of dest and sets the result to dest.
+// prev[i] is the value of dest i+1 values ago and it is nil if dest does not have a value at that time.
+// No value is set if fn returns nil.
+// It starts from where dest was left off and stops at stop.
+func generateFilter(stop Value, src, dest ValueSeries, n int, fn func(v *Value, prev []*float64) *float64) ValueSeries {
+	f := operationGetStart(src, dest)
+	prev := make([]*float64, n)
+	for {
+		if f == nil {
+			break
+		}
+		pv := f.prev
+		for i := 0; i < n; i++ {
+			prev[i] = nil
+			if pv == nil {
+				continue
+			}
+			if d := dest.Get(pv.t); d != nil {
+				prev[i] = NewFloat64(d.v)
+			}
+			pv = pv.prev
+		}
+		if v := fn(f, prev); v != nil {
+			dest.Set(f.t, *v)
+		}
+		if f.t.Equal(stop.t) {
+			break
+		}
+		f = f.next
+	}
+	return dest
+}
@@ -0,0 +1,45 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// WMA generates a ValueSeries of weighted moving average.
+// Weights decrease in arithmetical progression, with the most recent value having the weight of l.
+//
+// The formula for WMA is
+//   - wma = sum(p[i] * (l - i)) / sum(l - i) for i in [0, l)
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - l - int64: lookback periods [1, ∞)
+func WMA(p ValueSeries, l int64) ValueSeries {
+	key := fmt.Sprintf("wma:%s:%d", p.ID(), l)
+	wma := getCache(key)
+	if wma == nil {
+		wma = NewValueSeries()
+	}
+
+	if p == nil || p.GetCurrent() == nil {
+		return wma
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+
+	wma = generateWindow(*stop, p, wma, int(l), func(w []float64) float64 {
+		var tot, norm float64
+		for i := range w {
+			weight := float64(i + 1)
+			tot = tot + w[i]*weight
+			norm = norm + weight
+		}
+		return tot / norm
+	})
+
+	setCache(key, wma)
+
+	wma.SetCurrent(stop.t)
+
+	return wma
+}
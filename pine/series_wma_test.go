@@ -0,0 +1,55 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesWMAReference tests WMA(close, 4) against TA-Lib's WMA
+func TestSeriesWMAReference(t *testing.T) {
+	exp := []float64{
+		97.8830, 96.8030, 96.5600, 97.7300, 97.9530, 97.2730, 97.4520, 97.7860,
+		98.6460, 99.9460, 99.7330, 99.3980, 98.9200, 97.9680, 98.2690, 97.6900,
+		97.1830, 97.8140, 97.1050, 96.5390, 96.8020, 97.8260, 99.0870, 100.4470,
+		100.9480, 100.3090, 98.6430, 98.3120, 97.9580, 98.2720, 98.6290, 98.0630,
+		97.9970, 96.4210, 95.3710, 94.7610, 94.4580, 93.6430, 93.0320, 92.1270,
+		91.5080, 90.7660, 89.0150, 87.1930, 86.4070, 87.2140, 86.8310, 86.3150,
+		86.8870, 85.9290, 85.9690, 85.1760, 83.8730, 84.4100, 86.1530, 87.3120,
+		87.7830,
+	}
+
+	assertReference(t, "wma", 3, exp, func(o OHLCVSeries) ValueSeries {
+		return WMA(OHLCVAttr(o, OHLCPropClose), 4)
+	})
+}
+
+// TestSeriesWMAFlat tests that a flat window returns the flat value
+func TestSeriesWMAFlat(t *testing.T) {
+	assertFlat(t, "wma", 3, func(p ValueSeries) ValueSeries {
+		return WMA(p, 4)
+	})
+}
+
+func TestMemoryLeakWMA(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		WMA(prop, 4)
+		return nil
+	})
+}
+
+func ExampleWMA() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		wma := WMA(prop, 4)
+		log.Printf("WMA: %+v", wma.Val())
+	}
+}
@@ -0,0 +1,41 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// WPR generates a ValueSeries of Williams %R.
+// It shows the current closing price in relation to the high and low of the past l bars.
+//
+// The formula for WPR is
+//   - wpr = 100 * (close - highest(high, l)) / (highest(high, l) - lowest(low, l))
+//
+// There is no value where highest(high, l) equals lowest(low, l), i.e. a flat window.
+//
+// The arguments are:
+//   - o: OHLCVSeries - source of data
+//   - l: int64 - lookback periods [1, ∞)
+func WPR(o OHLCVSeries, l int64) ValueSeries {
+	key := fmt.Sprintf("wpr:%s:%d", o.ID(), l)
+	wpr := getCache(key)
+	if wpr == nil {
+		wpr = NewValueSeries()
+	}
+
+	stop := o.Current()
+	if stop == nil {
+		return wpr
+	}
+
+	c := OHLCVAttr(o, OHLCPropClose)
+	hh := Highest(OHLCVAttr(o, OHLCPropHigh), l)
+	ll := Lowest(OHLCVAttr(o, OHLCPropLow), l)
+
+	wpr = MulConst(divNonZero(Sub(c, hh), Sub(hh, ll)), 100)
+
+	setCache(key, wpr)
+
+	wpr.SetCurrent(stop.S)
+
+	return wpr
+}
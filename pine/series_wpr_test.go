@@ -0,0 +1,71 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesWPRNoData tests no data scenario
+func TestSeriesWPRNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wpr := WPR(series, 3)
+	if wpr == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if wpr.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *wpr.Val())
+	}
+}
+
+// TestSeriesWPRReference tests WPR(14) against TA-Lib's WILLR on referenceTestData
+func TestSeriesWPRReference(t *testing.T) {
+	exp := []float64{
+		-53.5088, -45.6816, -49.0114, -64.5161, -44.1960, -79.4710, -83.2494, -53.4005,
+		-84.8268, -89.3668, -65.7439, -41.1765, -32.4106, -10.9181, -18.9826, -43.3002,
+		-76.7990, -47.7667, -57.6923, -46.6501, -46.1538, -67.1216, -56.4516, -81.7734,
+		-80.0000, -80.0000, -79.1133, -94.9612, -91.2651, -83.1904, -84.0480, -86.1744,
+		-96.1827, -95.4349, -82.9476, -65.9133, -83.6749, -86.9658, -65.8718, -91.0581,
+		-77.1328, -88.1426, -92.2088, -62.5000, -33.5993, -29.7185, -36.6492,
+	}
+
+	assertReference(t, "wpr", 13, exp, func(o OHLCVSeries) ValueSeries {
+		return WPR(o, 14)
+	})
+}
+
+// TestSeriesWPRFlat tests that a flat window has no value instead of dividing zero by zero
+func TestSeriesWPRFlat(t *testing.T) {
+	assertFlatOHLCV(t, "wpr", 0, nil, func(o OHLCVSeries) ValueSeries {
+		return WPR(o, 3)
+	})
+}
+
+func TestMemoryLeakWPR(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		WPR(o, 3)
+		return nil
+	})
+}
+
+func ExampleWPR() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		wpr := WPR(series, 3)
+		log.Printf("WPR: %+v", wpr.Val())
+	}
+}
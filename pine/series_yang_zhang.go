@@ -0,0 +1,61 @@
+package pine
+
+import (
+	"fmt"
+	"math"
+)
+
+// YangZhang generates a ValueSeries of Yang-Zhang volatility estimator.
+// It combines the overnight volatility, the open to close volatility and Rogers-Satchell volatility so that it is unbiased with both the drift and the opening jumps.
+//
+// The formula for YangZhang is
+//   - overnight = variance(ln(open / close[1]), l)
+//   - openclose = variance(ln(close / open), l)
+//   - k = 0.34 / (1.34 + (l + 1) / (l - 1))
+//   - yangzhang = sqrt((overnight + k * openclose + (1 - k) * rogerssatchell) * ppy)
+//
+// where variance is the sample variance with the denominator of l - 1 and rogerssatchell is the variance of RogersSatchell.
+// The first value is generated at the (l+1)th OHLCV since the overnight return requires the previous close.
+//
+// The arguments are:
+//   - o: OHLCVSeries - source of data
+//   - l: int64 - lookback periods [2, ∞)
+//   - ppy: float64 - OHLCVs per year to annualize the volatility. See PeriodsPerYear. Use 1 for the volatility per OHLCV
+func YangZhang(o OHLCVSeries, l int64, ppy float64) ValueSeries {
+	key := fmt.Sprintf("yangzhang:%s:%d:%+v", o.ID(), l, ppy)
+	yz := getCache(key)
+	if yz == nil {
+		yz = NewValueSeries()
+	}
+
+	stop := o.Current()
+	if stop == nil || l < 2 {
+		return yz
+	}
+
+	overnight := ohlcvTerm(o, "yangzhangovernight", func(cur *OHLCV) *float64 {
+		if cur.prev == nil {
+			return nil
+		}
+		return NewFloat64(math.Log(cur.O / cur.prev.C))
+	})
+	openclose := ohlcvTerm(o, "yangzhangopenclose", func(cur *OHLCV) *float64 {
+		return NewFloat64(math.Log(cur.C / cur.O))
+	})
+
+	n := float64(l)
+	k := 0.34 / (1.34 + (n+1)/(n-1))
+
+	vari := Add(
+		Add(Variance(overnight, l), MulConst(Variance(openclose, l), k)),
+		MulConst(rogersSatchellVariance(o, l), 1-k),
+	)
+
+	yz = annualizedVolatility(vari, ppy)
+
+	setCache(key, yz)
+
+	yz.SetCurrent(stop.S)
+
+	return yz
+}
@@ -0,0 +1,94 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesYangZhangReference tests YangZhang(10, 252) against the formula composed of TA-Lib's VAR and SMA on referenceTestData
+func TestSeriesYangZhangReference(t *testing.T) {
+	assertReference(t, "yangzhang", 10, []float64{
+		0.3585, 0.3522, 0.3821, 0.3643, 0.3580, 0.3705, 0.3833, 0.3921,
+		0.4099, 0.4205, 0.4288, 0.4376, 0.4162, 0.4345, 0.4354, 0.4218,
+		0.4035, 0.3918, 0.3860, 0.3900, 0.3789, 0.3490, 0.3569, 0.3261,
+		0.3294, 0.3491, 0.3801, 0.4038, 0.4001, 0.3827, 0.3938, 0.4102,
+		0.4350, 0.4339, 0.4491, 0.4521, 0.4342, 0.4333, 0.4437, 0.4549,
+		0.4505, 0.4775, 0.4579, 0.4758, 0.4643, 0.4701, 0.4557, 0.4688,
+		0.4742, 0.4838,
+	}, func(o OHLCVSeries) ValueSeries {
+		return YangZhang(o, 10, 252)
+	})
+}
+
+// TestSeriesYangZhangFlat tests that flat OHLCVs without opening jumps have no volatility
+func TestSeriesYangZhangFlat(t *testing.T) {
+	assertFlatOHLCV(t, "yangzhang", 3, NewFloat64(0), func(o OHLCVSeries) ValueSeries {
+		return YangZhang(o, 3, 252)
+	})
+}
+
+// TestSeriesYangZhangShortLookback tests that the sample variance requires at least two OHLCVs
+func TestSeriesYangZhangShortLookback(t *testing.T) {
+	assertFlatOHLCV(t, "yangzhang", 0, nil, func(o OHLCVSeries) ValueSeries {
+		return YangZhang(o, 1, 252)
+	})
+}
+
+// TestSeriesYangZhangSourceTrimmed tests that YangZhang resumes from the first remaining OHLCV
+// when the last OHLCV it was generated for is trimmed from the source between calls
+func TestSeriesYangZhangSourceTrimmed(t *testing.T) {
+	data := referenceTestData()
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 12; i++ {
+		series.Next()
+		YangZhang(series, 10, 252)
+	}
+
+	// skip the 13th to 16th OHLCV and trim the first 4
+	for i := 12; i < 16; i++ {
+		series.Next()
+	}
+	series.SetMax(int64(len(data) - 4))
+
+	// the remaining OHLCVs are expected to be generated as if the series started at the 5th OHLCV
+	fresh, err := NewOHLCVSeries(data[4:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 4; i < 15; i++ {
+		fresh.Next()
+	}
+
+	for i := 15; i < len(data); i++ {
+		fresh.Next()
+		yangzhang := YangZhang(series, 10, 252)
+		assertSeriesVal(t, "yangzhang", i, YangZhang(fresh, 10, 252).Val(), yangzhang)
+		series.Next()
+	}
+}
+
+func TestMemoryLeakYangZhang(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		YangZhang(o, 3, 252)
+		return nil
+	})
+}
+
+func ExampleYangZhang() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		yz := YangZhang(series, 3, 252)
+		log.Printf("YangZhang: %+v", yz.Val())
+	}
+}
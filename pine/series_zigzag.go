@@ -0,0 +1,227 @@
+package pine
+
+import (
+	"fmt"
+	"time"
+)
+
+// ZigZagDeviation is the minimum price movement from the extreme of the current leg to confirm a swing.
+// Use ZigZagPercent or ZigZagATR to create one.
+type ZigZagDeviation struct {
+	percent float64
+	mult    float64
+	atrl    int64
+}
+
+// ZigZagPercent confirms a swing when the price reverses by pct percent of the extreme
+func ZigZagPercent(pct float64) ZigZagDeviation {
+	return ZigZagDeviation{percent: pct}
+}
+
+// ZigZagATR confirms a swing when the price reverses by mult times ATR of atrl lookback periods
+func ZigZagATR(mult float64, atrl int64) ZigZagDeviation {
+	return ZigZagDeviation{mult: mult, atrl: atrl}
+}
+
+func (z ZigZagDeviation) key() string {
+	return fmt.Sprintf("%v:%v:%d", z.percent, z.mult, z.atrl)
+}
+
+// ZigZag generates ValueSeries of the zigzag's confirmed swing, the current leg and the direction in that order.
+//
+// A leg extends while the price makes new extremes in its direction.
+// Once the price reverses from the extreme by the deviation, the extreme is confirmed as a swing and a new leg starts in the opposite direction.
+//
+// The return values are:
+//   - swing: ValueSeries - price of the swing confirmed at the OHLCV. There are no values where no swing was confirmed. Use ZigZagSwingTime for the time of the swing
+//   - leg: ValueSeries - price of the extreme of the current leg. It repaints as the leg extends
+//   - dir: ValueSeries - 1 if the current leg is up and -1 if it is down
+//
+// The arguments are:
+//   - o: OHLCVSeries - source of data
+//   - dev: ZigZagDeviation - minimum reversal to confirm a swing
+func ZigZag(o OHLCVSeries, dev ZigZagDeviation) (swing, leg, dir ValueSeries) {
+	zz := getZigZag(o, dev)
+	return zz.swing, zz.leg, zz.dir
+}
+
+// ZigZagSwingTime returns the time of the swing confirmed at the current OHLCV.
+// nil is returned if no swing was confirmed at the current OHLCV.
+func ZigZagSwingTime(o OHLCVSeries, dev ZigZagDeviation) *time.Time {
+	getZigZag(o, dev)
+	stop := o.Current()
+	s := zigZagCache[zigZagStateKey(o, dev)]
+	if stop == nil || s == nil || s.swingt.IsZero() || !s.confirmt.Equal(stop.S) {
+		return nil
+	}
+	t := s.swingt
+	return &t
+}
+
+// zigZagSeries are the series generated by ZigZag
+type zigZagSeries struct {
+	swing, leg, dir ValueSeries
+	// the last three confirmed swings where p3 is the latest
+	p1, p2, p3 ValueSeries
+}
+
+// zigZagState is the state of the zigzag carried over to the next call
+type zigZagState struct {
+	dir int
+	// extreme of the current leg. It is the highest high before the direction is determined
+	ext  float64
+	extt time.Time
+	// lowest low before the direction is determined
+	alt  float64
+	altt time.Time
+	// the last three confirmed swings where swings[2] is the latest
+	swings []float64
+	// time of the latest confirmed swing and the OHLCV where it was confirmed
+	swingt   time.Time
+	confirmt time.Time
+	// time of the last processed OHLCV
+	last time.Time
+}
+
+var zigZagCache map[string]*zigZagState = make(map[string]*zigZagState)
+
+func zigZagStateKey(o OHLCVSeries, dev ZigZagDeviation) string {
+	return fmt.Sprintf("zigzag:%s:%s", o.ID(), dev.key())
+}
+
+func getZigZag(o OHLCVSeries, dev ZigZagDeviation) zigZagSeries {
+	zz := zigZagSeries{}
+	keys := make([]string, 0)
+	series := []*ValueSeries{&zz.swing, &zz.leg, &zz.dir, &zz.p1, &zz.p2, &zz.p3}
+	for i, name := range []string{"swing", "leg", "dir", "p1", "p2", "p3"} {
+		key := fmt.Sprintf("zigzag%s:%s:%s", name, o.ID(), dev.key())
+		vs := getCache(key)
+		if vs == nil {
+			vs = NewValueSeries()
+		}
+		*series[i] = vs
+		keys = append(keys, key)
+	}
+
+	stop := o.Current()
+	if stop == nil {
+		return zz
+	}
+
+	var atr ValueSeries
+	if dev.percent == 0 {
+		atr = ATR(OHLCVAttr(o, OHLCPropTRHL), dev.atrl)
+	}
+
+	// deviation from the price at the OHLCV
+	deviation := func(price float64, cur *OHLCV) (float64, bool) {
+		if atr == nil {
+			return price * dev.percent / 100, true
+		}
+		v := atr.Get(cur.S)
+		if v == nil {
+			return 0, false
+		}
+		return v.v * dev.mult, true
+	}
+
+	statekey := zigZagStateKey(o, dev)
+	s := zigZagCache[statekey]
+
+	var cur *OHLCV
+	if s == nil {
+		s = &zigZagState{swings: make([]float64, 0)}
+		cur = o.GetFirst()
+	} else {
+		cur = ohlcvAfter(o, s.last)
+	}
+
+	for {
+		if cur == nil || cur.S.After(stop.S) {
+			break
+		}
+
+		var swingt *time.Time
+		var swingv float64
+
+		// confirm records v at t as a swing and starts a new leg in the direction of dir from ext
+		confirm := func(v float64, t time.Time, dir int, ext float64) {
+			swingv = v
+			swingt = &t
+			s.swings = append(s.swings, v)
+			if len(s.swings) > 3 {
+				s.swings = s.swings[1:]
+			}
+			s.dir = dir
+			s.ext = ext
+			s.extt = cur.S
+		}
+
+		switch s.dir {
+		case 0:
+			if s.last.IsZero() {
+				s.ext, s.extt = cur.H, cur.S
+				s.alt, s.altt = cur.L, cur.S
+				break
+			}
+			if cur.H > s.ext {
+				s.ext, s.extt = cur.H, cur.S
+			}
+			if cur.L < s.alt {
+				s.alt, s.altt = cur.L, cur.S
+			}
+			if d, ok := deviation(s.alt, cur); ok && s.altt.Before(cur.S) && cur.H >= s.alt+d {
+				confirm(s.alt, s.altt, 1, cur.H)
+			} else if d, ok := deviation(s.ext, cur); ok && s.extt.Before(cur.S) && cur.L <= s.ext-d {
+				confirm(s.ext, s.extt, -1, cur.L)
+			}
+		case 1:
+			if cur.H > s.ext {
+				s.ext, s.extt = cur.H, cur.S
+			} else if d, ok := deviation(s.ext, cur); ok && cur.L <= s.ext-d {
+				confirm(s.ext, s.extt, -1, cur.L)
+			}
+		case -1:
+			if cur.L < s.ext {
+				s.ext, s.extt = cur.L, cur.S
+			} else if d, ok := deviation(s.ext, cur); ok && cur.H >= s.ext+d {
+				confirm(s.ext, s.extt, 1, cur.H)
+			}
+		}
+
+		if swingt != nil {
+			zz.swing.Set(cur.S, swingv)
+			s.swingt = *swingt
+			s.confirmt = cur.S
+		}
+		if s.dir != 0 {
+			zz.leg.Set(cur.S, s.ext)
+			zz.dir.Set(cur.S, float64(s.dir))
+		}
+		if n := len(s.swings); n > 0 {
+			zz.p3.Set(cur.S, s.swings[n-1])
+			if n > 1 {
+				zz.p2.Set(cur.S, s.swings[n-2])
+			}
+			if n > 2 {
+				zz.p1.Set(cur.S, s.swings[n-3])
+			}
+		}
+
+		s.last = cur.S
+
+		if cur.S.Equal(stop.S) {
+			break
+		}
+		cur = cur.next
+	}
+
+	setState(zigZagCache, statekey, s)
+
+	for i, vs := range series {
+		(*vs).SetCurrent(stop.S)
+		setCache(keys[i], *vs)
+	}
+
+	return zz
+}
@@ -0,0 +1,73 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// ZigZagFibRetracement generates a ValueSeries of the Fibonacci retracement level of the last confirmed zigzag swing.
+// The level is 0 at the last swing and 1 at the swing before it.
+//
+// The formula for ZigZagFibRetracement is
+//   - level = swing - (swing - swing[1]) * ratio
+//
+// where swing is the last confirmed swing and swing[1] is the one before it.
+//
+// The arguments are:
+//   - o: OHLCVSeries - source of data
+//   - dev: ZigZagDeviation - minimum reversal to confirm a swing
+//   - ratio: float64 - Fibonacci ratio i.e. 0.382, 0.5, 0.618
+func ZigZagFibRetracement(o OHLCVSeries, dev ZigZagDeviation, ratio float64) ValueSeries {
+	key := fmt.Sprintf("zigzagfibretracement:%s:%s:%v", o.ID(), dev.key(), ratio)
+	level := getCache(key)
+	if level == nil {
+		level = NewValueSeries()
+	}
+
+	stop := o.Current()
+	if stop == nil {
+		return level
+	}
+
+	zz := getZigZag(o, dev)
+	level = Sub(zz.p3, MulConst(Sub(zz.p3, zz.p2), ratio))
+
+	level.SetCurrent(stop.S)
+
+	setCache(key, level)
+
+	return level
+}
+
+// ZigZagFibExtension generates a ValueSeries of the trend-based Fibonacci extension level of the last three confirmed zigzag swings.
+// The move between the first two swings is projected from the last swing.
+//
+// The formula for ZigZagFibExtension is
+//   - level = swing + (swing[1] - swing[2]) * ratio
+//
+// where swing is the last confirmed swing, swing[1] is the one before it and so on.
+//
+// The arguments are:
+//   - o: OHLCVSeries - source of data
+//   - dev: ZigZagDeviation - minimum reversal to confirm a swing
+//   - ratio: float64 - Fibonacci ratio i.e. 0.618, 1, 1.618
+func ZigZagFibExtension(o OHLCVSeries, dev ZigZagDeviation, ratio float64) ValueSeries {
+	key := fmt.Sprintf("zigzagfibextension:%s:%s:%v", o.ID(), dev.key(), ratio)
+	level := getCache(key)
+	if level == nil {
+		level = NewValueSeries()
+	}
+
+	stop := o.Current()
+	if stop == nil {
+		return level
+	}
+
+	zz := getZigZag(o, dev)
+	level = Add(zz.p3, MulConst(Sub(zz.p2, zz.p1), ratio))
+
+	level.SetCurrent(stop.S)
+
+	setCache(key, level)
+
+	return level
+}
@@ -0,0 +1,113 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesZigZagFibNoData tests no data scenario
+func TestSeriesZigZagFibNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	retracement := ZigZagFibRetracement(series, ZigZagPercent(30), 0.618)
+	extension := ZigZagFibExtension(series, ZigZagPercent(30), 1.618)
+	if retracement == nil || extension == nil {
+		t.Fatal("Expected to be non nil but got nil")
+	}
+	if retracement.Val() != nil || extension.Val() != nil {
+		t.Error("Expected to be nil but got non nil")
+	}
+}
+
+// TestSeriesZigZagFibIteration tests the 0.618 retracement and the 1.618 extension of the zigzag with the deviation of 30% against values of the reference algorithm
+func TestSeriesZigZagFibIteration(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// array in order of retracement, extension
+	tests := [][]*float64{
+		{nil, nil},
+		{nil, nil},
+		{NewFloat64(14.1560), nil},
+		{NewFloat64(15.7384), NewFloat64(23.2440)},
+		{NewFloat64(13.8526), NewFloat64(5.3616)},
+		{NewFloat64(16.3912), NewFloat64(26.2474)},
+		{NewFloat64(14.4852), NewFloat64(6.2088)},
+		{NewFloat64(14.4852), NewFloat64(6.2088)},
+		{NewFloat64(16.1710), NewFloat64(24.2148)},
+		{NewFloat64(13.6234), NewFloat64(3.6290)},
+	}
+
+	for i, v := range tests {
+		series.Next()
+		retracement := ZigZagFibRetracement(series, ZigZagPercent(30), 0.618)
+		extension := ZigZagFibExtension(series, ZigZagPercent(30), 1.618)
+		assertSeriesVal(t, "retracement", i, v[0], retracement)
+		assertSeriesVal(t, "extension", i, v[1], extension)
+	}
+}
+
+// TestSeriesZigZagFibATR tests the levels of the zigzag with the deviation of ATR against values of the reference algorithm
+func TestSeriesZigZagFibATR(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// array in order of retracement, extension
+	tests := [][]*float64{
+		{nil, nil},
+		{nil, nil},
+		{nil, nil},
+		{NewFloat64(16.1092), nil},
+		{NewFloat64(13.8526), NewFloat64(4.3908)},
+		{NewFloat64(16.3912), NewFloat64(26.2474)},
+		{NewFloat64(16.3912), NewFloat64(26.2474)},
+		{NewFloat64(16.3912), NewFloat64(26.2474)},
+		{NewFloat64(16.3912), NewFloat64(26.2474)},
+		{NewFloat64(14.5234), NewFloat64(6.3088)},
+	}
+
+	for i, v := range tests {
+		series.Next()
+		retracement := ZigZagFibRetracement(series, ZigZagATR(1, 3), 0.618)
+		extension := ZigZagFibExtension(series, ZigZagATR(1, 3), 1.618)
+		assertSeriesVal(t, "retracement", i, v[0], retracement)
+		assertSeriesVal(t, "extension", i, v[1], extension)
+	}
+}
+
+func TestMemoryLeakZigZagFib(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		ZigZagFibRetracement(o, ZigZagPercent(30), 0.618)
+		ZigZagFibExtension(o, ZigZagPercent(30), 1.618)
+		return nil
+	})
+}
+
+func ExampleZigZagFibRetracement() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		retracement := ZigZagFibRetracement(series, ZigZagPercent(30), 0.618)
+		extension := ZigZagFibExtension(series, ZigZagPercent(30), 1.618)
+		log.Printf("retracement: %+v, extension: %+v", retracement.Val(), extension.Val())
+	}
+}
@@ -0,0 +1,182 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesZigZagNoData tests no data scenario
+func TestSeriesZigZagNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	swing, leg, dir := ZigZag(series, ZigZagPercent(30))
+	if swing == nil || leg == nil || dir == nil {
+		t.Fatal("Expected to be non nil but got nil")
+	}
+	if swing.Val() != nil || leg.Val() != nil || dir.Val() != nil {
+		t.Error("Expected to be nil but got non nil")
+	}
+}
+
+// TestSeriesZigZagIteration tests the output with the deviation of 30% against values of the reference algorithm
+func TestSeriesZigZagIteration(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// array in order of swing, leg, dir
+	tests := [][]*float64{
+		{nil, nil, nil},
+		{NewFloat64(11.1000), NewFloat64(19.1000), NewFloat64(1.0000)},
+		{NewFloat64(19.1000), NewFloat64(10.3000), NewFloat64(-1.0000)},
+		{NewFloat64(10.3000), NewFloat64(19.6000), NewFloat64(1.0000)},
+		{NewFloat64(19.6000), NewFloat64(11.2000), NewFloat64(-1.0000)},
+		{NewFloat64(11.2000), NewFloat64(19.8000), NewFloat64(1.0000)},
+		{NewFloat64(19.8000), NewFloat64(12.9000), NewFloat64(-1.0000)},
+		{nil, NewFloat64(10.3000), NewFloat64(-1.0000)},
+		{NewFloat64(10.3000), NewFloat64(19.0000), NewFloat64(1.0000)},
+		{NewFloat64(19.0000), NewFloat64(10.0000), NewFloat64(-1.0000)},
+	}
+
+	for i, v := range tests {
+		series.Next()
+		swing, leg, dir := ZigZag(series, ZigZagPercent(30))
+		assertSeriesVal(t, "swing", i, v[0], swing)
+		assertSeriesVal(t, "leg", i, v[1], leg)
+		assertSeriesVal(t, "dir", i, v[2], dir)
+	}
+}
+
+// TestSeriesZigZagATR tests the output with the deviation of ATR against values of the reference algorithm
+//
+// The up leg extends from the 6th to the 9th OHLCV as the reversals are smaller than ATR
+func TestSeriesZigZagATR(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// array in order of swing, leg, dir
+	tests := [][]*float64{
+		{nil, nil, nil},
+		{nil, nil, nil},
+		{NewFloat64(19.7000), NewFloat64(10.3000), NewFloat64(-1.0000)},
+		{NewFloat64(10.3000), NewFloat64(19.6000), NewFloat64(1.0000)},
+		{NewFloat64(19.6000), NewFloat64(11.2000), NewFloat64(-1.0000)},
+		{NewFloat64(11.2000), NewFloat64(19.8000), NewFloat64(1.0000)},
+		{nil, NewFloat64(19.8000), NewFloat64(1.0000)},
+		{nil, NewFloat64(19.9000), NewFloat64(1.0000)},
+		{nil, NewFloat64(19.9000), NewFloat64(1.0000)},
+		{NewFloat64(19.9000), NewFloat64(10.0000), NewFloat64(-1.0000)},
+	}
+
+	for i, v := range tests {
+		series.Next()
+		swing, leg, dir := ZigZag(series, ZigZagATR(1, 3))
+		assertSeriesVal(t, "swing", i, v[0], swing)
+		assertSeriesVal(t, "leg", i, v[1], leg)
+		assertSeriesVal(t, "dir", i, v[2], dir)
+	}
+}
+
+// TestSeriesZigZagSwingTime tests that the time of the confirmed swing is returned
+func TestSeriesZigZagSwingTime(t *testing.T) {
+	data := OHLCVStaticTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// index of the OHLCV of the swing, -1 if no swing was confirmed
+	tests := []int{-1, -1, 0, 2, 3, 4, -1, -1, -1, 7}
+
+	for i, v := range tests {
+		series.Next()
+		st := ZigZagSwingTime(series, ZigZagATR(1, 3))
+		if v == -1 {
+			if st != nil {
+				t.Errorf("Expected to be nil but got %+v for iteration: %d", *st, i)
+			}
+			continue
+		}
+		if st == nil {
+			t.Fatalf("Expected %+v but got nil for iteration: %d", data[v].S, i)
+		}
+		if !st.Equal(data[v].S) {
+			t.Errorf("Expected %+v but got %+v for iteration: %d", data[v].S, *st, i)
+		}
+	}
+}
+
+// TestSeriesZigZagSourceTrimmed tests that ZigZag resumes from the first remaining OHLCV
+// when the last OHLCV it was generated for is trimmed from the source between calls
+func TestSeriesZigZagSourceTrimmed(t *testing.T) {
+	data := OHLCVStaticTestData()
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		series.Next()
+		ZigZag(series, ZigZagPercent(30))
+	}
+
+	// skip the 4th to 7th OHLCV and trim the first 4
+	for i := 3; i < 7; i++ {
+		series.Next()
+	}
+	series.SetMax(int64(len(data) - 4))
+
+	// the remaining OHLCVs are expected to continue as if the 4th OHLCV, which was never processed, did not exist
+	rest := append(append([]OHLCV{}, data[:3]...), data[4:]...)
+	expected, err := NewOHLCVSeries(rest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		expected.Next()
+		ZigZag(expected, ZigZagPercent(30))
+	}
+
+	for i := 6; i < len(data); i++ {
+		expected.Next()
+		_, expleg, expdir := ZigZag(expected, ZigZagPercent(30))
+		_, leg, dir := ZigZag(series, ZigZagPercent(30))
+		assertSeriesVal(t, "leg", i, expleg.Val(), leg)
+		assertSeriesVal(t, "dir", i, expdir.Val(), dir)
+		series.Next()
+	}
+}
+
+func TestMemoryLeakZigZag(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		ZigZag(o, ZigZagPercent(30))
+		return nil
+	})
+}
+
+func ExampleZigZag() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		swing, leg, dir := ZigZag(series, ZigZagPercent(30))
+		log.Printf("swing: %+v, leg: %+v, dir: %+v", swing.Val(), leg.Val(), dir.Val())
+	}
+}
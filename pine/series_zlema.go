@@ -0,0 +1,40 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// ZLEMA generates a ValueSeries of zero lag exponential moving average.
+// It removes the lag of EMA by adding the momentum of the lag period to the source.
+//
+// The formula for ZLEMA is
+//   - lag = (l - 1) / 2
+//   - zlema = ema(p + (p - p[lag]), l)
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - l - int64: lookback periods [1, ∞)
+func ZLEMA(p ValueSeries, l int64) ValueSeries {
+	key := fmt.Sprintf("zlema:%s:%d", p.ID(), l)
+	zlema := getCache(key)
+	if zlema == nil {
+		zlema = NewValueSeries()
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+	if stop == nil {
+		return zlema
+	}
+
+	lag := int((l - 1) / 2)
+	delagged := Add(p, Change(p, lag))
+
+	zlema = EMA(delagged, l)
+
+	setCache(key, zlema)
+
+	zlema.SetCurrent(stop.t)
+
+	return zlema
+}
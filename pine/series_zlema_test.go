@@ -0,0 +1,61 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesZLEMAReference tests ZLEMA(close, 4) against TA-Lib's EMA(2 * close - close[1], 4) as the lag of length 4 is 1
+func TestSeriesZLEMAReference(t *testing.T) {
+	exp := []float64{
+		95.7750, 96.3690, 98.8414, 97.5088, 96.2093, 97.7216, 98.3529, 99.3038,
+		100.7503, 99.0182, 98.8909, 98.5905, 97.3463, 98.8358, 97.1815, 96.8129,
+		98.6077, 96.4486, 95.9932, 97.2959, 99.0695, 99.8897, 101.1338, 100.8323,
+		99.3434, 97.0740, 98.6644, 98.0427, 98.7016, 98.7730, 97.4478, 98.0167,
+		95.0140, 94.8564, 94.6898, 94.6619, 92.8891, 92.7175, 91.6065, 91.3559,
+		90.3095, 87.6377, 86.0186, 86.5552, 88.6651, 86.2671, 85.6562, 87.7137,
+		85.1442, 86.0745, 84.4487, 83.0132, 85.3479, 88.0768, 87.8101, 87.3980,
+	}
+
+	assertReference(t, "zlema", 4, exp, func(o OHLCVSeries) ValueSeries {
+		return ZLEMA(OHLCVAttr(o, OHLCPropClose), 4)
+	})
+}
+
+// TestSeriesZLEMAFlat tests that a flat window returns the flat value
+func TestSeriesZLEMAFlat(t *testing.T) {
+	assertFlat(t, "zlema", 4, func(p ValueSeries) ValueSeries {
+		return ZLEMA(p, 4)
+	})
+}
+
+// TestSeriesZLEMASourceTrimmed tests that trimming the source to 6 values does not change the output
+func TestSeriesZLEMASourceTrimmed(t *testing.T) {
+	assertSourceTrimmed(t, "zlema", 6, func(p ValueSeries) ValueSeries {
+		return ZLEMA(p, 4)
+	})
+}
+
+func TestMemoryLeakZLEMA(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		ZLEMA(prop, 4)
+		return nil
+	})
+}
+
+func ExampleZLEMA() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		zlema := ZLEMA(prop, 4)
+		log.Printf("ZLEMA: %+v", zlema.Val())
+	}
+}
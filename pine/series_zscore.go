@@ -0,0 +1,39 @@
+package pine
+
+import (
+	"fmt"
+)
+
+// ZScore generates a ValueSeries of the number of standard deviations the current value is away from the mean.
+// It uses the population (biased) standard deviation and is calculated from the running sums of Covariance.
+// There is no value if the window is flat since the standard deviation is 0.
+//
+// The formula for z-score is
+//   - z = (p - sma(p, l)) / stdev(p, l)
+//
+// Parameters
+//   - p - ValueSeries: source data
+//   - l - int: lookback periods [1, ∞)
+func ZScore(p ValueSeries, l int) ValueSeries {
+	key := fmt.Sprintf("zscore:%s:%d", p.ID(), l)
+	z := getCache(key)
+	if z == nil {
+		z = NewValueSeries()
+	}
+
+	// current available value
+	stop := p.GetCurrent()
+	if stop == nil {
+		return z
+	}
+
+	cv := getCovariance(p, p, l)
+
+	z = divNonZero(Sub(p, cv.meana), cv.stdeva)
+
+	setCache(key, z)
+
+	z.SetCurrent(stop.t)
+
+	return z
+}
@@ -0,0 +1,114 @@
+package pine
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+// TestSeriesZScoreNoData tests no data scenario
+func TestSeriesZScoreNoData(t *testing.T) {
+
+	start := time.Now()
+	data := OHLCVTestData(start, 4, 5*60*1000)
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prop := OHLCVAttr(series, OHLCPropClose)
+	zscore := ZScore(prop, 4)
+	if zscore == nil {
+		t.Error("Expected to be non nil but got nil")
+	}
+	if zscore.Val() != nil {
+		t.Errorf("Expected to be nil but got %+v", *zscore.Val())
+	}
+}
+
+// TestSeriesZScoreReference tests ZScore(10) against (close - sma(close, 10)) / stdev(close, 10) with TA-Lib's SMA and STDDEV on referenceTestData
+func TestSeriesZScoreReference(t *testing.T) {
+	exp := []float64{
+		-0.0559, 0.6916, 1.3921, 1.8610, 0.3600, 0.1738, -0.1696, -1.0379,
+		0.3350, -1.4720, -1.4343, 0.2839, -1.4172, -1.5494, -0.0094, 1.5837,
+		1.7115, 1.8268, 1.2617, 0.2117, -1.1156, 0.0520, -0.4728, -0.1937,
+		-0.2726, -1.1689, -0.4886, -2.1845, -1.7154, -1.3645, -1.1284, -1.6867,
+		-1.3247, -1.4965, -1.3231, -1.5043, -2.2541, -1.9902, -1.3100, -0.2872,
+		-1.1914, -1.1572, 0.0840, -1.2201, -0.3889, -1.4461, -1.8123, -0.0511,
+		1.4378, 1.1808, 0.7121,
+	}
+
+	assertReference(t, "zscore", 9, exp, func(o OHLCVSeries) ValueSeries {
+		return ZScore(OHLCVAttr(o, OHLCPropClose), 10)
+	})
+}
+
+// TestSeriesZScoreHighLevel tests ZScore(1e6 + close / 1000, 10) against the values of TestSeriesZScoreReference since z-score does not depend on the level and the scale,
+// where the mean is far larger than the spread of the window
+func TestSeriesZScoreHighLevel(t *testing.T) {
+	exp := []float64{
+		-0.0559, 0.6916, 1.3921, 1.8610, 0.3600, 0.1738, -0.1696, -1.0379,
+		0.3350, -1.4720, -1.4343, 0.2839, -1.4172, -1.5494, -0.0094, 1.5837,
+		1.7115, 1.8268, 1.2617, 0.2117, -1.1156, 0.0520, -0.4728, -0.1937,
+		-0.2726, -1.1689, -0.4886, -2.1845, -1.7154, -1.3645, -1.1284, -1.6867,
+		-1.3247, -1.4965, -1.3231, -1.5043, -2.2541, -1.9902, -1.3100, -0.2872,
+		-1.1914, -1.1572, 0.0840, -1.2201, -0.3889, -1.4461, -1.8123, -0.0511,
+		1.4378, 1.1808, 0.7121,
+	}
+
+	assertReference(t, "zscore", 9, exp, func(o OHLCVSeries) ValueSeries {
+		return ZScore(AddConst(DivConst(OHLCVAttr(o, OHLCPropClose), 1000), 1e6), 10)
+	})
+}
+
+// TestSeriesZScoreFlat tests that a flat window has no value instead of dividing by zero,
+// including a flat window right after a volatile one that may leave rounding errors in the running sums
+//
+// t=time.Time     | 1    | 2    | 3      | 4       | 5    | 6    |
+// p=ValueSeries   | 10.1 | 13.7 | 12.5   | 12.5    | 12.5 | 12.5 |
+// zscore(p, 3)    |      |      | 0.2673 | -0.7071 |      |      |
+func TestSeriesZScoreFlat(t *testing.T) {
+	start := time.Now()
+	data := OHLCVTestData(start, 6, 5*60*1000)
+	for i, v := range []float64{10.1, 13.7, 12.5, 12.5, 12.5, 12.5} {
+		data[i].C = v
+	}
+
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []*float64{nil, nil, NewFloat64(0.2673), NewFloat64(-0.7071), nil, nil}
+
+	for i, v := range tests {
+		series.Next()
+		prop := OHLCVAttr(series, OHLCPropClose)
+		z := ZScore(prop, 3)
+		assertSeriesVal(t, "zscore", i, v, z)
+	}
+}
+
+func TestMemoryLeakZScore(t *testing.T) {
+	testMemoryLeak(t, func(o OHLCVSeries) error {
+		prop := OHLCVAttr(o, OHLCPropClose)
+		ZScore(prop, 4)
+		return nil
+	})
+}
+
+func ExampleZScore() {
+	start := time.Now()
+	data := OHLCVTestData(start, 10000, 5*60*1000)
+	series, _ := NewOHLCVSeries(data)
+	for {
+		if v, _ := series.Next(); v == nil {
+			break
+		}
+
+		prop := OHLCVAttr(series, OHLCPropClose)
+		zscore := ZScore(prop, 4)
+		log.Printf("ZScore: %+v", zscore.Val())
+	}
+}
@@ -0,0 +1,98 @@
+package pine
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// sortedWindow keeps the values of a rolling window in both arrival order and sorted order.
+// Order statistics such as median and percentiles can then be read without sorting the window every time.
+type sortedWindow struct {
+	// values in arrival order
+	fifo []float64
+	// values in ascending order except NaN
+	sorted []float64
+	// number of NaN in the window, which are kept out of sorted since they cannot be ordered
+	nan int
+	// time of the last pushed value
+	last time.Time
+}
+
+func newSortedWindow() *sortedWindow {
+	return &sortedWindow{
+		fifo:   make([]float64, 0),
+		sorted: make([]float64, 0),
+	}
+}
+
+// push appends v at t and evicts values that are older than l items
+func (w *sortedWindow) push(t time.Time, v float64, l int) {
+	w.fifo = append(w.fifo, v)
+	if math.IsNaN(v) {
+		w.nan++
+	} else {
+		i := sort.SearchFloat64s(w.sorted, v)
+		w.sorted = append(w.sorted, 0)
+		copy(w.sorted[i+1:], w.sorted[i:])
+		w.sorted[i] = v
+	}
+	w.last = t
+
+	for len(w.fifo) > l {
+		old := w.fifo[0]
+		w.fifo = w.fifo[1:]
+		if math.IsNaN(old) {
+			w.nan--
+			continue
+		}
+		j := sort.SearchFloat64s(w.sorted, old)
+		w.sorted = append(w.sorted[:j], w.sorted[j+1:]...)
+	}
+}
+
+// countLTE returns the number of values less than or equal to v
+func (w *sortedWindow) countLTE(v float64) int {
+	return sort.Search(len(w.sorted), func(i int) bool {
+		return w.sorted[i] > v
+	})
+}
+
+var sortedWindowCache map[string]*sortedWindow = make(map[string]*sortedWindow)
+
+// generateSorted applies fn on every rolling window of l values from src using a sorted window and sets the result to dest.
+// No value is set for a window that contains NaN.
+// The sorted window is cached with key so that it is carried over to the next call.
+func generateSorted(stop Value, src, dest ValueSeries, key string, l int, fn func(w *sortedWindow) float64) ValueSeries {
+	w := sortedWindowCache[key]
+
+	var f *Value
+	if w == nil {
+		w = newSortedWindow()
+		f = src.GetFirst()
+	} else if v := src.Get(w.last); v != nil {
+		f = v.next
+	} else {
+		// the source was trimmed beyond the window, start over
+		w = newSortedWindow()
+		f = src.GetFirst()
+	}
+
+	for {
+		if f == nil {
+			break
+		}
+		w.push(f.t, f.v, l)
+		if len(w.fifo) == l && w.nan == 0 {
+			dest.Set(f.t, fn(w))
+		}
+		if f.t.Equal(stop.t) {
+			break
+		}
+		f = f.next
+	}
+
+	setState(sortedWindowCache, key, w)
+
+	return dest
+}
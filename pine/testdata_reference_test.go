@@ -0,0 +1,125 @@
+package pine
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// referenceTestData returns 60 bars of a random walk (seeded, rounded to cents) whose indicator values are
+// computed with TA-Lib through its Go port github.com/markcheno/go-talib at v0.0.0-20250114000313-ec55a20c902f.
+// Indicators TA-Lib does not provide are composed from TA-Lib functions as described in each test.
+func referenceTestData() []OHLCV {
+	start := time.Now()
+	data := []OHLCV{
+		{O: 100.27, H: 104.96, L: 99.85, C: 103.05, V: 1447},
+		{O: 102.35, H: 102.63, L: 98.07, C: 99.52, V: 1153},
+		{O: 99.95, H: 100.58, L: 98.42, C: 98.58, V: 1572},
+		{O: 97.77, H: 98.58, L: 93.56, C: 95.25, V: 823},
+		{O: 95.41, H: 97.07, L: 93.78, C: 96.40, V: 1488},
+		{O: 97.62, H: 97.70, L: 95.66, C: 96.83, V: 800},
+		{O: 97.49, H: 100.34, L: 96.33, C: 99.69, V: 1120},
+		{O: 99.10, H: 99.32, L: 96.20, C: 97.60, V: 1393},
+		{O: 97.51, H: 97.94, L: 95.83, C: 95.93, V: 1219},
+		{O: 97.41, H: 98.45, L: 96.23, C: 97.96, V: 1655},
+		{O: 99.30, H: 100.76, L: 98.12, C: 98.63, V: 1180},
+		{O: 98.71, H: 101.29, L: 97.52, C: 99.68, V: 1935},
+		{O: 98.99, H: 103.17, L: 98.39, C: 101.30, V: 1937},
+		{O: 101.27, H: 102.53, L: 98.26, C: 98.86, V: 1651},
+		{O: 99.81, H: 100.33, L: 97.56, C: 98.78, V: 1103},
+		{O: 99.52, H: 100.64, L: 96.75, C: 98.46, V: 1956},
+		{O: 99.07, H: 100.97, L: 95.93, C: 96.97, V: 1313},
+		{O: 96.38, H: 100.17, L: 95.85, C: 99.02, V: 1226},
+		{O: 97.72, H: 97.96, L: 95.23, C: 96.86, V: 1998},
+		{O: 98.23, H: 99.94, L: 96.14, C: 96.56, V: 1792},
+		{O: 97.63, H: 100.91, L: 97.25, C: 98.93, V: 1353},
+		{O: 98.96, H: 99.91, L: 94.80, C: 96.07, V: 1014},
+		{O: 96.51, H: 98.01, L: 95.65, C: 95.69, V: 891},
+		{O: 94.56, H: 99.27, L: 94.50, C: 97.47, V: 1320},
+		{O: 97.97, H: 100.92, L: 97.85, C: 99.60, V: 1891},
+		{O: 99.73, H: 101.02, L: 98.67, C: 100.36, V: 1946},
+		{O: 99.67, H: 102.56, L: 98.95, C: 101.68, V: 1352},
+		{O: 100.73, H: 102.18, L: 100.04, C: 101.03, V: 1890},
+		{O: 101.45, H: 101.51, L: 98.01, C: 99.07, V: 1036},
+		{O: 98.57, H: 99.61, L: 94.78, C: 96.37, V: 1085},
+		{O: 97.84, H: 98.83, L: 96.86, C: 98.71, V: 948},
+		{O: 99.20, H: 99.26, L: 97.68, C: 97.91, V: 1435},
+		{O: 99.06, H: 100.88, L: 97.81, C: 98.80, V: 849},
+		{O: 99.27, H: 100.65, L: 98.22, C: 98.84, V: 1630},
+		{O: 99.15, H: 99.89, L: 96.13, C: 97.15, V: 1165},
+		{O: 96.75, H: 99.84, L: 95.83, C: 98.01, V: 1503},
+		{O: 96.98, H: 98.11, L: 92.41, C: 94.26, V: 1651},
+		{O: 95.55, H: 97.51, L: 92.85, C: 94.44, V: 1217},
+		{O: 93.94, H: 95.30, L: 93.65, C: 94.44, V: 1882},
+		{O: 94.94, H: 96.89, L: 94.49, C: 94.53, V: 1645},
+		{O: 93.27, H: 94.84, L: 91.86, C: 92.38, V: 1085},
+		{O: 93.62, H: 94.67, L: 91.55, C: 92.42, V: 1055},
+		{O: 92.96, H: 94.64, L: 89.22, C: 91.18, V: 1330},
+		{O: 91.73, H: 91.79, L: 89.91, C: 91.08, V: 1102},
+		{O: 91.22, H: 92.70, L: 88.15, C: 89.91, V: 1546},
+		{O: 89.68, H: 91.01, L: 86.21, C: 86.77, V: 1225},
+		{O: 88.00, H: 88.78, L: 84.44, C: 85.18, V: 1589},
+		{O: 84.85, H: 87.62, L: 83.47, C: 86.27, V: 1899},
+		{O: 86.84, H: 89.39, L: 85.97, C: 89.05, V: 1294},
+		{O: 87.75, H: 88.42, L: 84.91, C: 85.86, V: 1014},
+		{O: 85.05, H: 85.93, L: 83.55, C: 85.30, V: 1047},
+		{O: 85.52, H: 89.58, L: 84.07, C: 88.05, V: 1857},
+		{O: 87.17, H: 87.25, L: 83.57, C: 84.67, V: 1776},
+		{O: 85.78, H: 87.72, L: 84.20, C: 86.07, V: 1673},
+		{O: 85.22, H: 85.45, L: 82.61, C: 84.04, V: 1259},
+		{O: 83.84, H: 85.84, L: 81.42, C: 82.45, V: 1797},
+		{O: 82.87, H: 86.20, L: 82.61, C: 85.65, V: 1661},
+		{O: 86.47, H: 90.11, L: 84.94, C: 88.91, V: 1220},
+		{O: 87.51, H: 90.07, L: 87.39, C: 88.16, V: 882},
+		{O: 89.20, H: 90.97, L: 86.75, C: 87.47, V: 1235},
+	}
+
+	for i := range data {
+		data[i].S = start.Add(time.Duration(i) * time.Minute)
+	}
+	return data
+}
+
+// assertReference iterates referenceTestData and compares the value of fn at each bar with exp, which holds the
+// reference values from the bar at start. Bars before start are expected to be nil.
+func assertReference(t *testing.T, name string, start int, exp []float64, fn func(o OHLCVSeries) ValueSeries) {
+	t.Helper()
+	compareReference(t, name, start, exp, true, fn)
+}
+
+// assertReferenceRange is like assertReference but only compares the bars from start to start + len(exp) - 1.
+// It is for indicators whose start up differs from the reference by design.
+func assertReferenceRange(t *testing.T, name string, start int, exp []float64, fn func(o OHLCVSeries) ValueSeries) {
+	t.Helper()
+	compareReference(t, name, start, exp, false, fn)
+}
+
+func compareReference(t *testing.T, name string, start int, exp []float64, nilBefore bool, fn func(o OHLCVSeries) ValueSeries) {
+	t.Helper()
+	data := referenceTestData()
+	series, err := NewOHLCVSeries(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range data {
+		series.Next()
+		got := fn(series).Val()
+		if i < start {
+			if nilBefore && got != nil {
+				t.Errorf("Expected %s to be nil but got %+v for iteration: %d", name, *got, i)
+			}
+			continue
+		}
+		if i-start >= len(exp) {
+			break
+		}
+		if got == nil {
+			t.Errorf("Expected %s to be %+v but got nil for iteration: %d", name, exp[i-start], i)
+			continue
+		}
+		if math.Abs(*got-exp[i-start]) > 1e-4 {
+			t.Errorf("Expected %s to be %+v but got %+v for iteration: %d", name, exp[i-start], *got, i)
+		}
+	}
+}
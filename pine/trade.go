@@ -0,0 +1,30 @@
+package pine
+
+import "time"
+
+// TradeSide is the aggressor side of a trade
+type TradeSide int
+
+const (
+	// TradeSideUnknown is a trade whose aggressor is not known
+	TradeSideUnknown TradeSide = iota
+	// TradeSideBuy is a trade where the buyer took the liquidity
+	TradeSideBuy
+	// TradeSideSell is a trade where the seller took the liquidity
+	TradeSideSell
+)
+
+// Trade is an execution tick
+type Trade struct {
+	// P is the price
+	P float64
+	// V is the size
+	V float64
+	// T is the time of the execution
+	T time.Time
+	// Side is the aggressor side
+	Side TradeSide
+
+	prev *Trade
+	next *Trade
+}
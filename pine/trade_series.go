@@ -0,0 +1,145 @@
+package pine
+
+import (
+	"github.com/twinj/uuid"
+)
+
+// TradeSeries represents a series of trades.
+//
+// Unlike OHLCVSeries, trades are not looked up by time so that multiple trades can have the same time.
+// The developer is responsible for providing the trades in chronological order.
+// Use a BarBuilder to aggregate the trades into an OHLCVSeries.
+type TradeSeries interface {
+	ID() string
+
+	Push(Trade)
+
+	Shift() bool
+
+	Len() int
+
+	// Current returns current trade
+	Current() *Trade
+
+	// GetFirst returns first trade
+	GetFirst() *Trade
+
+	// GetLast returns last trade
+	GetLast() *Trade
+
+	// Next moves the pointer to the next one.
+	// If there is no next item, nil is returned and the pointer does not advance.
+	Next() *Trade
+
+	// set the maximum number of trades. This helps prevent high memory usage.
+	SetMax(int64)
+}
+
+// NewTradeSeries generates a series of trades
+func NewTradeSeries(trades []Trade) TradeSeries {
+	u := uuid.NewV4()
+	s := &tradeSeries{
+		id:  u.String(),
+		max: 1000, // default maximum items
+	}
+	for _, v := range trades {
+		s.Push(v)
+	}
+	return s
+}
+
+type tradeSeries struct {
+	// current trade
+	cur *Trade
+
+	id string
+
+	first *Trade
+
+	last *Trade
+
+	len int
+
+	// max number of trades. 0 means no limit. Defaults to 1000
+	max int64
+}
+
+func (s *tradeSeries) Push(t Trade) {
+	t.prev, t.next = nil, nil
+	if s.last != nil {
+		t.prev = s.last
+		s.last.next = &t
+	}
+	s.last = &t
+	if s.first == nil {
+		s.first = &t
+	}
+	s.len++
+	s.resize()
+}
+
+func (s *tradeSeries) Shift() bool {
+	if s.first == nil {
+		return false
+	}
+	if s.last == s.first {
+		s.last = nil
+	}
+	s.first = s.first.next
+	if s.first != nil {
+		s.first.prev = nil
+	}
+	s.len--
+	return true
+}
+
+func (s *tradeSeries) Len() int {
+	return s.len
+}
+
+func (s *tradeSeries) Current() *Trade {
+	return s.cur
+}
+
+func (s *tradeSeries) GetFirst() *Trade {
+	return s.first
+}
+
+func (s *tradeSeries) GetLast() *Trade {
+	return s.last
+}
+
+func (s *tradeSeries) ID() string {
+	return s.id
+}
+
+func (s *tradeSeries) Next() *Trade {
+	if s.cur == nil {
+		// set first one if nil
+		s.cur = s.first
+		return s.cur
+	}
+	if s.cur.next == nil {
+		return nil
+	}
+	s.cur = s.cur.next
+	return s.cur
+}
+
+func (s *tradeSeries) SetMax(m int64) {
+	s.max = m
+	s.resize()
+}
+
+func (s *tradeSeries) resize() {
+	// set to unlimited, nothing to perform
+	if s.max == 0 {
+		return
+	}
+	for {
+		if int64(s.Len()) <= s.max {
+			break
+		}
+		s.Shift()
+	}
+}
@@ -0,0 +1,102 @@
+package pine
+
+import (
+	"testing"
+	"time"
+)
+
+// tradeTestData generates trades in the order of seconds from the start, price, size and side
+//
+// seconds | 0  | 20 | 40 | 70 | 80 | 130 | 150 | 185 |
+// price   | 10 | 11 | 9  | 12 | 11 | 13  | 12  | 14  |
+// size    | 1  | 2  | 1  | 3  | 1  | 2   | 1   | 1   |
+// side    | B  | S  | B  | B  | S  | S   | B   | B   |
+func tradeTestData() []Trade {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	b, s := TradeSideBuy, TradeSideSell
+	data := []struct {
+		sec  int
+		p    float64
+		v    float64
+		side TradeSide
+	}{
+		{0, 10, 1, b},
+		{20, 11, 2, s},
+		{40, 9, 1, b},
+		{70, 12, 3, b},
+		{80, 11, 1, s},
+		{130, 13, 2, s},
+		{150, 12, 1, b},
+		{185, 14, 1, b},
+	}
+
+	trades := make([]Trade, 0)
+	for _, v := range data {
+		trades = append(trades, Trade{
+			P:    v.p,
+			V:    v.v,
+			T:    start.Add(time.Duration(v.sec) * time.Second),
+			Side: v.side,
+		})
+	}
+	return trades
+}
+
+func TestNewTradeSeriesPush(t *testing.T) {
+	data := tradeTestData()
+	s := NewTradeSeries(nil)
+
+	for i, v := range data {
+		s.Push(v)
+
+		if s.Len() != i+1 {
+			t.Errorf("expected len of %d but got %d", i+1, s.Len())
+		}
+	}
+
+	for i := range data {
+		v := s.Next()
+		if v.P != data[i].P {
+			t.Errorf("expected %+v but got %+v", data[i].P, v.P)
+		}
+	}
+
+	if v := s.Next(); v != nil {
+		t.Errorf("expected nil but got %+v", v)
+	}
+	if s.Current().P != data[len(data)-1].P {
+		t.Errorf("expected %+v but got %+v", data[len(data)-1].P, s.Current().P)
+	}
+}
+
+func TestNewTradeSeriesSameTime(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewTradeSeries([]Trade{
+		{P: 10, V: 1, T: start},
+		{P: 11, V: 1, T: start},
+	})
+
+	if s.Len() != 2 {
+		t.Errorf("expected len of %d but got %d", 2, s.Len())
+	}
+	if s.GetFirst().P != 10 || s.GetLast().P != 11 {
+		t.Errorf("expected 10 and 11 but got %+v and %+v", s.GetFirst().P, s.GetLast().P)
+	}
+}
+
+func TestNewTradeSeriesMaxResize(t *testing.T) {
+	data := tradeTestData()
+	s := NewTradeSeries(data)
+	s.SetMax(3)
+
+	if s.Len() != 3 {
+		t.Errorf("expected len of %d but got %d", 3, s.Len())
+	}
+
+	for i := 0; i < 3; i++ {
+		v := s.Next()
+		if v.P != data[i+5].P {
+			t.Errorf("expected %+v but got %+v", data[i+5].P, v.P)
+		}
+	}
+}
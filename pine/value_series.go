@@ -186,3 +186,50 @@ func (s *valueSeries) Shift() bool {
 	}
 	return true
 }
+
+// pop removes the last item
+func (s *valueSeries) pop() bool {
+	if s.last == nil {
+		return false
+	}
+	delete(s.timemap, s.last.t.Unix())
+	if s.cur == s.last {
+		s.cur = nil
+	}
+	if s.first == s.last {
+		s.first = nil
+	}
+	s.last = s.last.prev
+	if s.last != nil {
+		s.last.next = nil
+	}
+	return true
+}
+
+// insert sets the value at t in the order of time, unlike Set which appends to the end
+func (s *valueSeries) insert(t time.Time, val float64) {
+	if s.last == nil || s.getValue(t.Unix()) != nil || s.last.t.Before(t) {
+		s.Set(t, val)
+		return
+	}
+
+	v := &Value{
+		t: t,
+		v: val,
+	}
+	// the value after which v is inserted. nil inserts v at the beginning
+	p := s.last
+	for p != nil && t.Before(p.t) {
+		p = p.prev
+	}
+	v.prev = p
+	if p != nil {
+		v.next = p.next
+		p.next = v
+	} else {
+		v.next = s.first
+		s.first = v
+	}
+	v.next.prev = v
+	s.setValue(t.Unix(), v)
+}
@@ -402,6 +402,35 @@ func TestValueSeriesGetFirst(t *testing.T) {
 	}
 }
 
+func TestValueSeriesInsert(t *testing.T) {
+	s := NewValueSeries().(*valueSeries)
+	now := time.Now()
+	s.Set(now.Add(2*time.Second), 3)
+	s.Set(now.Add(4*time.Second), 5)
+	s.insert(now.Add(3*time.Second), 4)
+	s.insert(now, 1)
+	s.insert(now.Add(5*time.Second), 6)
+	s.insert(now.Add(2*time.Second), 30)
+
+	exp := []float64{1, 30, 4, 5, 6}
+	v := s.GetFirst()
+	for i, e := range exp {
+		if v == nil || v.v != e {
+			t.Fatalf("expected %+v but got %+v for index: %d", e, v, i)
+		}
+		if v.next != nil && v.next.prev != v {
+			t.Errorf("expected prev of the next value to be linked for index: %d", i)
+		}
+		v = v.next
+	}
+	if v != nil {
+		t.Errorf("expected the end of the series but got %+v", *v)
+	}
+	if s.GetLast().v != 6 {
+		t.Errorf("expected the last value to be 6 but got %+v", s.GetLast().v)
+	}
+}
+
 func TestMemoryLeakArithmetic(t *testing.T) {
 	v := 4.2351
 
@@ -0,0 +1,54 @@
+package pine
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// PeriodsPerYear returns the number of OHLCVs in a year, which annualizes the volatility estimators.
+//
+// The arguments are:
+//   - interval: time.Duration - trading time covered by each OHLCV, i.e. 5 * time.Minute. A daily OHLCV covers a whole session
+//   - session: time.Duration - trading time of a day, i.e. 24 * time.Hour for cryptocurrencies or 390 * time.Minute for US equities
+//   - days: float64 - trading days in a year, i.e. 365 for cryptocurrencies or 252 for US equities
+func PeriodsPerYear(interval, session time.Duration, days float64) float64 {
+	if interval <= 0 {
+		return 0
+	}
+	return days * float64(session) / float64(interval)
+}
+
+// annualizedVolatility converts a ValueSeries of variance per OHLCV into the annualized volatility.
+// The volatility is per OHLCV if ppy is 1.
+func annualizedVolatility(vari ValueSeries, ppy float64) ValueSeries {
+	key := fmt.Sprintf("annualizedvolatility:%+v", ppy)
+	return operationConst(vari, key, func(v float64) float64 {
+		return math.Sqrt(math.Max(v, 0) * ppy)
+	}, true)
+}
+
+// ohlcvTerm generates a cached ValueSeries of fn(OHLCV) for every OHLCV under the name.
+// No value is set if fn returns nil, i.e. the previous OHLCV is required.
+func ohlcvTerm(o OHLCVSeries, name string, fn func(cur *OHLCV) *float64) ValueSeries {
+	key := fmt.Sprintf("%s:%s", name, o.ID())
+	term := getCache(key)
+	if term == nil {
+		term = NewValueSeries()
+	}
+
+	stop := o.Current()
+	if stop == nil {
+		return term
+	}
+
+	term = generateOHLCVRecursive(o, term, func(cur *OHLCV, _ *float64) *float64 {
+		return fn(cur)
+	})
+
+	setCache(key, term)
+
+	term.SetCurrent(stop.S)
+
+	return term
+}
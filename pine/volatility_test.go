@@ -0,0 +1,28 @@
+package pine
+
+import (
+	"testing"
+	"time"
+)
+
+// TestPeriodsPerYear tests the number of OHLCVs in a year of typical markets
+func TestPeriodsPerYear(t *testing.T) {
+	testTable := []struct {
+		name     string
+		interval time.Duration
+		session  time.Duration
+		days     float64
+		exp      float64
+	}{
+		{name: "daily equities", interval: 390 * time.Minute, session: 390 * time.Minute, days: 252, exp: 252},
+		{name: "5 minute equities", interval: 5 * time.Minute, session: 390 * time.Minute, days: 252, exp: 19656},
+		{name: "hourly cryptocurrencies", interval: time.Hour, session: 24 * time.Hour, days: 365, exp: 8760},
+		{name: "no interval", interval: 0, session: 24 * time.Hour, days: 365, exp: 0},
+	}
+
+	for _, v := range testTable {
+		if got := PeriodsPerYear(v.interval, v.session, v.days); got != v.exp {
+			t.Errorf("Expected %s to be %+v but got %+v", v.name, v.exp, got)
+		}
+	}
+}